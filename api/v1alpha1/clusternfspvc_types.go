@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterNfsPvcSpec defines the desired state of ClusterNfsPvc.
+type ClusterNfsPvcSpec struct {
+	// namespaceSelector selects the namespaces an NfsPvc is materialized in.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+
+	// template describes the NfsPvc materialized in every selected namespace.
+	// The NfsPvcs are named after the ClusterNfsPvc.
+	Template NfsPvcTemplateSpec `json:"template"`
+}
+
+// ClusterNfsPvcStatus defines the observed state of ClusterNfsPvc.
+type ClusterNfsPvcStatus struct {
+	// observedGeneration is the most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// namespaces lists the namespaces the NfsPvc is materialized in.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// failedNamespaces lists the selected namespaces the NfsPvc could not be materialized in.
+	// +optional
+	FailedNamespaces []string `json:"failedNamespaces,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Server",type=string,JSONPath=`.spec.template.spec.server`
+// +kubebuilder:printcolumn:name="Path",type=string,JSONPath=`.spec.template.spec.path`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterNfsPvc is the Schema for the clusternfspvcs API
+type ClusterNfsPvc struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterNfsPvcSpec   `json:"spec,omitempty"`
+	Status ClusterNfsPvcStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterNfsPvcList contains a list of ClusterNfsPvc
+type ClusterNfsPvcList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterNfsPvc `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterNfsPvc{}, &ClusterNfsPvcList{})
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InSyncNfsExportPolicyCondition is True when the export policy allows exactly the addresses of the selected nodes.
+const InSyncNfsExportPolicyCondition = "InSync"
+
+// NfsExportPolicyHook is an HTTP endpoint managing export policies on behalf of the operator.
+type NfsExportPolicyHook struct {
+	// url of the hook. The clients of a policy are read with GET <url>/export-policies/<policyName>
+	// and replaced with PUT <url>/export-policies/<policyName>, both with a {"clients": [...]} body.
+	// +kubebuilder:validation:Pattern="^https?://"
+	URL string `json:"url"`
+}
+
+// NfsExportPolicySpec defines the desired state of NfsExportPolicy.
+type NfsExportPolicySpec struct {
+	// policyName is the name of the export policy on the storage array.
+	// +kubebuilder:validation:MinLength=1
+	PolicyName string `json:"policyName"`
+
+	// nodeSelector selects the nodes whose addresses the export policy allows. All the nodes when unset.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// addressTypes are the types of the node addresses the export policy allows.
+	// +kubebuilder:default={"InternalIP"}
+	// +kubebuilder:validation:MinItems=1
+	// +optional
+	AddressTypes []corev1.NodeAddressType `json:"addressTypes,omitempty"`
+
+	// hook manages the export policy through an HTTP endpoint instead of the storage backend of the operator.
+	// +optional
+	Hook *NfsExportPolicyHook `json:"hook,omitempty"`
+
+	// dryRun only reports the drift between the export policy and the nodes, without changing the policy.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// shard is the name of the operator instance that manages the export policy when the operator is
+	// sharded, so that a single instance changes it. The instances of the other shards ignore the policy.
+	// Empty for an operator that is not sharded.
+	// +optional
+	Shard string `json:"shard,omitempty"`
+}
+
+// NfsExportPolicyStatus defines the observed state of NfsExportPolicy.
+type NfsExportPolicyStatus struct {
+	// clients are the addresses of the selected nodes.
+	// +optional
+	Clients []string `json:"clients,omitempty"`
+
+	// missing are the addresses of selected nodes the export policy does not allow.
+	// +optional
+	Missing []string `json:"missing,omitempty"`
+
+	// unexpected are the clients allowed by the export policy that are not addresses of selected nodes.
+	// +optional
+	Unexpected []string `json:"unexpected,omitempty"`
+
+	// lastSyncTime is when the export policy was last compared with the nodes.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// conditions of the export policy, e.g. InSync.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Policy",type=string,JSONPath=`.spec.policyName`
+// +kubebuilder:printcolumn:name="DryRun",type=boolean,JSONPath=`.spec.dryRun`
+// +kubebuilder:printcolumn:name="Shard",type=string,JSONPath=`.spec.shard`,priority=1
+// +kubebuilder:printcolumn:name="InSync",type=string,JSONPath=`.status.conditions[?(@.type=="InSync")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NfsExportPolicy is the Schema for the nfsexportpolicies API
+type NfsExportPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NfsExportPolicySpec   `json:"spec,omitempty"`
+	Status NfsExportPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NfsExportPolicyList contains a list of NfsExportPolicy
+type NfsExportPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NfsExportPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NfsExportPolicy{}, &NfsExportPolicyList{})
+}
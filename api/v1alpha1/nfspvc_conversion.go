@@ -0,0 +1,167 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/dana-team/nfspvc-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConversionDataAnnotation keeps, as JSON, the fields of an nfspvc that the API version it is converted
+// to cannot represent, so that converting it back restores them.
+const ConversionDataAnnotation = "nfspvc.dana.io/conversion-data"
+
+// conversionData are the fields of a v1alpha1 nfspvc that v1beta1 cannot represent.
+type conversionData struct {
+	// Capacity is the capacity of the v1alpha1 nfspvc, when it is not a single storage quantity.
+	Capacity *corev1.ResourceList `json:"capacity,omitempty"`
+}
+
+var _ conversion.Convertible = &NfsPvc{}
+
+// ConvertTo converts the nfspvc to the v1beta1 hub.
+func (src *NfsPvc) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1beta1.NfsPvc)
+	if !ok {
+		return fmt.Errorf("expected a v1beta1 NfsPvc but got %T", dstRaw)
+	}
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	removeConversionData(&dst.ObjectMeta)
+	dst.Spec = v1beta1.NfsPvcSpec{
+		Server:            v1beta1.NfsServerReference{Host: src.Spec.Server, Path: src.Spec.Path},
+		Capacity:          src.Spec.Capacity[corev1.ResourceStorage],
+		AccessModes:       src.Spec.AccessModes,
+		MountOptions:      v1beta1.NfsMountOptions{NfsVersion: src.Spec.NfsVersion},
+		DataSource:        (*v1beta1.NfsPvcDataSource)(src.Spec.DataSource),
+		CreatePath:        (*v1beta1.NfsPvcCreatePath)(src.Spec.CreatePath),
+		MountInstructions: (*v1beta1.NfsPvcMountInstructions)(src.Spec.MountInstructions),
+	}
+	if src.Spec.Security != nil {
+		dst.Spec.MountOptions.Security = &v1beta1.NfsSecurity{
+			Sec:               v1beta1.NfsSecurityFlavor(src.Spec.Security.Sec),
+			TransportSecurity: v1beta1.NfsTransportSecurity(src.Spec.Security.TransportSecurity),
+		}
+	}
+	dst.Status = v1beta1.NfsPvcStatus{
+		PVCPhase:          corev1.PersistentVolumeClaimPhase(src.Status.PvcPhase),
+		PVPhase:           corev1.PersistentVolumePhase(src.Status.PvPhase),
+		NegotiatedVersion: src.Status.NegotiatedVersion,
+		Path:              src.Status.Path,
+		Usage:             (*v1beta1.NfsPvcUsage)(src.Status.Usage),
+		Conditions:        src.Status.Conditions,
+	}
+	for _, diagnostic := range src.Status.Diagnostics {
+		dst.Status.Diagnostics = append(dst.Status.Diagnostics, v1beta1.NfsPvcDiagnostic(diagnostic))
+	}
+
+	data := conversionData{}
+	if _, ok := src.Spec.Capacity[corev1.ResourceStorage]; !ok || len(src.Spec.Capacity) != 1 {
+		// an empty list, unlike a nil one, is kept in the annotation
+		capacity := corev1.ResourceList{}
+		maps.Copy(capacity, src.Spec.Capacity)
+		data.Capacity = &capacity
+	}
+	return setConversionData(dst, data)
+}
+
+// ConvertFrom converts the v1beta1 hub to the nfspvc.
+func (dst *NfsPvc) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1beta1.NfsPvc)
+	if !ok {
+		return fmt.Errorf("expected a v1beta1 NfsPvc but got %T", srcRaw)
+	}
+	data, err := takeConversionData(src)
+	if err != nil {
+		return err
+	}
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	removeConversionData(&dst.ObjectMeta)
+	dst.Spec = NfsPvcSpec{
+		Server:            src.Spec.Server.Host,
+		Path:              src.Spec.Server.Path,
+		Capacity:          corev1.ResourceList{corev1.ResourceStorage: src.Spec.Capacity},
+		AccessModes:       src.Spec.AccessModes,
+		NfsVersion:        src.Spec.MountOptions.NfsVersion,
+		DataSource:        (*NfsPvcDataSource)(src.Spec.DataSource),
+		CreatePath:        (*NfsPvcCreatePath)(src.Spec.CreatePath),
+		MountInstructions: (*NfsPvcMountInstructions)(src.Spec.MountInstructions),
+	}
+	if data.Capacity != nil {
+		dst.Spec.Capacity = *data.Capacity
+	}
+	if security := src.Spec.MountOptions.Security; security != nil {
+		dst.Spec.Security = &NfsSecurity{
+			Sec:               NfsSecurityFlavor(security.Sec),
+			TransportSecurity: NfsTransportSecurity(security.TransportSecurity),
+		}
+	}
+	dst.Status = NfsPvcStatus{
+		PvcPhase:          string(src.Status.PVCPhase),
+		PvPhase:           string(src.Status.PVPhase),
+		NegotiatedVersion: src.Status.NegotiatedVersion,
+		Path:              src.Status.Path,
+		Usage:             (*NfsPvcUsage)(src.Status.Usage),
+		Conditions:        src.Status.Conditions,
+	}
+	for _, diagnostic := range src.Status.Diagnostics {
+		dst.Status.Diagnostics = append(dst.Status.Diagnostics, NfsPvcDiagnostic(diagnostic))
+	}
+	return nil
+}
+
+// setConversionData keeps the data in the annotation of the nfspvc, unless it is empty.
+func setConversionData(nfspvc *v1beta1.NfsPvc, data conversionData) error {
+	if data.Capacity == nil {
+		return nil
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode the conversion data: %v", err)
+	}
+	if nfspvc.Annotations == nil {
+		nfspvc.Annotations = map[string]string{}
+	}
+	nfspvc.Annotations[ConversionDataAnnotation] = string(encoded)
+	return nil
+}
+
+// removeConversionData removes the annotation of the conversion data from the metadata.
+func removeConversionData(meta *metav1.ObjectMeta) {
+	delete(meta.Annotations, ConversionDataAnnotation)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+}
+
+// takeConversionData returns the data kept in the annotation of the nfspvc.
+func takeConversionData(nfspvc *v1beta1.NfsPvc) (conversionData, error) {
+	data := conversionData{}
+	encoded, ok := nfspvc.Annotations[ConversionDataAnnotation]
+	if !ok {
+		return data, nil
+	}
+	if err := json.Unmarshal([]byte(encoded), &data); err != nil {
+		return data, fmt.Errorf("failed to decode the %s annotation: %v", ConversionDataAnnotation, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,149 @@
+package v1alpha1
+
+import (
+	"math/rand"
+	"reflect"
+
+	"github.com/dana-team/nfspvc-operator/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+	"sigs.k8s.io/randfill"
+)
+
+// fuzzIterations is the number of random nfspvcs each round trip is checked with.
+const fuzzIterations = 1000
+
+// nfspvcFuzzerFuncs fill the quantities with valid values, which the default filler does not.
+func nfspvcFuzzerFuncs(_ serializer.CodecFactory) []interface{} {
+	return []interface{}{
+		func(quantity *resource.Quantity, c randfill.Continue) {
+			*quantity = *resource.NewQuantity(c.Int63n(1<<40), resource.BinarySI)
+		},
+	}
+}
+
+// fieldPaths adds the path of every field reachable from the value to visited, and the paths of the
+// fields that are set to filled.
+func fieldPaths(value reflect.Value, path string, visited, filled sets.Set[string]) {
+	visited.Insert(path)
+	if value.IsZero() {
+		return
+	}
+	filled.Insert(path)
+	switch value.Kind() {
+	case reflect.Pointer:
+		fieldPaths(value.Elem(), path, visited, filled)
+	case reflect.Slice:
+		for i := range value.Len() {
+			fieldPaths(value.Index(i), path+"[]", visited, filled)
+		}
+	case reflect.Struct:
+		for i := range value.NumField() {
+			if field := value.Type().Field(i); field.IsExported() {
+				fieldPaths(value.Field(i), path+"."+field.Name, visited, filled)
+			}
+		}
+	}
+}
+
+var _ = Describe("NfsPvc conversion", func() {
+	var (
+		scheme *runtime.Scheme
+		filler *randfill.Filler
+		// fullFiller sets every field, unlike filler which also leaves some of them empty.
+		fullFiller *randfill.Filler
+	)
+
+	// fill fills the object with the filler on even iterations and with the full filler on odd ones.
+	fill := func(i int, obj any) {
+		if i%2 == 0 {
+			filler.Fill(obj)
+		} else {
+			fullFiller.Fill(obj)
+		}
+	}
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(AddToScheme(scheme)).To(Succeed())
+		Expect(v1beta1.AddToScheme(scheme)).To(Succeed())
+		funcs := fuzzer.MergeFuzzerFuncs(metafuzzer.Funcs, nfspvcFuzzerFuncs)
+		filler = fuzzer.FuzzerFor(funcs, rand.NewSource(GinkgoRandomSeed()), serializer.NewCodecFactory(scheme))
+		fullFiller = fuzzer.FuzzerFor(funcs, rand.NewSource(GinkgoRandomSeed()), serializer.NewCodecFactory(scheme)).
+			NilChance(0).NumElements(1, 3)
+	})
+
+	It("should fill every field of the nfspvcs it round-trips", func() {
+		visited, filled := sets.New[string](), sets.New[string]()
+		for range fuzzIterations {
+			alpha := &NfsPvc{}
+			fullFiller.Fill(alpha)
+			fieldPaths(reflect.ValueOf(alpha.Spec), "v1alpha1.spec", visited, filled)
+			fieldPaths(reflect.ValueOf(alpha.Status), "v1alpha1.status", visited, filled)
+			beta := &v1beta1.NfsPvc{}
+			fullFiller.Fill(beta)
+			fieldPaths(reflect.ValueOf(beta.Spec), "v1beta1.spec", visited, filled)
+			fieldPaths(reflect.ValueOf(beta.Status), "v1beta1.status", visited, filled)
+		}
+		Expect(sets.List(visited.Difference(filled))).To(BeEmpty())
+	})
+
+	It("should be served by the conversion webhook", func() {
+		Expect(conversion.IsConvertible(scheme, &NfsPvc{})).To(BeTrue())
+	})
+
+	It("should round-trip v1alpha1 nfspvcs through v1beta1 losslessly", func() {
+		for i := range fuzzIterations {
+			original := &NfsPvc{}
+			fill(i, original)
+			hub := &v1beta1.NfsPvc{}
+			Expect(original.DeepCopy().ConvertTo(hub)).To(Succeed())
+			converted := &NfsPvc{}
+			Expect(converted.ConvertFrom(hub)).To(Succeed())
+			Expect(equality.Semantic.DeepEqual(original.ObjectMeta, converted.ObjectMeta)).To(BeTrue(), diff.Diff(original.ObjectMeta, converted.ObjectMeta))
+			Expect(equality.Semantic.DeepEqual(original.Spec, converted.Spec)).To(BeTrue(), diff.Diff(original.Spec, converted.Spec))
+			Expect(equality.Semantic.DeepEqual(original.Status, converted.Status)).To(BeTrue(), diff.Diff(original.Status, converted.Status))
+		}
+	})
+
+	It("should round-trip v1beta1 nfspvcs through v1alpha1 losslessly", func() {
+		for i := range fuzzIterations {
+			original := &v1beta1.NfsPvc{}
+			fill(i, original)
+			spoke := &NfsPvc{}
+			Expect(spoke.ConvertFrom(original.DeepCopy())).To(Succeed())
+			converted := &v1beta1.NfsPvc{}
+			Expect(spoke.ConvertTo(converted)).To(Succeed())
+			Expect(equality.Semantic.DeepEqual(original.ObjectMeta, converted.ObjectMeta)).To(BeTrue(), diff.Diff(original.ObjectMeta, converted.ObjectMeta))
+			Expect(equality.Semantic.DeepEqual(original.Spec, converted.Spec)).To(BeTrue(), diff.Diff(original.Spec, converted.Spec))
+			Expect(equality.Semantic.DeepEqual(original.Status, converted.Status)).To(BeTrue(), diff.Diff(original.Status, converted.Status))
+		}
+	})
+
+	It("should keep the capacity v1beta1 cannot represent in an annotation", func() {
+		original := &NfsPvc{Spec: NfsPvcSpec{Server: "nas", Path: "/exports/data", Capacity: corev1.ResourceList{
+			corev1.ResourceStorage:          resource.MustParse("10Gi"),
+			corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
+		}}}
+		hub := &v1beta1.NfsPvc{}
+		Expect(original.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Spec.Server).To(Equal(v1beta1.NfsServerReference{Host: "nas", Path: "/exports/data"}))
+		Expect(hub.Spec.Capacity.String()).To(Equal("10Gi"))
+		Expect(hub.Annotations).To(HaveKey(ConversionDataAnnotation))
+
+		converted := &NfsPvc{}
+		Expect(converted.ConvertFrom(hub)).To(Succeed())
+		Expect(converted.Annotations).NotTo(HaveKey(ConversionDataAnnotation))
+		Expect(converted.Spec.Capacity).To(HaveLen(2))
+	})
+})
@@ -21,6 +21,85 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// NfsSecurityFlavor is the RPC security flavor used to mount an NFS export.
+// +kubebuilder:validation:Enum=sys;krb5;krb5i;krb5p
+type NfsSecurityFlavor string
+
+const (
+	SysNfsSecurityFlavor   NfsSecurityFlavor = "sys"
+	Krb5NfsSecurityFlavor  NfsSecurityFlavor = "krb5"
+	Krb5iNfsSecurityFlavor NfsSecurityFlavor = "krb5i"
+	Krb5pNfsSecurityFlavor NfsSecurityFlavor = "krb5p"
+)
+
+// NfsTransportSecurity is the transport layer security used to mount an NFS export.
+// +kubebuilder:validation:Enum=none;tls;mtls
+type NfsTransportSecurity string
+
+const (
+	NoneNfsTransportSecurity NfsTransportSecurity = "none"
+	TLSNfsTransportSecurity  NfsTransportSecurity = "tls"
+	MTLSNfsTransportSecurity NfsTransportSecurity = "mtls"
+)
+
+// NfsSecurity defines the security modes of an NFS mount.
+type NfsSecurity struct {
+	// sec is the RPC security flavor, rendered as the sec mount option.
+	// +kubebuilder:default=sys
+	// +optional
+	Sec NfsSecurityFlavor `json:"sec,omitempty"`
+
+	// transportSecurity is the RPC-with-TLS mode, rendered as the xprtsec mount option.
+	// +kubebuilder:default=none
+	// +optional
+	TransportSecurity NfsTransportSecurity `json:"transportSecurity,omitempty"`
+}
+
+// NfsPvcDataSource is an NfsPvc whose data populates a new NfsPvc.
+type NfsPvcDataSource struct {
+	// name of the source NfsPvc.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// namespace of the source NfsPvc, the namespace of the new NfsPvc when empty. A source
+	// in another namespace must grant the clone by listing the namespace of the new NfsPvc
+	// in its nfspvc.dana.io/clone-to annotation.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// NfsPvcCreatePath is the ownership and the mode of an export subdirectory created by the operator.
+type NfsPvcCreatePath struct {
+	// uid owning the directory, the uid of the Job creating it when unset.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	UID *int64 `json:"uid,omitempty"`
+
+	// gid owning the directory, the gid of the Job creating it when unset.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GID *int64 `json:"gid,omitempty"`
+
+	// mode of the directory in octal, e.g. "0775".
+	// +kubebuilder:validation:Pattern="^0?[0-7]{3,4}$"
+	// +kubebuilder:default="0755"
+	// +optional
+	Mode string `json:"mode,omitempty"`
+}
+
+// NfsPvcMountInstructions is where and how the consumers outside of Kubernetes mount the export.
+type NfsPvcMountInstructions struct {
+	// mountPoint is the directory the export is mounted on, without whitespace or control characters.
+	// +kubebuilder:validation:Pattern="^/[^[:space:][:cntrl:]]*$"
+	MountPoint string `json:"mountPoint"`
+
+	// options are added to the mount options of the PV, e.g. _netdev or hard, each without whitespace,
+	// commas or control characters.
+	// +kubebuilder:validation:items:Pattern="^[^[:space:][:cntrl:],]+$"
+	// +optional
+	Options []string `json:"options,omitempty"`
+}
+
 // NfsPvcSpec defines the desired state of NfsPvc.
 type NfsPvcSpec struct {
 	// accessModes contains the desired access modes the volume should have(RWX, RWO, ROX).
@@ -31,20 +110,45 @@ type NfsPvcSpec struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Capacity is immutable"
 	Capacity corev1.ResourceList `json:"capacity" protobuf:"bytes,1,rep,name=capacity,casttype=ResourceList,castkey=ResourceName"`
 
-	// path that is exported by the NFS server.
-	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Path is immutable"
+	// path that is exported by the NFS server. It can only be changed by an NfsPvcMigration.
+	// When empty, the export is provisioned by the storage backend of the operator, sized
+	// from capacity, and its path is reported in status.path.
 	// +kubebuilder:validation:Pattern="^/"
-	Path string `json:"path" protobuf:"bytes,2,opt,name=path"`
+	// +optional
+	Path string `json:"path,omitempty" protobuf:"bytes,2,opt,name=path"`
 
-	// server is the hostname or IP address of the NFS server
-	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Server is immutable"
+	// server is the hostname or IP address of the NFS server. It can only be changed by an NfsPvcMigration
+	// or an NfsServerFailover.
 	// +kubebuilder:validation:MinLength=1
 	Server string `json:"server" protobuf:"bytes,1,opt,name=server"`
 
-	// nfsVersion specifies the version of the NFS protocol to use .
-	// +kubebuilder:validation:Enum="3";"4";"4.1";"4.2"
+	// nfsVersion specifies the version of the NFS protocol to use. With "auto" the operator
+	// probes the server and uses the highest supported version allowed by its configuration.
+	// +kubebuilder:validation:Enum="3";"4";"4.1";"4.2";"auto"
 	// +kubebuilder:default="3"
 	NfsVersion string `json:"nfsVersion,omitempty" protobuf:"bytes,4,opt,name=nfsVersion"`
+
+	// security configures the RPC security flavor and the transport security of the mount.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Security is immutable"
+	// +optional
+	Security *NfsSecurity `json:"security,omitempty" protobuf:"bytes,5,opt,name=security"`
+
+	// dataSource is an NfsPvc whose data is copied into the export before the PV is created,
+	// so the PVC stays Pending until the export is populated.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="DataSource is immutable"
+	// +optional
+	DataSource *NfsPvcDataSource `json:"dataSource,omitempty" protobuf:"bytes,6,opt,name=dataSource"`
+
+	// createPath makes the operator create the path in its parent export, with the given ownership
+	// and mode, before the PV is created. An existing directory only gets its ownership and mode set.
+	// +optional
+	CreatePath *NfsPvcCreatePath `json:"createPath,omitempty" protobuf:"bytes,7,opt,name=createPath"`
+
+	// mountInstructions makes the operator render a ConfigMap named <name>-mount with an fstab line,
+	// a systemd mount unit, an autofs map entry and a cloud-init snippet mounting the export, for the
+	// consumers outside of Kubernetes such as virtual machines.
+	// +optional
+	MountInstructions *NfsPvcMountInstructions `json:"mountInstructions,omitempty" protobuf:"bytes,8,opt,name=mountInstructions"`
 }
 
 // NfsPvcStatus defines the observed state of NfsPvc.
@@ -53,8 +157,77 @@ type NfsPvcStatus struct {
 	PvcPhase string `json:"pvcPhase,omitempty" protobuf:"bytes,3,opt,name=pvcPhase"`
 	// pvPhase indicates if a volume is available, bound to a claim, or released by a claim.
 	PvPhase string `json:"pvPhase,omitempty" protobuf:"bytes,3,opt,name=pvPhase"`
+	// negotiatedVersion is the NFS version picked by probing the server when nfsVersion is "auto".
+	NegotiatedVersion string `json:"negotiatedVersion,omitempty" protobuf:"bytes,4,opt,name=negotiatedVersion"`
+	// path of the export provisioned by the storage backend when spec.path is empty.
+	Path string `json:"path,omitempty" protobuf:"bytes,6,opt,name=path"`
+	// usage of the export, as measured by the usage agent on a node mounting the PV.
+	// +optional
+	Usage *NfsPvcUsage `json:"usage,omitempty" protobuf:"bytes,7,opt,name=usage"`
+	// diagnostics are the findings of the diagnostic checks that failed against the nfspvc, its PV
+	// and its PVC, which explain why it is not bound.
+	// +optional
+	// +listType=map
+	// +listMapKey=check
+	Diagnostics []NfsPvcDiagnostic `json:"diagnostics,omitempty" protobuf:"bytes,8,rep,name=diagnostics"`
+	// conditions of the nfspvc, e.g. Populating while the export is filled from the dataSource,
+	// PathReady once the path of createPath exists, or NearlyFull once the usage passes the threshold.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" protobuf:"bytes,5,rep,name=conditions"`
 }
 
+// NfsPvcUsage is the space used on the export of an nfspvc.
+type NfsPvcUsage struct {
+	// usedBytes is the space used on the file system of the export.
+	UsedBytes int64 `json:"usedBytes" protobuf:"varint,1,opt,name=usedBytes"`
+	// availableBytes is the space still available on the file system of the export.
+	AvailableBytes int64 `json:"availableBytes" protobuf:"varint,2,opt,name=availableBytes"`
+	// node is the node whose mount of the export was measured.
+	// +optional
+	Node string `json:"node,omitempty" protobuf:"bytes,3,opt,name=node"`
+	// lastUpdateTime is when the usage was measured.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime" protobuf:"bytes,4,opt,name=lastUpdateTime"`
+}
+
+// NfsPvcDiagnostic is the finding of a failed diagnostic check.
+type NfsPvcDiagnostic struct {
+	// check is the name of the failed check, e.g. ClaimRefUID.
+	Check string `json:"check" protobuf:"bytes,1,opt,name=check"`
+	// finding explains what is wrong.
+	Finding string `json:"finding" protobuf:"bytes,2,opt,name=finding"`
+	// fix suggests how to repair it.
+	// +optional
+	Fix string `json:"fix,omitempty" protobuf:"bytes,3,opt,name=fix"`
+}
+
+// NfsVersionAuto is the nfsVersion that makes the operator negotiate the NFS version with the server.
+const NfsVersionAuto = "auto"
+
+const (
+	// PopulatingNfsPvcCondition is True while the export of an nfspvc is filled from its dataSource.
+	PopulatingNfsPvcCondition = "Populating"
+	// ProvisionedNfsPvcCondition is True once the storage backend provisioned the export of an nfspvc without path.
+	ProvisionedNfsPvcCondition = "Provisioned"
+	// PathReadyNfsPvcCondition is True once the path of an nfspvc with createPath exists in its export.
+	PathReadyNfsPvcCondition = "PathReady"
+	// NearlyFullNfsPvcCondition is True while the usage of the export of an nfspvc is past the warning threshold.
+	NearlyFullNfsPvcCondition = "NearlyFull"
+	// MountInstructionsReadyNfsPvcCondition is True once the ConfigMap of the mount instructions of an nfspvc is rendered.
+	MountInstructionsReadyNfsPvcCondition = "MountInstructionsReady"
+
+	// CloneGrantAnnotation lists the namespaces, or "*", that may clone an nfspvc.
+	CloneGrantAnnotation = "nfspvc.dana.io/clone-to"
+	// AdoptedPVAnnotation names the existing PV of an nfspvc that adopted an existing PVC, in place
+	// of the PV named after the nfspvc. It is set by kubectl-nfspvc adopt and is immutable.
+	AdoptedPVAnnotation = "nfspvc.dana.io/adopted-pv"
+	// ReclaimExportAnnotation, set to "true", opts an nfspvc into the Scrub and Archive reclaim policies,
+	// which remove the data of its export once it is deleted.
+	ReclaimExportAnnotation = "nfspvc.dana.io/reclaim-export"
+)
+
+// +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 
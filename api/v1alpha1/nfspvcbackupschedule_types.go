@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NfsPvcBackupScheduleSpec defines the desired state of NfsPvcBackupSchedule.
+type NfsPvcBackupScheduleSpec struct {
+	// schedule is the cron schedule of the backups, e.g. "0 2 * * *". It is interpreted in UTC.
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// selector selects the NfsPvcs, in the namespace of the schedule, that are backed up.
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// destination is where the backups are copied to. Every backup is an NfsPvcSnapshot
+	// written to the <namespace>/<snapshot name> directory of the destination.
+	Destination NfsPvcSnapshotDestination `json:"destination"`
+
+	// method is the tool used to copy the data.
+	// +kubebuilder:default=rsync
+	// +optional
+	Method NfsPvcSnapshotMethod `json:"method,omitempty"`
+
+	// retention is the number of successful backups kept for every NfsPvc. Older backups
+	// are deleted along with their data.
+	// +kubebuilder:default=7
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Retention int32 `json:"retention,omitempty"`
+
+	// suspend stops scheduling new backups. Existing backups are still pruned.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// NfsPvcBackupTargetStatus is the backup state of one NfsPvc selected by a schedule.
+type NfsPvcBackupTargetStatus struct {
+	// name is the name of the NfsPvc.
+	Name string `json:"name"`
+	// lastBackup is the name of the most recent NfsPvcSnapshot of the NfsPvc.
+	// +optional
+	LastBackup string `json:"lastBackup,omitempty"`
+	// lastSuccessTime is when the most recent successful backup completed.
+	// +optional
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+	// lastFailureTime is when the most recent failed backup completed.
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+	// lastFailureMessage explains the most recent failed backup.
+	// +optional
+	LastFailureMessage string `json:"lastFailureMessage,omitempty"`
+	// backups is the number of successful backups kept.
+	// +optional
+	Backups int32 `json:"backups,omitempty"`
+}
+
+// NfsPvcBackupScheduleStatus defines the observed state of NfsPvcBackupSchedule.
+type NfsPvcBackupScheduleStatus struct {
+	// lastScheduleTime is the last time backups were scheduled.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// nextScheduleTime is the next time backups are scheduled.
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+	// message explains why backups cannot be scheduled, e.g. an invalid schedule.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// targets is the backup state of every selected NfsPvc.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Targets []NfsPvcBackupTargetStatus `json:"targets,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`
+// +kubebuilder:printcolumn:name="Suspend",type=boolean,JSONPath=`.spec.suspend`
+// +kubebuilder:printcolumn:name="Last Schedule",type=date,JSONPath=`.status.lastScheduleTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NfsPvcBackupSchedule is the Schema for the nfspvcbackupschedules API
+type NfsPvcBackupSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NfsPvcBackupScheduleSpec   `json:"spec,omitempty"`
+	Status NfsPvcBackupScheduleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NfsPvcBackupScheduleList contains a list of NfsPvcBackupSchedule
+type NfsPvcBackupScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NfsPvcBackupSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NfsPvcBackupSchedule{}, &NfsPvcBackupScheduleList{})
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MigrationAnnotation names the NfsPvcMigration allowed to change the server and the path of an NfsPvc.
+const MigrationAnnotation = "nfspvc.dana.io/migration"
+
+// NfsPvcMigrationPhase is the progress of a migration.
+type NfsPvcMigrationPhase string
+
+const (
+	CopyingNfsPvcMigrationPhase     NfsPvcMigrationPhase = "Copying"
+	QuiescingNfsPvcMigrationPhase   NfsPvcMigrationPhase = "Quiescing"
+	SyncingNfsPvcMigrationPhase     NfsPvcMigrationPhase = "Syncing"
+	SwitchingNfsPvcMigrationPhase   NfsPvcMigrationPhase = "Switching"
+	SucceededNfsPvcMigrationPhase   NfsPvcMigrationPhase = "Succeeded"
+	RollingBackNfsPvcMigrationPhase NfsPvcMigrationPhase = "RollingBack"
+	RolledBackNfsPvcMigrationPhase  NfsPvcMigrationPhase = "RolledBack"
+)
+
+// NfsPvcMigrationStepName is a step of a migration.
+type NfsPvcMigrationStepName string
+
+const (
+	// CopyNfsPvcMigrationStep copies the data to the target while the volume is in use.
+	CopyNfsPvcMigrationStep NfsPvcMigrationStepName = "Copy"
+	// QuiesceNfsPvcMigrationStep scales the consuming workloads down to zero.
+	QuiesceNfsPvcMigrationStep NfsPvcMigrationStepName = "Quiesce"
+	// FinalSyncNfsPvcMigrationStep copies the changes made since the first copy.
+	FinalSyncNfsPvcMigrationStep NfsPvcMigrationStepName = "FinalSync"
+	// SwitchNfsPvcMigrationStep recreates the PV against the target and binds the PVC to it again.
+	SwitchNfsPvcMigrationStep NfsPvcMigrationStepName = "Switch"
+)
+
+// NfsPvcMigrationStepState is the state of a step of a migration.
+type NfsPvcMigrationStepState string
+
+const (
+	PendingNfsPvcMigrationStepState    NfsPvcMigrationStepState = "Pending"
+	RunningNfsPvcMigrationStepState    NfsPvcMigrationStepState = "Running"
+	SucceededNfsPvcMigrationStepState  NfsPvcMigrationStepState = "Succeeded"
+	SkippedNfsPvcMigrationStepState    NfsPvcMigrationStepState = "Skipped"
+	FailedNfsPvcMigrationStepState     NfsPvcMigrationStepState = "Failed"
+	RolledBackNfsPvcMigrationStepState NfsPvcMigrationStepState = "RolledBack"
+)
+
+// NfsPvcMigrationSpec defines the desired state of NfsPvcMigration.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type NfsPvcMigrationSpec struct {
+	// nfsPvcName is the NfsPvc, in the namespace of the migration, that is moved.
+	// +kubebuilder:validation:MinLength=1
+	NfsPvcName string `json:"nfsPvcName"`
+
+	// server is the hostname or the IP address of the NFS server the NfsPvc is moved to.
+	// +kubebuilder:validation:MinLength=1
+	Server string `json:"server"`
+
+	// path is the export on the new server the NfsPvc is moved to.
+	// +kubebuilder:validation:Pattern="^/"
+	Path string `json:"path"`
+
+	// quiesce scales the Deployments and StatefulSets mounting the PVC down to zero before
+	// the final sync, and back up once the PVC is bound again. Without it, the switch waits
+	// for the consumers of the PVC to be stopped.
+	// +optional
+	Quiesce bool `json:"quiesce,omitempty"`
+
+	// timeout is how long the quiesce and the switch steps wait before the migration is rolled back.
+	// +kubebuilder:default="10m"
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// NfsPvcMigrationStep is the progress of a step of a migration.
+type NfsPvcMigrationStep struct {
+	// name of the step.
+	Name NfsPvcMigrationStepName `json:"name"`
+	// state of the step.
+	State NfsPvcMigrationStepState `json:"state"`
+	// startTime is when the step started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// completionTime is when the step completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// message explains the state.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// QuiescedWorkload is a workload scaled down by a migration.
+type QuiescedWorkload struct {
+	// kind of the workload, Deployment or StatefulSet.
+	Kind string `json:"kind"`
+	// name of the workload.
+	Name string `json:"name"`
+	// replicas of the workload before it was scaled down.
+	Replicas int32 `json:"replicas"`
+}
+
+// NfsPvcMigrationStatus defines the observed state of NfsPvcMigration.
+type NfsPvcMigrationStatus struct {
+	// phase is the progress of the migration.
+	// +optional
+	Phase NfsPvcMigrationPhase `json:"phase,omitempty"`
+	// message explains the phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// sourceServer is the server of the NfsPvc before the migration, which a rollback restores.
+	// +optional
+	SourceServer string `json:"sourceServer,omitempty"`
+	// sourcePath is the path of the NfsPvc before the migration, which a rollback restores.
+	// +optional
+	SourcePath string `json:"sourcePath,omitempty"`
+	// steps is the progress of every step.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Steps []NfsPvcMigrationStep `json:"steps,omitempty"`
+	// quiescedWorkloads are the workloads scaled down by the migration.
+	// +optional
+	QuiescedWorkloads []QuiescedWorkload `json:"quiescedWorkloads,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="NfsPvc",type=string,JSONPath=`.spec.nfsPvcName`
+// +kubebuilder:printcolumn:name="Server",type=string,JSONPath=`.spec.server`
+// +kubebuilder:printcolumn:name="Path",type=string,JSONPath=`.spec.path`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NfsPvcMigration is the Schema for the nfspvcmigrations API
+type NfsPvcMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NfsPvcMigrationSpec   `json:"spec,omitempty"`
+	Status NfsPvcMigrationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NfsPvcMigrationList contains a list of NfsPvcMigration
+type NfsPvcMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NfsPvcMigration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NfsPvcMigration{}, &NfsPvcMigrationList{})
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NfsPvcSetRetentionPolicyType is the action taken on a generated NfsPvc when it is no longer needed.
+// +kubebuilder:validation:Enum=Retain;Delete
+type NfsPvcSetRetentionPolicyType string
+
+const (
+	// RetainNfsPvcSetRetentionPolicyType orphans the generated NfsPvc, keeping it and its PV and PVC.
+	RetainNfsPvcSetRetentionPolicyType NfsPvcSetRetentionPolicyType = "Retain"
+	// DeleteNfsPvcSetRetentionPolicyType deletes the generated NfsPvc.
+	DeleteNfsPvcSetRetentionPolicyType NfsPvcSetRetentionPolicyType = "Delete"
+)
+
+// NfsPvcSetRetentionPolicy describes what happens to generated NfsPvcs on scale down and on deletion of the set.
+type NfsPvcSetRetentionPolicy struct {
+	// whenScaled specifies what happens to NfsPvcs that are no longer generated by the set.
+	// +kubebuilder:default=Retain
+	// +optional
+	WhenScaled NfsPvcSetRetentionPolicyType `json:"whenScaled,omitempty"`
+
+	// whenDeleted specifies what happens to the generated NfsPvcs when the set is deleted.
+	// +kubebuilder:default=Retain
+	// +optional
+	WhenDeleted NfsPvcSetRetentionPolicyType `json:"whenDeleted,omitempty"`
+}
+
+// NfsPvcTemplateSpec describes the NfsPvcs generated by an NfsPvcSet.
+type NfsPvcTemplateSpec struct {
+	// labels are added to every generated NfsPvc.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// annotations are added to every generated NfsPvc.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// spec of the generated NfsPvcs. The server and path fields are Go templates
+	// rendered with .Name, .Ordinal, .Workload, .SetName and .Namespace.
+	// +kubebuilder:validation:XValidation:rule="self.server == oldSelf.server && self.path == oldSelf.path",message="Server and Path are immutable"
+	Spec NfsPvcSpecTemplate `json:"spec"`
+}
+
+// NfsPvcSpecTemplate is the spec of the generated NfsPvcs. It holds the fields of NfsPvcSpec without
+// their immutability rules, which apply to the generated NfsPvcs and not to the template.
+type NfsPvcSpecTemplate struct {
+	// accessModes contains the desired access modes the volume should have(RWX, RWO, ROX).
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes"`
+
+	// capacity is the description of the persistent volume's resources and capacity.
+	Capacity corev1.ResourceList `json:"capacity"`
+
+	// path that is exported by the NFS server. When empty, the export is provisioned by the storage
+	// backend of the operator.
+	// +kubebuilder:validation:Pattern="^/"
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// server is the hostname or IP address of the NFS server.
+	// +kubebuilder:validation:MinLength=1
+	Server string `json:"server"`
+
+	// nfsVersion specifies the version of the NFS protocol to use.
+	// +kubebuilder:validation:Enum="3";"4";"4.1";"4.2";"auto"
+	// +kubebuilder:default="3"
+	NfsVersion string `json:"nfsVersion,omitempty"`
+
+	// security configures the RPC security flavor and the transport security of the mount.
+	// +optional
+	Security *NfsSecurity `json:"security,omitempty"`
+
+	// dataSource is an NfsPvc whose data is copied into the export before the PV is created.
+	// +optional
+	DataSource *NfsPvcDataSource `json:"dataSource,omitempty"`
+
+	// createPath makes the operator create the path in its parent export before the PV is created.
+	// +optional
+	CreatePath *NfsPvcCreatePath `json:"createPath,omitempty"`
+
+	// mountInstructions makes the operator render a ConfigMap named <name>-mount mounting the export.
+	// +optional
+	MountInstructions *NfsPvcMountInstructions `json:"mountInstructions,omitempty"`
+}
+
+// NfsPvcSpec returns a copy of the template as the spec of an NfsPvc.
+func (in *NfsPvcSpecTemplate) NfsPvcSpec() NfsPvcSpec {
+	template := in.DeepCopy()
+	return NfsPvcSpec{
+		AccessModes:       template.AccessModes,
+		Capacity:          template.Capacity,
+		Path:              template.Path,
+		Server:            template.Server,
+		NfsVersion:        template.NfsVersion,
+		Security:          template.Security,
+		DataSource:        template.DataSource,
+		CreatePath:        template.CreatePath,
+		MountInstructions: template.MountInstructions,
+	}
+}
+
+// NfsPvcSetSpec defines the desired state of NfsPvcSet.
+// +kubebuilder:validation:XValidation:rule="[has(self.replicas), has(self.names), has(self.workloadSelector)].filter(x, x).size() == 1",message="exactly one of replicas, names or workloadSelector must be set"
+type NfsPvcSetSpec struct {
+	// replicas is the number of NfsPvcs to generate, one per ordinal starting at 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// names is a list of items to generate an NfsPvc for.
+	// +listType=set
+	// +optional
+	Names []string `json:"names,omitempty"`
+
+	// workloadSelector selects StatefulSets in the namespace of the set. An NfsPvc is
+	// generated for every replica of every selected StatefulSet.
+	// +optional
+	WorkloadSelector *metav1.LabelSelector `json:"workloadSelector,omitempty"`
+
+	// template describes the NfsPvcs that will be generated.
+	Template NfsPvcTemplateSpec `json:"template"`
+
+	// retentionPolicy describes the lifecycle of the generated NfsPvcs.
+	// +optional
+	RetentionPolicy *NfsPvcSetRetentionPolicy `json:"retentionPolicy,omitempty"`
+}
+
+// NfsPvcSetStatus defines the observed state of NfsPvcSet.
+type NfsPvcSetStatus struct {
+	// observedGeneration is the most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// replicas is the number of NfsPvcs the set should generate.
+	Replicas int32 `json:"replicas"`
+	// currentReplicas is the number of generated NfsPvcs that currently exist.
+	CurrentReplicas int32 `json:"currentReplicas"`
+	// readyReplicas is the number of generated NfsPvcs whose PVC is bound.
+	ReadyReplicas int32 `json:"readyReplicas"`
+	// outdatedReplicas is the number of generated NfsPvcs whose accessModes, capacity, security or
+	// dataSource differ from the template, which only applies these immutable fields at creation.
+	// +optional
+	OutdatedReplicas int32 `json:"outdatedReplicas,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=`.status.replicas`
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readyReplicas`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NfsPvcSet is the Schema for the nfspvcsets API
+type NfsPvcSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NfsPvcSetSpec   `json:"spec,omitempty"`
+	Status NfsPvcSetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NfsPvcSetList contains a list of NfsPvcSet
+type NfsPvcSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NfsPvcSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NfsPvcSet{}, &NfsPvcSetList{})
+}
@@ -0,0 +1,31 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"math/rand"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+)
+
+var _ = Describe("NfsPvcSpecTemplate", func() {
+	It("should carry every field of the template into the spec", func() {
+		filler := fuzzer.FuzzerFor(fuzzer.MergeFuzzerFuncs(metafuzzer.Funcs, nfspvcFuzzerFuncs),
+			rand.NewSource(GinkgoRandomSeed()), serializer.NewCodecFactory(runtime.NewScheme()))
+		for range fuzzIterations {
+			template := NfsPvcSpecTemplate{}
+			filler.Fill(&template)
+			spec := template.NfsPvcSpec()
+
+			fromTemplate, err := json.Marshal(template)
+			Expect(err).NotTo(HaveOccurred())
+			fromSpec, err := json.Marshal(spec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fromSpec).To(MatchJSON(fromTemplate))
+		}
+	})
+})
@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NfsPvcSnapshotMethod is the tool used to copy the data of a snapshot.
+// +kubebuilder:validation:Enum=rsync;tar
+type NfsPvcSnapshotMethod string
+
+const (
+	RsyncNfsPvcSnapshotMethod NfsPvcSnapshotMethod = "rsync"
+	TarNfsPvcSnapshotMethod   NfsPvcSnapshotMethod = "tar"
+)
+
+// NfsPvcSnapshotDeletionPolicy is what happens to the copied data when the snapshot is deleted.
+// +kubebuilder:validation:Enum=Retain;Delete
+type NfsPvcSnapshotDeletionPolicy string
+
+const (
+	// RetainNfsPvcSnapshotDeletionPolicy keeps the copied data on the destination.
+	RetainNfsPvcSnapshotDeletionPolicy NfsPvcSnapshotDeletionPolicy = "Retain"
+	// DeleteNfsPvcSnapshotDeletionPolicy removes the copied data from the destination.
+	DeleteNfsPvcSnapshotDeletionPolicy NfsPvcSnapshotDeletionPolicy = "Delete"
+)
+
+// NfsPvcSnapshotPhase is the progress of a snapshot.
+type NfsPvcSnapshotPhase string
+
+const (
+	PendingNfsPvcSnapshotPhase   NfsPvcSnapshotPhase = "Pending"
+	RunningNfsPvcSnapshotPhase   NfsPvcSnapshotPhase = "Running"
+	SucceededNfsPvcSnapshotPhase NfsPvcSnapshotPhase = "Succeeded"
+	FailedNfsPvcSnapshotPhase    NfsPvcSnapshotPhase = "Failed"
+)
+
+// NfsPvcSnapshotDestination is where the data of a snapshot is copied to.
+// +kubebuilder:validation:XValidation:rule="has(self.nfsPvcName) != (has(self.server) || has(self.path))",message="exactly one of nfsPvcName or server and path must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.server) == has(self.path)",message="server and path must be set together"
+type NfsPvcSnapshotDestination struct {
+	// nfsPvcName is an NfsPvc in the namespace of the snapshot to copy the data into.
+	// +optional
+	NfsPvcName string `json:"nfsPvcName,omitempty"`
+
+	// server is the hostname or the IP address of a backup NFS server to copy the data into.
+	// +optional
+	Server string `json:"server,omitempty"`
+
+	// path is the export on the backup server to copy the data into.
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// NfsPvcSnapshotSpec defines the desired state of NfsPvcSnapshot.
+// +kubebuilder:validation:XValidation:rule="self.source == oldSelf.source && self.destination == oldSelf.destination && self.method == oldSelf.method",message="source, destination and method are immutable"
+type NfsPvcSnapshotSpec struct {
+	// source is the name of the NfsPvc, in the namespace of the snapshot, whose data is copied.
+	// +kubebuilder:validation:MinLength=1
+	Source string `json:"source"`
+
+	// destination is where the data is copied to. The data is written to the
+	// <namespace>/<snapshot name> directory of the destination.
+	Destination NfsPvcSnapshotDestination `json:"destination"`
+
+	// method is the tool used to copy the data.
+	// +kubebuilder:default=rsync
+	// +optional
+	Method NfsPvcSnapshotMethod `json:"method,omitempty"`
+
+	// deletionPolicy specifies whether the copied data is removed when the snapshot is deleted.
+	// +kubebuilder:default=Retain
+	// +optional
+	DeletionPolicy NfsPvcSnapshotDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// expireAfter is the retention of the snapshot. The snapshot is deleted once it
+	// completed longer than expireAfter ago.
+	// +optional
+	ExpireAfter *metav1.Duration `json:"expireAfter,omitempty"`
+}
+
+// NfsPvcSnapshotStatus defines the observed state of NfsPvcSnapshot.
+type NfsPvcSnapshotStatus struct {
+	// phase is the progress of the snapshot.
+	// +optional
+	Phase NfsPvcSnapshotPhase `json:"phase,omitempty"`
+	// message explains the phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// jobName is the name of the Job copying the data.
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+	// startTime is when the copy started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// completionTime is when the copy completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// size is the size of the copied data.
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// files is the number of copied files.
+	// +optional
+	Files int64 `json:"files,omitempty"`
+	// location is the NFS location of the copied data.
+	// +optional
+	Location string `json:"location,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Source",type=string,JSONPath=`.spec.source`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Size",type=string,JSONPath=`.status.size`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NfsPvcSnapshot is the Schema for the nfspvcsnapshots API
+type NfsPvcSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NfsPvcSnapshotSpec   `json:"spec,omitempty"`
+	Status NfsPvcSnapshotStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NfsPvcSnapshotList contains a list of NfsPvcSnapshot
+type NfsPvcSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NfsPvcSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NfsPvcSnapshot{}, &NfsPvcSnapshotList{})
+}
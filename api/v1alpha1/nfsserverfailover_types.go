@@ -0,0 +1,149 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FailoverAnnotation names the NfsServerFailover moving an NfsPvc to another server.
+// It allows the failover to change the server of the NfsPvc, and is removed once the NfsPvc is moved.
+const FailoverAnnotation = "nfspvc.dana.io/failover"
+
+// FailedOverAnnotation names the NfsServerFailover that moved an NfsPvc to another server,
+// which marks the NfsPvc for the failback.
+const FailedOverAnnotation = "nfspvc.dana.io/failed-over-by"
+
+// NfsServerFailoverPhase is the progress of a failover.
+type NfsServerFailoverPhase string
+
+const (
+	ProgressingNfsServerFailoverPhase NfsServerFailoverPhase = "Progressing"
+	CompletedNfsServerFailoverPhase   NfsServerFailoverPhase = "Completed"
+)
+
+// NfsServerFailoverSpec defines the desired state of NfsServerFailover.
+// +kubebuilder:validation:XValidation:rule="self.from != self.to",message="from and to must be different servers"
+// +kubebuilder:validation:XValidation:rule="self.from == oldSelf.from && self.to == oldSelf.to",message="from and to are immutable"
+type NfsServerFailoverSpec struct {
+	// from is the server the NfsPvcs are moved away from.
+	// +kubebuilder:validation:MinLength=1
+	From string `json:"from"`
+
+	// to is the server the NfsPvcs are moved to. It must export the same paths as from,
+	// usually as a replica of it.
+	// +kubebuilder:validation:MinLength=1
+	To string `json:"to"`
+
+	// selector restricts the failover to the NfsPvcs matching it. All the NfsPvcs of from are moved when unset.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// namespaceSelector restricts the failover to the NfsPvcs of the matching namespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// batchSize is the maximum number of NfsPvcs whose PV is being recreated at once.
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BatchSize int32 `json:"batchSize,omitempty"`
+
+	// failback moves the NfsPvcs moved by this failover back to from.
+	// +optional
+	Failback bool `json:"failback,omitempty"`
+}
+
+// NfsServerFailoverStatus defines the observed state of NfsServerFailover.
+type NfsServerFailoverStatus struct {
+	// phase is the progress of the failover, or of the failback when it is requested.
+	// +optional
+	Phase NfsServerFailoverPhase `json:"phase,omitempty"`
+	// failback is true when the status describes the failback.
+	// +optional
+	Failback bool `json:"failback,omitempty"`
+	// message explains the phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// total is the number of NfsPvcs to move.
+	// +optional
+	Total int32 `json:"total,omitempty"`
+	// moved is the number of NfsPvcs whose PVC is bound to a PV of the new server.
+	// +optional
+	Moved int32 `json:"moved,omitempty"`
+	// inProgress is the number of NfsPvcs whose PV is being recreated.
+	// +optional
+	InProgress int32 `json:"inProgress,omitempty"`
+	// failed lists the NfsPvcs, as namespace/name, that could not be moved.
+	// +optional
+	Failed []string `json:"failed,omitempty"`
+	// completionTime is when every NfsPvc was moved.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="From",type=string,JSONPath=`.spec.from`
+// +kubebuilder:printcolumn:name="To",type=string,JSONPath=`.spec.to`
+// +kubebuilder:printcolumn:name="Failback",type=boolean,JSONPath=`.spec.failback`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Moved",type=integer,JSONPath=`.status.moved`
+// +kubebuilder:printcolumn:name="Total",type=integer,JSONPath=`.status.total`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NfsServerFailover is the Schema for the nfsserverfailovers API
+type NfsServerFailover struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NfsServerFailoverSpec   `json:"spec,omitempty"`
+	Status NfsServerFailoverStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NfsServerFailoverList contains a list of NfsServerFailover
+type NfsServerFailoverList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NfsServerFailover `json:"items"`
+}
+
+// Servers returns the server the NfsPvcs are moved away from and the server they are moved to,
+// which are swapped for a failback.
+func (in *NfsServerFailover) Servers() (string, string) {
+	if in.Spec.Failback {
+		return in.Spec.To, in.Spec.From
+	}
+	return in.Spec.From, in.Spec.To
+}
+
+// IsProgressing returns true until the failover completed in the direction of its spec, unless it is deleted.
+func (in *NfsServerFailover) IsProgressing() bool {
+	if in.DeletionTimestamp != nil {
+		return false
+	}
+	return in.Status.Phase != CompletedNfsServerFailoverPhase || in.Status.Failback != in.Spec.Failback
+}
+
+func init() {
+	SchemeBuilder.Register(&NfsServerFailover{}, &NfsServerFailoverList{})
+}
@@ -21,31 +21,795 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNfsPvc) DeepCopyInto(out *ClusterNfsPvc) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNfsPvc.
+func (in *ClusterNfsPvc) DeepCopy() *ClusterNfsPvc {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNfsPvc)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterNfsPvc) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNfsPvcList) DeepCopyInto(out *ClusterNfsPvcList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterNfsPvc, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNfsPvcList.
+func (in *ClusterNfsPvcList) DeepCopy() *ClusterNfsPvcList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNfsPvcList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterNfsPvcList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNfsPvcSpec) DeepCopyInto(out *ClusterNfsPvcSpec) {
+	*out = *in
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNfsPvcSpec.
+func (in *ClusterNfsPvcSpec) DeepCopy() *ClusterNfsPvcSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNfsPvcSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNfsPvcStatus) DeepCopyInto(out *ClusterNfsPvcStatus) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailedNamespaces != nil {
+		in, out := &in.FailedNamespaces, &out.FailedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNfsPvcStatus.
+func (in *ClusterNfsPvcStatus) DeepCopy() *ClusterNfsPvcStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNfsPvcStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsExportPolicy) DeepCopyInto(out *NfsExportPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsExportPolicy.
+func (in *NfsExportPolicy) DeepCopy() *NfsExportPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsExportPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NfsExportPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsExportPolicyHook) DeepCopyInto(out *NfsExportPolicyHook) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsExportPolicyHook.
+func (in *NfsExportPolicyHook) DeepCopy() *NfsExportPolicyHook {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsExportPolicyHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsExportPolicyList) DeepCopyInto(out *NfsExportPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NfsExportPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsExportPolicyList.
+func (in *NfsExportPolicyList) DeepCopy() *NfsExportPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsExportPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NfsExportPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsExportPolicySpec) DeepCopyInto(out *NfsExportPolicySpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AddressTypes != nil {
+		in, out := &in.AddressTypes, &out.AddressTypes
+		*out = make([]corev1.NodeAddressType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hook != nil {
+		in, out := &in.Hook, &out.Hook
+		*out = new(NfsExportPolicyHook)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsExportPolicySpec.
+func (in *NfsExportPolicySpec) DeepCopy() *NfsExportPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsExportPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsExportPolicyStatus) DeepCopyInto(out *NfsExportPolicyStatus) {
+	*out = *in
+	if in.Clients != nil {
+		in, out := &in.Clients, &out.Clients
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Missing != nil {
+		in, out := &in.Missing, &out.Missing
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Unexpected != nil {
+		in, out := &in.Unexpected, &out.Unexpected
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsExportPolicyStatus.
+func (in *NfsExportPolicyStatus) DeepCopy() *NfsExportPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsExportPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NfsPvc) DeepCopyInto(out *NfsPvc) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvc.
+func (in *NfsPvc) DeepCopy() *NfsPvc {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvc)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NfsPvc) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcBackupSchedule) DeepCopyInto(out *NfsPvcBackupSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcBackupSchedule.
+func (in *NfsPvcBackupSchedule) DeepCopy() *NfsPvcBackupSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcBackupSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NfsPvcBackupSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcBackupScheduleList) DeepCopyInto(out *NfsPvcBackupScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NfsPvcBackupSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcBackupScheduleList.
+func (in *NfsPvcBackupScheduleList) DeepCopy() *NfsPvcBackupScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcBackupScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NfsPvcBackupScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcBackupScheduleSpec) DeepCopyInto(out *NfsPvcBackupScheduleSpec) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	out.Destination = in.Destination
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcBackupScheduleSpec.
+func (in *NfsPvcBackupScheduleSpec) DeepCopy() *NfsPvcBackupScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcBackupScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcBackupScheduleStatus) DeepCopyInto(out *NfsPvcBackupScheduleStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]NfsPvcBackupTargetStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcBackupScheduleStatus.
+func (in *NfsPvcBackupScheduleStatus) DeepCopy() *NfsPvcBackupScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcBackupScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcBackupTargetStatus) DeepCopyInto(out *NfsPvcBackupTargetStatus) {
+	*out = *in
+	if in.LastSuccessTime != nil {
+		in, out := &in.LastSuccessTime, &out.LastSuccessTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcBackupTargetStatus.
+func (in *NfsPvcBackupTargetStatus) DeepCopy() *NfsPvcBackupTargetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcBackupTargetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcCreatePath) DeepCopyInto(out *NfsPvcCreatePath) {
+	*out = *in
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.GID != nil {
+		in, out := &in.GID, &out.GID
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcCreatePath.
+func (in *NfsPvcCreatePath) DeepCopy() *NfsPvcCreatePath {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcCreatePath)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcDataSource) DeepCopyInto(out *NfsPvcDataSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcDataSource.
+func (in *NfsPvcDataSource) DeepCopy() *NfsPvcDataSource {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcDataSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcDiagnostic) DeepCopyInto(out *NfsPvcDiagnostic) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcDiagnostic.
+func (in *NfsPvcDiagnostic) DeepCopy() *NfsPvcDiagnostic {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcDiagnostic)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcList) DeepCopyInto(out *NfsPvcList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NfsPvc, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcList.
+func (in *NfsPvcList) DeepCopy() *NfsPvcList {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NfsPvcList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcMigration) DeepCopyInto(out *NfsPvcMigration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcMigration.
+func (in *NfsPvcMigration) DeepCopy() *NfsPvcMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NfsPvcMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcMigrationList) DeepCopyInto(out *NfsPvcMigrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NfsPvcMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcMigrationList.
+func (in *NfsPvcMigrationList) DeepCopy() *NfsPvcMigrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcMigrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NfsPvcMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcMigrationSpec) DeepCopyInto(out *NfsPvcMigrationSpec) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcMigrationSpec.
+func (in *NfsPvcMigrationSpec) DeepCopy() *NfsPvcMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcMigrationStatus) DeepCopyInto(out *NfsPvcMigrationStatus) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]NfsPvcMigrationStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.QuiescedWorkloads != nil {
+		in, out := &in.QuiescedWorkloads, &out.QuiescedWorkloads
+		*out = make([]QuiescedWorkload, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcMigrationStatus.
+func (in *NfsPvcMigrationStatus) DeepCopy() *NfsPvcMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcMigrationStep) DeepCopyInto(out *NfsPvcMigrationStep) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcMigrationStep.
+func (in *NfsPvcMigrationStep) DeepCopy() *NfsPvcMigrationStep {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcMigrationStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcMountInstructions) DeepCopyInto(out *NfsPvcMountInstructions) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcMountInstructions.
+func (in *NfsPvcMountInstructions) DeepCopy() *NfsPvcMountInstructions {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcMountInstructions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcSet) DeepCopyInto(out *NfsPvcSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcSet.
+func (in *NfsPvcSet) DeepCopy() *NfsPvcSet {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NfsPvcSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcSetList) DeepCopyInto(out *NfsPvcSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NfsPvcSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcSetList.
+func (in *NfsPvcSetList) DeepCopy() *NfsPvcSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NfsPvcSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcSetRetentionPolicy) DeepCopyInto(out *NfsPvcSetRetentionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcSetRetentionPolicy.
+func (in *NfsPvcSetRetentionPolicy) DeepCopy() *NfsPvcSetRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcSetRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcSetSpec) DeepCopyInto(out *NfsPvcSetSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WorkloadSelector != nil {
+		in, out := &in.WorkloadSelector, &out.WorkloadSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Template.DeepCopyInto(&out.Template)
+	if in.RetentionPolicy != nil {
+		in, out := &in.RetentionPolicy, &out.RetentionPolicy
+		*out = new(NfsPvcSetRetentionPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcSetSpec.
+func (in *NfsPvcSetSpec) DeepCopy() *NfsPvcSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcSetStatus) DeepCopyInto(out *NfsPvcSetStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcSetStatus.
+func (in *NfsPvcSetStatus) DeepCopy() *NfsPvcSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcSnapshot) DeepCopyInto(out *NfsPvcSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvc.
-func (in *NfsPvc) DeepCopy() *NfsPvc {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcSnapshot.
+func (in *NfsPvcSnapshot) DeepCopy() *NfsPvcSnapshot {
 	if in == nil {
 		return nil
 	}
-	out := new(NfsPvc)
+	out := new(NfsPvcSnapshot)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NfsPvc) DeepCopyObject() runtime.Object {
+func (in *NfsPvcSnapshot) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -53,52 +817,136 @@ func (in *NfsPvc) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NfsPvcList) DeepCopyInto(out *NfsPvcList) {
+func (in *NfsPvcSnapshotDestination) DeepCopyInto(out *NfsPvcSnapshotDestination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcSnapshotDestination.
+func (in *NfsPvcSnapshotDestination) DeepCopy() *NfsPvcSnapshotDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcSnapshotDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcSnapshotList) DeepCopyInto(out *NfsPvcSnapshotList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]NfsPvc, len(*in))
+		*out = make([]NfsPvcSnapshot, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcList.
-func (in *NfsPvcList) DeepCopy() *NfsPvcList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcSnapshotList.
+func (in *NfsPvcSnapshotList) DeepCopy() *NfsPvcSnapshotList {
 	if in == nil {
 		return nil
 	}
-	out := new(NfsPvcList)
+	out := new(NfsPvcSnapshotList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NfsPvcList) DeepCopyObject() runtime.Object {
+func (in *NfsPvcSnapshotList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcSnapshotSpec) DeepCopyInto(out *NfsPvcSnapshotSpec) {
+	*out = *in
+	out.Destination = in.Destination
+	if in.ExpireAfter != nil {
+		in, out := &in.ExpireAfter, &out.ExpireAfter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcSnapshotSpec.
+func (in *NfsPvcSnapshotSpec) DeepCopy() *NfsPvcSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcSnapshotStatus) DeepCopyInto(out *NfsPvcSnapshotStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcSnapshotStatus.
+func (in *NfsPvcSnapshotStatus) DeepCopy() *NfsPvcSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NfsPvcSpec) DeepCopyInto(out *NfsPvcSpec) {
 	*out = *in
 	if in.AccessModes != nil {
 		in, out := &in.AccessModes, &out.AccessModes
-		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
 		copy(*out, *in)
 	}
 	if in.Capacity != nil {
 		in, out := &in.Capacity, &out.Capacity
-		*out = make(v1.ResourceList, len(*in))
+		*out = make(corev1.ResourceList, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val.DeepCopy()
 		}
 	}
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(NfsSecurity)
+		**out = **in
+	}
+	if in.DataSource != nil {
+		in, out := &in.DataSource, &out.DataSource
+		*out = new(NfsPvcDataSource)
+		**out = **in
+	}
+	if in.CreatePath != nil {
+		in, out := &in.CreatePath, &out.CreatePath
+		*out = new(NfsPvcCreatePath)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MountInstructions != nil {
+		in, out := &in.MountInstructions, &out.MountInstructions
+		*out = new(NfsPvcMountInstructions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcSpec.
@@ -111,9 +959,73 @@ func (in *NfsPvcSpec) DeepCopy() *NfsPvcSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcSpecTemplate) DeepCopyInto(out *NfsPvcSpecTemplate) {
+	*out = *in
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(NfsSecurity)
+		**out = **in
+	}
+	if in.DataSource != nil {
+		in, out := &in.DataSource, &out.DataSource
+		*out = new(NfsPvcDataSource)
+		**out = **in
+	}
+	if in.CreatePath != nil {
+		in, out := &in.CreatePath, &out.CreatePath
+		*out = new(NfsPvcCreatePath)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MountInstructions != nil {
+		in, out := &in.MountInstructions, &out.MountInstructions
+		*out = new(NfsPvcMountInstructions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcSpecTemplate.
+func (in *NfsPvcSpecTemplate) DeepCopy() *NfsPvcSpecTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcSpecTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NfsPvcStatus) DeepCopyInto(out *NfsPvcStatus) {
 	*out = *in
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(NfsPvcUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Diagnostics != nil {
+		in, out := &in.Diagnostics, &out.Diagnostics
+		*out = make([]NfsPvcDiagnostic, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcStatus.
@@ -125,3 +1037,187 @@ func (in *NfsPvcStatus) DeepCopy() *NfsPvcStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcTemplateSpec) DeepCopyInto(out *NfsPvcTemplateSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcTemplateSpec.
+func (in *NfsPvcTemplateSpec) DeepCopy() *NfsPvcTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcUsage) DeepCopyInto(out *NfsPvcUsage) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcUsage.
+func (in *NfsPvcUsage) DeepCopy() *NfsPvcUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsSecurity) DeepCopyInto(out *NfsSecurity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsSecurity.
+func (in *NfsSecurity) DeepCopy() *NfsSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsServerFailover) DeepCopyInto(out *NfsServerFailover) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsServerFailover.
+func (in *NfsServerFailover) DeepCopy() *NfsServerFailover {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsServerFailover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NfsServerFailover) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsServerFailoverList) DeepCopyInto(out *NfsServerFailoverList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NfsServerFailover, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsServerFailoverList.
+func (in *NfsServerFailoverList) DeepCopy() *NfsServerFailoverList {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsServerFailoverList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NfsServerFailoverList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsServerFailoverSpec) DeepCopyInto(out *NfsServerFailoverSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsServerFailoverSpec.
+func (in *NfsServerFailoverSpec) DeepCopy() *NfsServerFailoverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsServerFailoverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsServerFailoverStatus) DeepCopyInto(out *NfsServerFailoverStatus) {
+	*out = *in
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsServerFailoverStatus.
+func (in *NfsServerFailoverStatus) DeepCopy() *NfsServerFailoverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsServerFailoverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuiescedWorkload) DeepCopyInto(out *QuiescedWorkload) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuiescedWorkload.
+func (in *QuiescedWorkload) DeepCopy() *QuiescedWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(QuiescedWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
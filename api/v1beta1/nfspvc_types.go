@@ -0,0 +1,250 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NfsSecurityFlavor is the RPC security flavor used to mount an NFS export.
+// +kubebuilder:validation:Enum=sys;krb5;krb5i;krb5p
+type NfsSecurityFlavor string
+
+const (
+	SysNfsSecurityFlavor   NfsSecurityFlavor = "sys"
+	Krb5NfsSecurityFlavor  NfsSecurityFlavor = "krb5"
+	Krb5iNfsSecurityFlavor NfsSecurityFlavor = "krb5i"
+	Krb5pNfsSecurityFlavor NfsSecurityFlavor = "krb5p"
+)
+
+// NfsTransportSecurity is the transport layer security used to mount an NFS export.
+// +kubebuilder:validation:Enum=none;tls;mtls
+type NfsTransportSecurity string
+
+const (
+	NoneNfsTransportSecurity NfsTransportSecurity = "none"
+	TLSNfsTransportSecurity  NfsTransportSecurity = "tls"
+	MTLSNfsTransportSecurity NfsTransportSecurity = "mtls"
+)
+
+// NfsServerReference is the export of an NFS server an NfsPvc mounts.
+type NfsServerReference struct {
+	// host is the hostname or IP address of the NFS server. It can only be changed by an
+	// NfsPvcMigration or an NfsServerFailover.
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host" protobuf:"bytes,1,opt,name=host"`
+
+	// path that is exported by the NFS server. It can only be changed by an NfsPvcMigration.
+	// When empty, the export is provisioned by the storage backend of the operator, sized
+	// from capacity, and its path is reported in status.path.
+	// +kubebuilder:validation:Pattern="^/"
+	// +optional
+	Path string `json:"path,omitempty" protobuf:"bytes,2,opt,name=path"`
+}
+
+// NfsSecurity defines the security modes of an NFS mount.
+type NfsSecurity struct {
+	// sec is the RPC security flavor, rendered as the sec mount option.
+	// +kubebuilder:default=sys
+	// +optional
+	Sec NfsSecurityFlavor `json:"sec,omitempty" protobuf:"bytes,1,opt,name=sec,casttype=NfsSecurityFlavor"`
+
+	// transportSecurity is the RPC-with-TLS mode, rendered as the xprtsec mount option.
+	// +kubebuilder:default=none
+	// +optional
+	TransportSecurity NfsTransportSecurity `json:"transportSecurity,omitempty" protobuf:"bytes,2,opt,name=transportSecurity,casttype=NfsTransportSecurity"`
+}
+
+// NfsMountOptions are the options the PV mounts the export with.
+type NfsMountOptions struct {
+	// nfsVersion specifies the version of the NFS protocol to use. With "auto" the operator
+	// probes the server and uses the highest supported version allowed by its configuration.
+	// +kubebuilder:validation:Enum="3";"4";"4.1";"4.2";"auto"
+	// +kubebuilder:default="3"
+	// +optional
+	NfsVersion string `json:"nfsVersion,omitempty" protobuf:"bytes,1,opt,name=nfsVersion"`
+
+	// security configures the RPC security flavor and the transport security of the mount.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Security is immutable"
+	// +optional
+	Security *NfsSecurity `json:"security,omitempty" protobuf:"bytes,2,opt,name=security"`
+}
+
+// NfsPvcDataSource is an NfsPvc whose data populates a new NfsPvc.
+type NfsPvcDataSource struct {
+	// name of the source NfsPvc.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+
+	// namespace of the source NfsPvc, the namespace of the new NfsPvc when empty. A source
+	// in another namespace must grant the clone by listing the namespace of the new NfsPvc
+	// in its nfspvc.dana.io/clone-to annotation.
+	// +optional
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,2,opt,name=namespace"`
+}
+
+// NfsPvcCreatePath is the ownership and the mode of an export subdirectory created by the operator.
+type NfsPvcCreatePath struct {
+	// uid owning the directory, the uid of the Job creating it when unset.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	UID *int64 `json:"uid,omitempty" protobuf:"varint,1,opt,name=uid"`
+
+	// gid owning the directory, the gid of the Job creating it when unset.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GID *int64 `json:"gid,omitempty" protobuf:"varint,2,opt,name=gid"`
+
+	// mode of the directory in octal, e.g. "0775".
+	// +kubebuilder:validation:Pattern="^0?[0-7]{3,4}$"
+	// +kubebuilder:default="0755"
+	// +optional
+	Mode string `json:"mode,omitempty" protobuf:"bytes,3,opt,name=mode"`
+}
+
+// NfsPvcMountInstructions is where and how the consumers outside of Kubernetes mount the export.
+type NfsPvcMountInstructions struct {
+	// mountPoint is the directory the export is mounted on, without whitespace or control characters.
+	// +kubebuilder:validation:Pattern="^/[^[:space:][:cntrl:]]*$"
+	MountPoint string `json:"mountPoint" protobuf:"bytes,1,opt,name=mountPoint"`
+
+	// options are added to the mount options of the PV, e.g. _netdev or hard, each without whitespace,
+	// commas or control characters.
+	// +kubebuilder:validation:items:Pattern="^[^[:space:][:cntrl:],]+$"
+	// +optional
+	Options []string `json:"options,omitempty" protobuf:"bytes,2,rep,name=options"`
+}
+
+// NfsPvcSpec defines the desired state of NfsPvc.
+type NfsPvcSpec struct {
+	// server is the NFS server and the path of the export it serves.
+	Server NfsServerReference `json:"server" protobuf:"bytes,1,opt,name=server"`
+
+	// capacity is the storage capacity of the persistent volume.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Capacity is immutable"
+	Capacity resource.Quantity `json:"capacity" protobuf:"bytes,2,opt,name=capacity"`
+
+	// accessModes contains the desired access modes the volume should have(RWX, RWO, ROX).
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="AccessModes is immutable"
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes" protobuf:"bytes,3,rep,name=accessModes,casttype=k8s.io/api/core/v1.PersistentVolumeAccessMode"`
+
+	// mountOptions are the NFS version and the security the PV mounts the export with.
+	// +kubebuilder:default={}
+	// +optional
+	MountOptions NfsMountOptions `json:"mountOptions,omitempty" protobuf:"bytes,4,opt,name=mountOptions"`
+
+	// dataSource is an NfsPvc whose data is copied into the export before the PV is created,
+	// so the PVC stays Pending until the export is populated.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="DataSource is immutable"
+	// +optional
+	DataSource *NfsPvcDataSource `json:"dataSource,omitempty" protobuf:"bytes,5,opt,name=dataSource"`
+
+	// createPath makes the operator create the path in its parent export, with the given ownership
+	// and mode, before the PV is created. An existing directory only gets its ownership and mode set.
+	// +optional
+	CreatePath *NfsPvcCreatePath `json:"createPath,omitempty" protobuf:"bytes,6,opt,name=createPath"`
+
+	// mountInstructions makes the operator render a ConfigMap named <name>-mount with an fstab line,
+	// a systemd mount unit, an autofs map entry and a cloud-init snippet mounting the export, for the
+	// consumers outside of Kubernetes such as virtual machines.
+	// +optional
+	MountInstructions *NfsPvcMountInstructions `json:"mountInstructions,omitempty" protobuf:"bytes,7,opt,name=mountInstructions"`
+}
+
+// NfsPvcUsage is the space used on the export of an nfspvc.
+type NfsPvcUsage struct {
+	// usedBytes is the space used on the file system of the export.
+	UsedBytes int64 `json:"usedBytes" protobuf:"varint,1,opt,name=usedBytes"`
+	// availableBytes is the space still available on the file system of the export.
+	AvailableBytes int64 `json:"availableBytes" protobuf:"varint,2,opt,name=availableBytes"`
+	// node is the node whose mount of the export was measured.
+	// +optional
+	Node string `json:"node,omitempty" protobuf:"bytes,3,opt,name=node"`
+	// lastUpdateTime is when the usage was measured.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime" protobuf:"bytes,4,opt,name=lastUpdateTime"`
+}
+
+// NfsPvcDiagnostic is the finding of a failed diagnostic check.
+type NfsPvcDiagnostic struct {
+	// check is the name of the failed check, e.g. ClaimRefUID.
+	Check string `json:"check" protobuf:"bytes,1,opt,name=check"`
+	// finding explains what is wrong.
+	Finding string `json:"finding" protobuf:"bytes,2,opt,name=finding"`
+	// fix suggests how to repair it.
+	// +optional
+	Fix string `json:"fix,omitempty" protobuf:"bytes,3,opt,name=fix"`
+}
+
+// NfsPvcStatus defines the observed state of NfsPvc.
+type NfsPvcStatus struct {
+	// pvcPhase is the phase of the PersistentVolumeClaim.
+	// +optional
+	PVCPhase corev1.PersistentVolumeClaimPhase `json:"pvcPhase,omitempty" protobuf:"bytes,1,opt,name=pvcPhase,casttype=k8s.io/api/core/v1.PersistentVolumeClaimPhase"`
+	// pvPhase is the phase of the PersistentVolume.
+	// +optional
+	PVPhase corev1.PersistentVolumePhase `json:"pvPhase,omitempty" protobuf:"bytes,2,opt,name=pvPhase,casttype=k8s.io/api/core/v1.PersistentVolumePhase"`
+	// negotiatedVersion is the NFS version picked by probing the server when nfsVersion is "auto".
+	// +optional
+	NegotiatedVersion string `json:"negotiatedVersion,omitempty" protobuf:"bytes,3,opt,name=negotiatedVersion"`
+	// path of the export provisioned by the storage backend when spec.server.path is empty.
+	// +optional
+	Path string `json:"path,omitempty" protobuf:"bytes,4,opt,name=path"`
+	// usage of the export, as measured by the usage agent on a node mounting the PV.
+	// +optional
+	Usage *NfsPvcUsage `json:"usage,omitempty" protobuf:"bytes,5,opt,name=usage"`
+	// diagnostics are the findings of the diagnostic checks that failed against the nfspvc, its PV
+	// and its PVC, which explain why it is not bound.
+	// +optional
+	// +listType=map
+	// +listMapKey=check
+	Diagnostics []NfsPvcDiagnostic `json:"diagnostics,omitempty" protobuf:"bytes,6,rep,name=diagnostics"`
+	// conditions of the nfspvc, e.g. Populating while the export is filled from the dataSource,
+	// PathReady once the path of createPath exists, or NearlyFull once the usage passes the threshold.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" protobuf:"bytes,7,rep,name=conditions"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// NfsPvc is the Schema for the nfspvcs API
+type NfsPvc struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NfsPvcSpec   `json:"spec,omitempty"`
+	Status NfsPvcStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NfsPvcList contains a list of NfsPvc
+type NfsPvcList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NfsPvc `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NfsPvc{}, &NfsPvcList{})
+}
@@ -0,0 +1,297 @@
+//go:build !ignore_autogenerated
+
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsMountOptions) DeepCopyInto(out *NfsMountOptions) {
+	*out = *in
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(NfsSecurity)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsMountOptions.
+func (in *NfsMountOptions) DeepCopy() *NfsMountOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsMountOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvc) DeepCopyInto(out *NfsPvc) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvc.
+func (in *NfsPvc) DeepCopy() *NfsPvc {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvc)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NfsPvc) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcCreatePath) DeepCopyInto(out *NfsPvcCreatePath) {
+	*out = *in
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.GID != nil {
+		in, out := &in.GID, &out.GID
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcCreatePath.
+func (in *NfsPvcCreatePath) DeepCopy() *NfsPvcCreatePath {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcCreatePath)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcDataSource) DeepCopyInto(out *NfsPvcDataSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcDataSource.
+func (in *NfsPvcDataSource) DeepCopy() *NfsPvcDataSource {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcDataSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcDiagnostic) DeepCopyInto(out *NfsPvcDiagnostic) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcDiagnostic.
+func (in *NfsPvcDiagnostic) DeepCopy() *NfsPvcDiagnostic {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcDiagnostic)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcList) DeepCopyInto(out *NfsPvcList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NfsPvc, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcList.
+func (in *NfsPvcList) DeepCopy() *NfsPvcList {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NfsPvcList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcMountInstructions) DeepCopyInto(out *NfsPvcMountInstructions) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcMountInstructions.
+func (in *NfsPvcMountInstructions) DeepCopy() *NfsPvcMountInstructions {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcMountInstructions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcSpec) DeepCopyInto(out *NfsPvcSpec) {
+	*out = *in
+	out.Server = in.Server
+	out.Capacity = in.Capacity.DeepCopy()
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+	in.MountOptions.DeepCopyInto(&out.MountOptions)
+	if in.DataSource != nil {
+		in, out := &in.DataSource, &out.DataSource
+		*out = new(NfsPvcDataSource)
+		**out = **in
+	}
+	if in.CreatePath != nil {
+		in, out := &in.CreatePath, &out.CreatePath
+		*out = new(NfsPvcCreatePath)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MountInstructions != nil {
+		in, out := &in.MountInstructions, &out.MountInstructions
+		*out = new(NfsPvcMountInstructions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcSpec.
+func (in *NfsPvcSpec) DeepCopy() *NfsPvcSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcStatus) DeepCopyInto(out *NfsPvcStatus) {
+	*out = *in
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(NfsPvcUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Diagnostics != nil {
+		in, out := &in.Diagnostics, &out.Diagnostics
+		*out = make([]NfsPvcDiagnostic, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcStatus.
+func (in *NfsPvcStatus) DeepCopy() *NfsPvcStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsPvcUsage) DeepCopyInto(out *NfsPvcUsage) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsPvcUsage.
+func (in *NfsPvcUsage) DeepCopy() *NfsPvcUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsPvcUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsSecurity) DeepCopyInto(out *NfsSecurity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsSecurity.
+func (in *NfsSecurity) DeepCopy() *NfsSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsServerReference) DeepCopyInto(out *NfsServerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsServerReference.
+func (in *NfsServerReference) DeepCopy() *NfsServerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsServerReference)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -59,4 +59,16 @@ Create the name of the service account to use
 {{- else }}
 {{- default "default" .Values.serviceAccount.name }}
 {{- end }}
-{{- end }}
\ No newline at end of file
+{{- end }}
+{{/*
+Image of the data mover Jobs, pinned by its digest when one is set
+*/}}
+{{- define "nfspvc-operator.dataMoverImage" -}}
+{{- with .Values.image.dataMover }}
+{{- if .digest }}
+{{- printf "%s@%s" .repository .digest }}
+{{- else }}
+{{- printf "%s:%s" .repository .tag }}
+{{- end }}
+{{- end }}
+{{- end }}
@@ -17,8 +17,10 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
 	"os"
 
 	webhooknfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/internal/webhook/v1alpha1"
@@ -27,10 +29,13 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -38,8 +43,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	nfspvcv1beta1 "github.com/dana-team/nfspvc-operator/api/v1beta1"
 	"github.com/dana-team/nfspvc-operator/internal/controller"
+	"github.com/dana-team/nfspvc-operator/internal/controller/jobs"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
 	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	"github.com/dana-team/nfspvc-operator/internal/nfsprobe"
+	"github.com/dana-team/nfspvc-operator/internal/sharding"
+	"github.com/dana-team/nfspvc-operator/internal/storage"
+	"github.com/dana-team/nfspvc-operator/internal/storage/ontap"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -52,6 +64,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(nfspvcv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(nfspvcv1beta1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -62,6 +75,10 @@ func main() {
 	var secureMetrics bool
 	var enableHTTP2 bool
 	var tlsOpts []func(*tls.Config)
+	var controllerOptions utils.ControllerOptions
+	var shardName string
+	var namespaces string
+	var namespaceSelector string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -72,6 +89,17 @@ func main() {
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&shardName, "shard-name", "",
+		"The name of this instance when several instances share the cluster. Every shard elects its own leader.")
+	flag.StringVar(&namespaces, "namespaces", "",
+		"Comma separated namespaces managed by this instance. All namespaces when empty.")
+	flag.StringVar(&namespaceSelector, "namespace-selector", "",
+		"Label selector of the namespaces managed by this instance. All namespaces when empty.")
+	if err := controllerOptions.BindFlags(flag.CommandLine); err != nil {
+		// the logger is only set once the flags are parsed
+		fmt.Fprintf(os.Stderr, "invalid controller options configuration: %v\n", err)
+		os.Exit(1)
+	}
 	opts := zap.Options{
 		Development: true,
 	}
@@ -80,6 +108,18 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if err := controllerOptions.Validate(); err != nil {
+		setupLog.Error(err, "invalid controller options")
+		os.Exit(1)
+	}
+
+	scope, err := sharding.NewScope(shardName, namespaces, namespaceSelector)
+	if err != nil {
+		setupLog.Error(err, "invalid sharding configuration")
+		os.Exit(1)
+	}
+	operatorNamespace := sharding.OperatorNamespace()
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -121,13 +161,20 @@ func main() {
 		// this setup is not recommended for production.
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	// the ConfigMaps and the Jobs of the operator are cached by their labels only
+	ownedLabels := map[client.Object]string{
+		&corev1.ConfigMap{}: resources.NfsPvcOwnerLabel,
+		&batchv1.Job{}:      jobs.JobLabel,
+	}
+	restConfig := ctrl.GetConfigOrDie()
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  scope.CacheOptions(ownedLabels),
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "201dc81e.dana.io",
+		LeaderElectionID:       scope.LeaderElectionID(),
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -150,18 +197,135 @@ func main() {
 		os.Exit(1)
 	}
 
+	storageBackend, err := newStorageBackend()
+	if err != nil {
+		setupLog.Error(err, "unable to configure the storage backend")
+		os.Exit(1)
+	}
+
+	if operatorNamespace != "" {
+		registryClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+		if err != nil {
+			setupLog.Error(err, "unable to create the shard registry client")
+			os.Exit(1)
+		}
+		if err := sharding.Register(context.Background(), registryClient, operatorNamespace, os.Getenv(sharding.DeploymentNameEnv), scope); err != nil {
+			setupLog.Error(err, "unable to register the shard")
+			os.Exit(1)
+		}
+	}
+
+	var clusterReader client.Reader = mgr.GetClient()
+	if !scope.IsClusterWide() {
+		clusterReader = mgr.GetAPIReader()
+	}
+
 	if err = (&controller.NfsPvcReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Log:    ctrl.Log.WithName("controllers").WithName("NfsPvcController"),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Log:      ctrl.Log.WithName("controllers").WithName("NfsPvcController"),
+		Options:  controllerOptions.Options(),
+		Scope:    scope,
+		Prober:   &nfsprobe.Prober{},
+		Recorder: mgr.GetEventRecorderFor("nfspvc-controller"),
+		Backend:  storageBackend,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NfsPvc")
 		os.Exit(1)
 	}
-	if err = webhooknfspvcv1alpha1.SetupNfsPvcWebhookWithManager(mgr); err != nil {
+	if err = (&controller.NfsPvcUsageReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Log:      ctrl.Log.WithName("controllers").WithName("NfsPvcUsageController"),
+		Options:  controllerOptions.Options(),
+		Scope:    scope,
+		Recorder: mgr.GetEventRecorderFor("nfspvc-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NfsPvcUsage")
+		os.Exit(1)
+	}
+	if err = (&controller.NfsPvcSetReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Log:     ctrl.Log.WithName("controllers").WithName("NfsPvcSetController"),
+		Options: controllerOptions.Options(),
+		Scope:   scope,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NfsPvcSet")
+		os.Exit(1)
+	}
+	if err = (&controller.ClusterNfsPvcReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Log:     ctrl.Log.WithName("controllers").WithName("ClusterNfsPvcController"),
+		Options: controllerOptions.Options(),
+		Scope:   scope,
+		Reader:  clusterReader,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterNfsPvc")
+		os.Exit(1)
+	}
+	if err = (&controller.NfsPvcSnapshotReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Log:     ctrl.Log.WithName("controllers").WithName("NfsPvcSnapshotController"),
+		Options: controllerOptions.Options(),
+		Scope:   scope,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NfsPvcSnapshot")
+		os.Exit(1)
+	}
+	if err = (&controller.NfsPvcBackupScheduleReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Log:     ctrl.Log.WithName("controllers").WithName("NfsPvcBackupScheduleController"),
+		Options: controllerOptions.Options(),
+		Scope:   scope,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NfsPvcBackupSchedule")
+		os.Exit(1)
+	}
+	if err = (&controller.NfsPvcMigrationReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Log:     ctrl.Log.WithName("controllers").WithName("NfsPvcMigrationController"),
+		Options: controllerOptions.Options(),
+		Scope:   scope,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NfsPvcMigration")
+		os.Exit(1)
+	}
+	if err = (&controller.NfsServerFailoverReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Log:      ctrl.Log.WithName("controllers").WithName("NfsServerFailoverController"),
+		Options:  controllerOptions.Options(),
+		Scope:    scope,
+		Reader:   clusterReader,
+		Recorder: mgr.GetEventRecorderFor("nfsserverfailover-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NfsServerFailover")
+		os.Exit(1)
+	}
+	if err = (&controller.NfsExportPolicyReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Log:     ctrl.Log.WithName("controllers").WithName("NfsExportPolicyController"),
+		Options: controllerOptions.Options(),
+		Scope:   scope,
+		Backend: storageBackend,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NfsExportPolicy")
+		os.Exit(1)
+	}
+	if err = webhooknfspvcv1alpha1.SetupNfsPvcWebhookWithManager(mgr, operatorNamespace); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "NfsPvc")
 		os.Exit(1)
 	}
+	if err = webhooknfspvcv1alpha1.SetupNfsPvcSetWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "NfsPvcSet")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -179,3 +343,19 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// newStorageBackend returns the storage backend selected by the STORAGE_BACKEND environment variable, nil when unset.
+func newStorageBackend() (storage.StorageBackend, error) {
+	switch name := os.Getenv(utils.StorageBackendEnv); name {
+	case "":
+		return nil, nil
+	case ontap.Name:
+		config, err := ontap.ConfigFromEnvironment()
+		if err != nil {
+			return nil, err
+		}
+		return ontap.New(config, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", name)
+	}
+}
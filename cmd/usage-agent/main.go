@@ -0,0 +1,82 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The usage agent runs on every node, measures the NFS volumes of the NfsPvcs mounted by the pods
+// of the node with statfs, and records their usage in the status of the NfsPvcs.
+package main
+
+import (
+	"flag"
+	"os"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/usageagent"
+)
+
+var (
+	scheme   = runtime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+	utilruntime.Must(nfspvcv1alpha1.AddToScheme(scheme))
+}
+
+func main() {
+	var nodeName string
+	var kubeletDir string
+	var interval time.Duration
+	var timeout time.Duration
+	flag.StringVar(&nodeName, "node-name", os.Getenv("NODE_NAME"), "The name of the node the agent runs on.")
+	flag.StringVar(&kubeletDir, "kubelet-dir", "/var/lib/kubelet", "The root directory of the kubelet, holding the volumes of the pods.")
+	flag.DurationVar(&interval, "interval", time.Minute, "The interval between two measurements of the usage.")
+	flag.DurationVar(&timeout, "timeout", usageagent.DefaultTimeout,
+		"The time given to the measurement of a volume, which blocks while its NFS server does not respond.")
+	opts := zap.Options{
+		Development: true,
+	}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
+	if nodeName == "" || interval <= 0 || timeout <= 0 {
+		setupLog.Info("the node name, a positive interval and a positive timeout are required")
+		os.Exit(1)
+	}
+	k8sClient, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		setupLog.Error(err, "unable to create client")
+		os.Exit(1)
+	}
+
+	agent := usageagent.NewAgent(k8sClient, ctrl.Log.WithName("usage-agent"), nodeName, kubeletDir, interval, timeout)
+	setupLog.Info("starting usage agent", "node", nodeName, "kubeletDir", kubeletDir, "interval", interval, "timeout", timeout)
+	if err := agent.Start(ctrl.SetupSignalHandler()); err != nil {
+		setupLog.Error(err, "problem running usage agent")
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,24 @@
+#!/usr/bin/env bash
+
+# Lets a Helm release adopt the nfspvcs.nfspvc.dana.io CRD, which releases before the conversion
+# webhook installed from the crds/ directory of the chart, so that the upgrade renders it as a template.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+if [[ $# -ne 2 ]]; then
+  echo "usage: $0 <release> <release-namespace>" >&2
+  exit 1
+fi
+RELEASE="$1"
+NAMESPACE="$2"
+CRD="nfspvcs.nfspvc.dana.io"
+KUBECTL="${KUBECTL:-kubectl}"
+
+"${KUBECTL}" get crd "${CRD}" >/dev/null
+"${KUBECTL}" label --overwrite crd "${CRD}" app.kubernetes.io/managed-by=Helm
+"${KUBECTL}" annotate --overwrite crd "${CRD}" \
+  meta.helm.sh/release-name="${RELEASE}" \
+  meta.helm.sh/release-namespace="${NAMESPACE}"
+echo "crd ${CRD} is adopted by release ${RELEASE} in namespace ${NAMESPACE}"
@@ -0,0 +1,42 @@
+#!/usr/bin/env bash
+
+# Generates the typed clientset, the listers and the informers of the API under pkg/client.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+ROOT="$(cd "$(dirname "${BASH_SOURCE[0]}")/.." && pwd)"
+CLIENT_GEN="${CLIENT_GEN:-client-gen}"
+LISTER_GEN="${LISTER_GEN:-lister-gen}"
+INFORMER_GEN="${INFORMER_GEN:-informer-gen}"
+MODULE="github.com/dana-team/nfspvc-operator"
+HEADER="${ROOT}/hack/boilerplate.go.txt"
+
+cd "${ROOT}"
+rm -rf pkg/client
+
+"${CLIENT_GEN}" \
+  --go-header-file "${HEADER}" \
+  --input-base "" \
+  --input "${MODULE}/api/v1alpha1" \
+  --input "${MODULE}/api/v1beta1" \
+  --clientset-name versioned \
+  --output-dir pkg/client/clientset \
+  --output-pkg "${MODULE}/pkg/client/clientset"
+
+"${LISTER_GEN}" \
+  --go-header-file "${HEADER}" \
+  --output-dir pkg/client/listers \
+  --output-pkg "${MODULE}/pkg/client/listers" \
+  "${MODULE}/api/v1alpha1" \
+  "${MODULE}/api/v1beta1"
+
+"${INFORMER_GEN}" \
+  --go-header-file "${HEADER}" \
+  --versioned-clientset-package "${MODULE}/pkg/client/clientset/versioned" \
+  --listers-package "${MODULE}/pkg/client/listers" \
+  --output-dir pkg/client/informers \
+  --output-pkg "${MODULE}/pkg/client/informers" \
+  "${MODULE}/api/v1alpha1" \
+  "${MODULE}/api/v1beta1"
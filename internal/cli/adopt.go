@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newAdoptCommand(o *options) *cobra.Command {
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "adopt PVC",
+		Short: "Create an NfsPvc managing an existing PVC bound to an NFS PV, without recreating them",
+		Long: `Create an NfsPvc named after an existing PVC bound to an NFS PV. The NfsPvc takes the export,
+capacity, access modes, NFS version and security of the PV, and names the PV in its
+nfspvc.dana.io/adopted-pv annotation, so that the operator manages the PV and the PVC in place.
+The mount options the NfsPvc cannot express are reported and kept on the PV.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k8sClient, namespace, err := o.setup()
+			if err != nil {
+				return err
+			}
+			return adopt(cmd.Context(), o, k8sClient, namespace, args[0], dryRun)
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the NfsPvc instead of creating it.")
+	return cmd
+}
+
+// adopt creates the nfspvc adopting the pvc and labels the pvc and its pv as owned by it.
+// An interrupted adoption is completed by running it again.
+func adopt(ctx context.Context, o *options, k8sClient client.Client, namespace, name string, dryRun bool) error {
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, pvc); err != nil {
+		return fmt.Errorf("failed to get pvc %s/%s: %v", namespace, name, err)
+	}
+	if pvc.Status.Phase != corev1.ClaimBound || pvc.Spec.VolumeName == "" {
+		return fmt.Errorf("pvc %s/%s is not bound", namespace, name)
+	}
+	pv := &corev1.PersistentVolume{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: pvc.Spec.VolumeName}, pv); err != nil {
+		return fmt.Errorf("failed to get pv %q: %v", pvc.Spec.VolumeName, err)
+	}
+	nfspvc, unsupported, err := adoptingNfsPvc(*pv, *pvc)
+	if err != nil {
+		return err
+	}
+	if len(unsupported) > 0 {
+		_, _ = fmt.Fprintf(o.errOut, "Warning: the NfsPvc cannot express the mount options %s of pv %q, which are kept on the PV but not on a recreated PV\n",
+			strings.Join(unsupported, ","), pv.Name)
+	}
+	if dryRun {
+		return printYAML(o, nfspvc)
+	}
+
+	existing := &danaiov1alpha1.NfsPvc{}
+	err = k8sClient.Get(ctx, client.ObjectKeyFromObject(nfspvc), existing)
+	switch {
+	case apierrors.IsNotFound(err):
+		if err := k8sClient.Create(ctx, nfspvc); err != nil {
+			return fmt.Errorf("failed to create nfspvc %s/%s: %v", namespace, name, err)
+		}
+	case err != nil:
+		return fmt.Errorf("failed to get nfspvc %s/%s: %v", namespace, name, err)
+	case existing.Annotations[danaiov1alpha1.AdoptedPVAnnotation] != pv.Name:
+		return fmt.Errorf("nfspvc %s/%s already exists and does not adopt pv %q", namespace, name, pv.Name)
+	}
+	for _, object := range []client.Object{pv, pvc} {
+		if err := setOwnerLabel(ctx, k8sClient, object, name); err != nil {
+			return err
+		}
+	}
+	_, _ = fmt.Fprintf(o.out, "nfspvc %s/%s adopted pvc %s/%s and pv %q\n", namespace, name, namespace, name, pv.Name)
+	return nil
+}
+
+// adoptingNfsPvc returns the nfspvc adopting the pvc bound to the NFS pv, with the mount options of
+// the pv it cannot express.
+func adoptingNfsPvc(pv corev1.PersistentVolume, pvc corev1.PersistentVolumeClaim) (*danaiov1alpha1.NfsPvc, []string, error) {
+	if pv.Spec.NFS == nil {
+		return nil, nil, fmt.Errorf("pv %q is not an NFS volume", pv.Name)
+	}
+	if ref := pv.Spec.ClaimRef; ref == nil || ref.Namespace != pvc.Namespace || ref.Name != pvc.Name || (ref.UID != "" && ref.UID != pvc.UID) {
+		return nil, nil, fmt.Errorf("pv %q is not bound to pvc %s/%s", pv.Name, pvc.Namespace, pvc.Name)
+	}
+	if owner, ok := pv.Labels[resources.NfsPvcOwnerLabel]; ok && owner != pvc.Name {
+		return nil, nil, fmt.Errorf("pv %q already belongs to nfspvc %q", pv.Name, owner)
+	}
+	storage, ok := pv.Spec.Capacity[corev1.ResourceStorage]
+	if !ok {
+		return nil, nil, fmt.Errorf("pv %q has no storage capacity", pv.Name)
+	}
+
+	nfspvc := newNfsPvc(pvc.Name, pvc.Namespace)
+	nfspvc.Annotations = map[string]string{danaiov1alpha1.AdoptedPVAnnotation: pv.Name}
+	nfspvc.Spec = danaiov1alpha1.NfsPvcSpec{
+		Server:      pv.Spec.NFS.Server,
+		Path:        pv.Spec.NFS.Path,
+		Capacity:    corev1.ResourceList{corev1.ResourceStorage: storage},
+		AccessModes: pvc.Spec.AccessModes,
+	}
+	if len(nfspvc.Spec.AccessModes) == 0 {
+		nfspvc.Spec.AccessModes = pv.Spec.AccessModes
+	}
+	unsupported := resources.ApplyMountOptions(&nfspvc.Spec, pv.Spec.MountOptions)
+	return nfspvc, unsupported, nil
+}
+
+// setOwnerLabel labels the pv or the pvc as owned by the nfspvc, so that the operator watches it.
+func setOwnerLabel(ctx context.Context, k8sClient client.Client, object client.Object, name string) error {
+	if object.GetLabels()[resources.NfsPvcOwnerLabel] == name {
+		return nil
+	}
+	patch := client.MergeFrom(object.DeepCopyObject().(client.Object))
+	labels := object.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[resources.NfsPvcOwnerLabel] = name
+	object.SetLabels(labels)
+	if err := k8sClient.Patch(ctx, object, patch); err != nil {
+		return fmt.Errorf("failed to label %q: %v", object.GetName(), err)
+	}
+	return nil
+}
@@ -0,0 +1,359 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const namespace = "team-a"
+
+var _ = Describe("kubectl-nfspvc", func() {
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		out       *bytes.Buffer
+		errOut    *bytes.Buffer
+		nfspvc    *danaiov1alpha1.NfsPvc
+		pv        corev1.PersistentVolume
+		pvc       corev1.PersistentVolumeClaim
+	)
+
+	run := func(args ...string) error {
+		out.Reset()
+		errOut.Reset()
+		cmd := newRootCommand(&options{out: out, errOut: errOut, client: k8sClient})
+		cmd.SetArgs(append(args, "--namespace", namespace))
+		return cmd.ExecuteContext(ctx)
+	}
+
+	pod := func(name, claim string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: corev1.PodSpec{Volumes: []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim},
+			}}}},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		out, errOut = &bytes.Buffer{}, &bytes.Buffer{}
+		nfspvc = &danaiov1alpha1.NfsPvc{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: namespace, Finalizers: []string{utils.NfsPvcDeletionFinalizer}},
+			Spec: danaiov1alpha1.NfsPvcSpec{
+				Server:      "nas",
+				Path:        "/exports/data",
+				NfsVersion:  "4.1",
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+				Capacity:    corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+			Status: danaiov1alpha1.NfsPvcStatus{PvcPhase: "Bound", PvPhase: "Bound"},
+		}
+		pv = resources.PreparePV(*nfspvc, "brown", "Retain")
+		pv.Finalizers = []string{pvProtectionFinalizer}
+		pv.UID = "pv-uid"
+		pvc = resources.PreparePVC(*nfspvc, "brown")
+		pvc.Status.Phase = corev1.ClaimBound
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			nfspvc, &pv, &pvc,
+			pod("app-0", "data", corev1.PodRunning),
+			pod("app-1", "data", corev1.PodSucceeded),
+			pod("other", "other", corev1.PodRunning),
+			&corev1.Event{
+				ObjectMeta:     metav1.ObjectMeta{Name: "data.1", Namespace: namespace},
+				InvolvedObject: corev1.ObjectReference{Kind: "PersistentVolumeClaim", Namespace: namespace, Name: "data"},
+				Type:           corev1.EventTypeWarning, Reason: "ProvisioningFailed", Message: "no volume plugin matched",
+			},
+			&corev1.Event{
+				ObjectMeta:     metav1.ObjectMeta{Name: "other.1", Namespace: namespace},
+				InvolvedObject: corev1.ObjectReference{Kind: "PersistentVolumeClaim", Namespace: namespace, Name: "other"},
+				Type:           corev1.EventTypeNormal, Reason: "Unrelated",
+			},
+		).Build()
+	})
+
+	It("should list the nfspvcs with their consumers", func() {
+		Expect(run("list")).To(Succeed())
+		Expect(out.String()).To(MatchRegexp(`NAME\s+SERVER\s+PATH\s+PVC\s+PV\s+CONSUMERS`))
+		Expect(out.String()).To(MatchRegexp(`data\s+nas\s+/exports/data\s+Bound\s+Bound\s+app-0\n`))
+	})
+
+	It("should describe an nfspvc with its PV, PVC and events", func() {
+		Expect(run("describe", "data")).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("nas:/exports/data"))
+		Expect(out.String()).To(MatchRegexp(`Mount Options:\s+nfsvers=4.1`))
+		Expect(out.String()).To(MatchRegexp(`Consumers:\s+app-0\n`))
+		Expect(out.String()).To(ContainSubstring("ProvisioningFailed"))
+		Expect(out.String()).NotTo(ContainSubstring("Unrelated"))
+	})
+
+	It("should create an nfspvc from flags", func() {
+		Expect(run("create", "logs", "--server", "nas", "--path", "/exports/logs", "--capacity", "5Gi", "--dry-run")).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("kind: NfsPvc"))
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "logs"}, &danaiov1alpha1.NfsPvc{}))).To(BeTrue())
+
+		Expect(run("create", "logs", "--server", "nas", "--path", "/exports/logs", "--capacity", "5Gi", "--access-mode", "ReadWriteOnce")).To(Succeed())
+		created := &danaiov1alpha1.NfsPvc{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "logs"}, created)).To(Succeed())
+		Expect(created.Spec.Server).To(Equal("nas"))
+		Expect(created.Spec.AccessModes).To(Equal([]corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}))
+
+		Expect(run("create", "bad", "--server", "nas", "--capacity", "lots")).To(MatchError(ContainSubstring("invalid capacity")))
+	})
+
+	It("should adopt an existing PVC bound to an NFS PV", func() {
+		legacyPVC := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: namespace, UID: "legacy-uid"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				VolumeName:  "pv-legacy",
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+		}
+		legacyPV := &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-legacy"},
+			Spec: corev1.PersistentVolumeSpec{
+				Capacity:     corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("100Gi")},
+				ClaimRef:     &corev1.ObjectReference{Namespace: namespace, Name: "legacy", UID: "legacy-uid"},
+				MountOptions: []string{"vers=4,minorversion=2", "hard", "sec=krb5p"},
+				PersistentVolumeSource: corev1.PersistentVolumeSource{
+					NFS: &corev1.NFSVolumeSource{Server: "old-nas", Path: "/vol/legacy"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, legacyPVC)).To(Succeed())
+		Expect(k8sClient.Create(ctx, legacyPV)).To(Succeed())
+
+		Expect(run("adopt", "legacy")).To(Succeed())
+		Expect(errOut.String()).To(ContainSubstring("hard"))
+		adopted := &danaiov1alpha1.NfsPvc{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "legacy"}, adopted)).To(Succeed())
+		Expect(adopted.Annotations).To(HaveKeyWithValue(danaiov1alpha1.AdoptedPVAnnotation, "pv-legacy"))
+		Expect(adopted.Spec.Server).To(Equal("old-nas"))
+		Expect(adopted.Spec.Path).To(Equal("/vol/legacy"))
+		Expect(adopted.Spec.NfsVersion).To(Equal("4.2"))
+		Expect(adopted.Spec.Security.Sec).To(Equal(danaiov1alpha1.Krb5pNfsSecurityFlavor))
+		Expect(resources.PVName(*adopted)).To(Equal("pv-legacy"))
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(legacyPV), legacyPV)).To(Succeed())
+		Expect(legacyPV.Labels).To(HaveKeyWithValue(resources.NfsPvcOwnerLabel, "legacy"))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(legacyPVC), legacyPVC)).To(Succeed())
+		Expect(legacyPVC.Labels).To(HaveKeyWithValue(resources.NfsPvcOwnerLabel, "legacy"))
+
+		By("running it again")
+		Expect(run("adopt", "legacy")).To(Succeed())
+		By("refusing a PVC that is not bound")
+		Expect(k8sClient.Create(ctx, &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: namespace}})).To(Succeed())
+		Expect(run("adopt", "pending")).To(MatchError(ContainSubstring("not bound")))
+	})
+
+	It("should refuse to force-delete an nfspvc mounted by running pods", func() {
+		Expect(run("force-delete", "data")).To(MatchError(ContainSubstring("app-0")))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(nfspvc), nfspvc)).To(Succeed())
+		Expect(nfspvc.DeletionTimestamp).To(BeNil())
+	})
+
+	It("should force-delete an nfspvc with its PVC and PV", func() {
+		Expect(run("force-delete", "data", "--ignore-consumers")).To(Succeed())
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(nfspvc), nfspvc))).To(BeTrue())
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(&pvc), &pvc))).To(BeTrue())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&pv), &pv)).To(Succeed())
+		Expect(pv.DeletionTimestamp).NotTo(BeNil())
+	})
+
+	It("should delete the PV of an nfspvc to recreate it", func() {
+		Expect(run("recreate-pv", "data", "--timeout", "0")).To(Succeed())
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(&pv), &pv))).To(BeTrue())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&pvc), &pvc)).To(Succeed())
+
+		nfspvc.Annotations = map[string]string{danaiov1alpha1.MigrationAnnotation: "move"}
+		Expect(k8sClient.Update(ctx, nfspvc)).To(Succeed())
+		Expect(run("recreate-pv", "data")).To(MatchError(ContainSubstring("migrated")))
+	})
+
+	It("should explain why an nfspvc is not bound", func() {
+		Expect(run("doctor", "data", "--storage-class", "brown")).To(Succeed())
+		Expect(out.String()).To(MatchRegexp(`PASS\s+ClaimRefUID`))
+
+		pvc.Annotations = map[string]string{resources.PVCBindStatusAnnotation: "yes"}
+		Expect(k8sClient.Update(ctx, &pvc)).To(Succeed())
+		pvc.Status.Phase = corev1.ClaimLost
+		Expect(k8sClient.Status().Update(ctx, &pvc)).To(Succeed())
+		Expect(run("doctor", "data", "--storage-class", "gold")).To(MatchError(ContainSubstring("3 of 15 checks failed")))
+		Expect(out.String()).To(MatchRegexp(`FAIL\s+PVCPhase\s+pvc "data" is Lost`))
+		Expect(out.String()).To(MatchRegexp(`FAIL\s+BindCompleted`))
+		Expect(out.String()).To(ContainSubstring("kubectl annotate pvc -n team-a data pv.kubernetes.io/bind-completed-"))
+		Expect(out.String()).To(MatchRegexp(`FAIL\s+StorageClass\s+pv "data-team-a-pv" has storage class "brown" instead of "gold"`))
+	})
+
+	It("should import the NFS PVs bound to PVCs and report the others", func() {
+		legacy := func(name string, phase corev1.PersistentVolumeClaimPhase, mountOptions ...string) {
+			Expect(k8sClient.Create(ctx, &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-" + name},
+				Status:     corev1.PersistentVolumeClaimStatus{Phase: phase},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-" + name},
+				Spec: corev1.PersistentVolumeSpec{
+					Capacity:     corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
+					AccessModes:  []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+					ClaimRef:     &corev1.ObjectReference{Namespace: namespace, Name: name},
+					MountOptions: mountOptions,
+					PersistentVolumeSource: corev1.PersistentVolumeSource{
+						NFS: &corev1.NFSVolumeSource{Server: "old-nas", Path: "/vol/" + name},
+					},
+				},
+			})).To(Succeed())
+		}
+		legacy("alpha", corev1.ClaimBound, "nfsvers=3")
+		legacy("beta", corev1.ClaimBound, "hard")
+		legacy("gamma", corev1.ClaimPending)
+
+		Expect(run("import")).To(Succeed())
+		Expect(strings.Count(out.String(), "---")).To(Equal(1))
+		Expect(out.String()).To(ContainSubstring("nfspvc.dana.io/adopted-pv: pv-alpha"))
+		Expect(out.String()).To(ContainSubstring("nfsVersion: \"3\""))
+		Expect(errOut.String()).To(MatchRegexp(`pv-beta\s+team-a/beta\s+the nfspvc cannot express the mount options hard`))
+		Expect(errOut.String()).To(MatchRegexp(`pv-gamma\s+team-a/gamma\s+the pvc is not bound to the pv`))
+		Expect(errOut.String()).To(ContainSubstring("Converted 1 NFS PVs, 2 could not be converted."))
+
+		report := filepath.Join(GinkgoT().TempDir(), "report.txt")
+		Expect(run("import", "--apply", "--report", report)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("nfspvc team-a/alpha adopted"))
+		Expect(os.ReadFile(report)).To(ContainSubstring("pv-beta"))
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "alpha"}, &danaiov1alpha1.NfsPvc{})).To(Succeed())
+
+		By("skipping the adopted PVs")
+		Expect(run("import")).To(Succeed())
+		Expect(out.String()).To(BeEmpty())
+	})
+
+	It("should convert the NFS mounts of a host", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "fstab"), []byte("nas:/vol/data /mnt/data nfs4 hard 0 0\n"), 0o600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "auto.master"), []byte("/apps /etc/auto.apps -ro\n"), 0o600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "auto.apps"), []byte("data nas:/vol/data\nlogs nas:/vol/logs\n"), 0o600)).To(Succeed())
+
+		Expect(run("convert", "fstab", filepath.Join(dir, "fstab"), "--capacity", "1Gi")).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("path: /vol/data"))
+		Expect(out.String()).To(ContainSubstring(`nfsVersion: "4"`))
+		Expect(errOut.String()).To(ContainSubstring("Warning: " + filepath.Join(dir, "fstab") + ":1: the nfspvc cannot express the mount options hard"))
+
+		Expect(run("convert", "autofs", filepath.Join(dir, "auto.master"), "--map-dir", dir, "--capacity", "1Gi")).To(Succeed())
+		Expect(strings.Count(out.String(), "kind: NfsPvc")).To(Equal(2))
+		Expect(out.String()).To(ContainSubstring("name: logs"))
+		Expect(out.String()).To(ContainSubstring("- ReadOnlyMany"))
+	})
+
+	It("should render the PV and the PVC of NfsPvc manifests offline", func() {
+		dir := GinkgoT().TempDir()
+		manifests := filepath.Join(dir, "manifests.yaml")
+		Expect(os.WriteFile(manifests, []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unrelated
+---
+apiVersion: nfspvc.dana.io/v1alpha1
+kind: NfsPvc
+metadata:
+  name: logs
+spec:
+  server: nas
+  path: /exports/logs
+  accessModes: [ReadWriteMany]
+  capacity:
+    storage: 1Gi
+`), 0o600)).To(Succeed())
+		config := filepath.Join(dir, "config.yaml")
+		Expect(os.WriteFile(config, []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: configuration-nfspvc
+data:
+  STORAGE_CLASS: green
+  RECLAIM_POLICY: Delete
+`), 0o600)).To(Succeed())
+
+		Expect(run("render", "-f", manifests, "--storage-class", "brown")).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("kind: PersistentVolumeClaim"))
+		Expect(out.String()).To(ContainSubstring("kind: PersistentVolume\n"))
+		Expect(out.String()).To(ContainSubstring("path: /exports/logs"))
+		Expect(out.String()).To(ContainSubstring("storageClassName: brown"))
+		Expect(out.String()).To(ContainSubstring("persistentVolumeReclaimPolicy: Retain"))
+		Expect(out.String()).NotTo(ContainSubstring("name: unrelated"))
+
+		By("reading the configuration of the operator")
+		Expect(run("render", "-f", manifests, "--config", config)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("storageClassName: green"))
+		Expect(out.String()).To(ContainSubstring("persistentVolumeReclaimPolicy: Delete"))
+		Expect(run("render", "-f", manifests, "--config", config, "--storage-class", "brown")).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("storageClassName: brown"))
+
+		By("converting the v1beta1 nfspvcs")
+		beta := filepath.Join(dir, "beta.yaml")
+		Expect(os.WriteFile(beta, []byte(`apiVersion: nfspvc.dana.io/v1beta1
+kind: NfsPvc
+metadata:
+  name: reports
+spec:
+  server:
+    host: nas
+    path: /exports/reports
+  accessModes: [ReadWriteMany]
+  capacity: 2Gi
+  mountOptions:
+    nfsVersion: "4.1"
+`), 0o600)).To(Succeed())
+		Expect(run("render", "-f", beta, "--storage-class", "brown")).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("path: /exports/reports"))
+		Expect(out.String()).To(ContainSubstring("storage: 2Gi"))
+		Expect(out.String()).To(ContainSubstring("nfsvers=4.1"))
+
+		By("failing on the unknown versions")
+		unknown := filepath.Join(dir, "unknown.yaml")
+		Expect(os.WriteFile(unknown, []byte(`apiVersion: nfspvc.dana.io/v2
+kind: NfsPvc
+metadata:
+  name: future
+`), 0o600)).To(Succeed())
+		Expect(run("render", "-f", unknown, "--storage-class", "brown")).To(MatchError("1 NfsPvcs are invalid"))
+		Expect(errOut.String()).To(ContainSubstring("unsupported version nfspvc.dana.io/v2 of NfsPvc"))
+
+		By("failing on the invalid nfspvcs")
+		invalid := filepath.Join(dir, "invalid.yaml")
+		Expect(os.WriteFile(invalid, []byte(`apiVersion: nfspvc.dana.io/v1alpha1
+kind: NfsPvc
+metadata:
+  name: broken
+spec:
+  server: nas
+  path: /exports/broken
+  security:
+    transportSecurity: tls
+  accessModes: [ReadWriteMany]
+  capacity:
+    storage: 1Gi
+`), 0o600)).To(Succeed())
+		Expect(run("render", "-f", manifests, "-f", invalid, "--storage-class", "brown")).To(MatchError("1 NfsPvcs are invalid"))
+		Expect(errOut.String()).To(ContainSubstring("Error: nfspvc " + namespace + "/broken"))
+		Expect(out.String()).To(ContainSubstring("path: /exports/logs"))
+		Expect(run("render", "-f", manifests)).To(MatchError(ContainSubstring("the storage class is required")))
+	})
+})
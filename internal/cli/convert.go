@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dana-team/nfspvc-operator/internal/hostmount"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func newConvertCommand(o *options) *cobra.Command {
+	var capacity string
+	cmd := &cobra.Command{
+		Use:   "convert",
+		Short: "Convert the NFS mounts of an fstab or of autofs maps to NfsPvcs",
+		Long: `Print the NfsPvcs of the NFS mounts of a host, one per export. The NFS version, the security
+and ro are mapped onto the NfsPvc, and the other mount options are reported as warnings.`,
+	}
+	cmd.PersistentFlags().StringVar(&capacity, "capacity", "", "The capacity of the NfsPvcs, e.g. 10Gi.")
+	_ = cmd.MarkPersistentFlagRequired("capacity")
+
+	var mapDir string
+	autofs := &cobra.Command{
+		Use:   "autofs MASTER",
+		Short: "Convert the NFS mounts of an autofs master map and of its maps",
+		Long: `Convert the NFS mounts of the file maps of an autofs master map, such as /etc/auto.master.
+A map named without a directory is read from /etc, as autofs does, or from --map-dir.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return convert(o, capacity, func() ([]hostmount.Mount, []hostmount.Issue, error) {
+				return readAutofs(args[0], mapDir)
+			})
+		},
+	}
+	autofs.Flags().StringVar(&mapDir, "map-dir", "", "Read the maps from this directory instead of the paths of the master map.")
+
+	fstab := &cobra.Command{
+		Use:   "fstab FILE...",
+		Short: "Convert the NFS mounts of fstab files",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return convert(o, capacity, func() ([]hostmount.Mount, []hostmount.Issue, error) {
+				return readFstabs(args)
+			})
+		},
+	}
+	cmd.AddCommand(autofs, fstab)
+	return cmd
+}
+
+// convert prints the nfspvcs of the mounts that read returns, and the issues as warnings.
+func convert(o *options, capacity string, read func() ([]hostmount.Mount, []hostmount.Issue, error)) error {
+	quantity, err := resource.ParseQuantity(capacity)
+	if err != nil {
+		return fmt.Errorf("invalid capacity %q: %v", capacity, err)
+	}
+	namespace, err := o.Namespace()
+	if err != nil {
+		return err
+	}
+	mounts, issues, err := read()
+	if err != nil {
+		return err
+	}
+	nfspvcs, conversionIssues := hostmount.Convert(mounts, namespace, quantity)
+	for _, issue := range append(issues, conversionIssues...) {
+		_, _ = fmt.Fprintf(o.errOut, "Warning: %s\n", issue)
+	}
+	for i := range nfspvcs {
+		if err := printYAML(o, &nfspvcs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readFstabs returns the NFS mounts of the fstab files.
+func readFstabs(paths []string) ([]hostmount.Mount, []hostmount.Issue, error) {
+	var mounts []hostmount.Mount
+	var issues []hostmount.Issue
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open %s: %v", path, err)
+		}
+		fileMounts, fileIssues, err := hostmount.ParseFstab(file, path)
+		_ = file.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+		mounts, issues = append(mounts, fileMounts...), append(issues, fileIssues...)
+	}
+	return mounts, issues, nil
+}
+
+// readAutofs returns the NFS mounts of the maps of the autofs master map.
+func readAutofs(masterPath, mapDir string) ([]hostmount.Mount, []hostmount.Issue, error) {
+	master, err := os.Open(masterPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open %s: %v", masterPath, err)
+	}
+	entries, issues, err := hostmount.ParseMaster(master, masterPath)
+	_ = master.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+	var mounts []hostmount.Mount
+	for _, entry := range entries {
+		mapPath := entry.Map
+		switch {
+		case mapDir != "":
+			mapPath = filepath.Join(mapDir, filepath.Base(entry.Map))
+		case !strings.Contains(entry.Map, "/"):
+			mapPath = filepath.Join("/etc", entry.Map)
+		}
+		file, err := os.Open(mapPath)
+		if err != nil {
+			issues = append(issues, hostmount.Issue{Source: entry.Source, Message: fmt.Sprintf("failed to open the map: %v", err)})
+			continue
+		}
+		mapMounts, mapIssues, err := hostmount.ParseMap(file, mapPath, entry)
+		_ = file.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+		mounts, issues = append(mounts, mapMounts...), append(issues, mapIssues...)
+	}
+	return mounts, issues, nil
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// createOptions are the flags of the create command.
+type createOptions struct {
+	server      string
+	path        string
+	capacity    string
+	accessModes []string
+	nfsVersion  string
+	dryRun      bool
+}
+
+func newCreateCommand(o *options) *cobra.Command {
+	c := createOptions{}
+	cmd := &cobra.Command{
+		Use:   "create NAME --server SERVER --path PATH --capacity CAPACITY",
+		Short: "Create an NfsPvc from flags",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nfspvc, err := c.nfsPvc(args[0])
+			if err != nil {
+				return err
+			}
+			if c.dryRun {
+				namespace, err := o.Namespace()
+				if err != nil {
+					return err
+				}
+				nfspvc.Namespace = namespace
+				return printYAML(o, nfspvc)
+			}
+			k8sClient, namespace, err := o.setup()
+			if err != nil {
+				return err
+			}
+			nfspvc.Namespace = namespace
+			return create(cmd.Context(), o, k8sClient, nfspvc)
+		},
+	}
+	cmd.Flags().StringVar(&c.server, "server", "", "The hostname or IP address of the NFS server.")
+	cmd.Flags().StringVar(&c.path, "path", "", "The path exported by the server, provisioned by the storage backend of the operator when empty.")
+	cmd.Flags().StringVar(&c.capacity, "capacity", "", "The capacity of the NfsPvc, e.g. 10Gi.")
+	cmd.Flags().StringSliceVar(&c.accessModes, "access-mode", []string{string(corev1.ReadWriteMany)}, "The access modes of the NfsPvc.")
+	cmd.Flags().StringVar(&c.nfsVersion, "nfs-version", "", `The NFS version, 3, 4, 4.1, 4.2 or "auto". The default of the operator when empty.`)
+	cmd.Flags().BoolVar(&c.dryRun, "dry-run", false, "Print the NfsPvc instead of creating it.")
+	_ = cmd.MarkFlagRequired("server")
+	_ = cmd.MarkFlagRequired("capacity")
+	return cmd
+}
+
+// nfsPvc returns the nfspvc described by the flags.
+func (c createOptions) nfsPvc(name string) (*danaiov1alpha1.NfsPvc, error) {
+	capacity, err := resource.ParseQuantity(c.capacity)
+	if err != nil {
+		return nil, fmt.Errorf("invalid capacity %q: %v", c.capacity, err)
+	}
+	nfspvc := newNfsPvc(name, "")
+	nfspvc.Spec = danaiov1alpha1.NfsPvcSpec{
+		Server:     c.server,
+		Path:       c.path,
+		Capacity:   corev1.ResourceList{corev1.ResourceStorage: capacity},
+		NfsVersion: c.nfsVersion,
+	}
+	for _, mode := range c.accessModes {
+		nfspvc.Spec.AccessModes = append(nfspvc.Spec.AccessModes, corev1.PersistentVolumeAccessMode(mode))
+	}
+	return nfspvc, nil
+}
+
+// create creates the nfspvc.
+func create(ctx context.Context, o *options, k8sClient client.Client, nfspvc *danaiov1alpha1.NfsPvc) error {
+	if err := k8sClient.Create(ctx, nfspvc); err != nil {
+		return fmt.Errorf("failed to create nfspvc %s/%s: %v", nfspvc.Namespace, nfspvc.Name, err)
+	}
+	_, _ = fmt.Fprintf(o.out, "nfspvc %s/%s created\n", nfspvc.Namespace, nfspvc.Name)
+	return nil
+}
+
+// newNfsPvc returns an empty nfspvc with its type, so that it prints as a manifest.
+func newNfsPvc(name, namespace string) *danaiov1alpha1.NfsPvc {
+	return &danaiov1alpha1.NfsPvc{
+		TypeMeta:   metav1.TypeMeta{APIVersion: danaiov1alpha1.GroupVersion.String(), Kind: "NfsPvc"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+	}
+}
+
+// printYAML prints the object as a YAML document.
+func printYAML(o *options, object any) error {
+	data, err := yaml.Marshal(object)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the manifest: %v", err)
+	}
+	_, _ = fmt.Fprintf(o.out, "---\n%s", data)
+	return nil
+}
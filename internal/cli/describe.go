@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newDescribeCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "describe NAME",
+		Short: "Show an NfsPvc, its PV and PVC, the pods mounting it and their events in one view",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k8sClient, namespace, err := o.setup()
+			if err != nil {
+				return err
+			}
+			return describe(cmd.Context(), o, k8sClient, namespace, args[0])
+		},
+	}
+}
+
+// describe prints the nfspvc, its PV and PVC, its consumers and the events of the three.
+func describe(ctx context.Context, o *options, k8sClient client.Client, namespace, name string) error {
+	nfspvc, err := getNfsPvc(ctx, k8sClient, namespace, name)
+	if err != nil {
+		return err
+	}
+	observed, err := resources.Observe(ctx, *nfspvc, k8sClient)
+	if err != nil {
+		return err
+	}
+	consumers, err := listConsumers(ctx, k8sClient, *nfspvc)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(o.out, 0, 8, 2, ' ', 0)
+	field := func(indent, key, value string) {
+		_, _ = fmt.Fprintf(w, "%s%s:\t%s\n", indent, key, orNone(value))
+	}
+	field("", "Name", nfspvc.Name)
+	field("", "Namespace", nfspvc.Namespace)
+	field("", "Server", nfspvc.Spec.Server)
+	field("", "Path", resources.ExportPath(*nfspvc))
+	field("", "Capacity", capacity(*nfspvc))
+	field("", "Access Modes", accessModes(nfspvc.Spec.AccessModes))
+	field("", "NFS Version", resources.NfsVersion(*nfspvc))
+	field("", "Finalizers", strings.Join(nfspvc.Finalizers, ","))
+	if nfspvc.DeletionTimestamp != nil {
+		field("", "Deleting Since", nfspvc.DeletionTimestamp.UTC().Format(time.RFC3339))
+	}
+	if adopted := nfspvc.Annotations[danaiov1alpha1.AdoptedPVAnnotation]; adopted != "" {
+		field("", "Adopted PV", adopted)
+	}
+	if usage := nfspvc.Status.Usage; usage != nil {
+		field("", "Usage", fmt.Sprintf("%d bytes used, %d bytes available (measured by %s)", usage.UsedBytes, usage.AvailableBytes, usage.Node))
+	}
+	_, _ = fmt.Fprintln(w, "Conditions:")
+	if len(nfspvc.Status.Conditions) == 0 {
+		_, _ = fmt.Fprintln(w, "  "+none)
+	}
+	for _, condition := range nfspvc.Status.Conditions {
+		_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", condition.Type, condition.Status, condition.Reason, condition.Message)
+	}
+
+	if len(nfspvc.Status.Diagnostics) > 0 {
+		_, _ = fmt.Fprintln(w, "Diagnostics:")
+	}
+	for _, diagnostic := range nfspvc.Status.Diagnostics {
+		_, _ = fmt.Fprintf(w, "  %s\t%s\n", diagnostic.Check, diagnostic.Finding)
+	}
+
+	_, _ = fmt.Fprintln(w, "PVC:")
+	if pvc := observed.PVC; pvc == nil {
+		_, _ = fmt.Fprintln(w, "  "+none)
+	} else {
+		field("  ", "Name", pvc.Name)
+		field("  ", "Phase", string(pvc.Status.Phase))
+		field("  ", "Volume", pvc.Spec.VolumeName)
+		field("  ", "Storage Class", ptrValue(pvc.Spec.StorageClassName))
+	}
+	_, _ = fmt.Fprintln(w, "PV:")
+	if pv := observed.PV; pv == nil {
+		_, _ = fmt.Fprintf(w, "  %s (expected %s)\n", none, resources.PVName(*nfspvc))
+	} else {
+		field("  ", "Name", pv.Name)
+		field("  ", "Phase", string(pv.Status.Phase))
+		if pv.Spec.ClaimRef != nil {
+			field("  ", "Claim", pv.Spec.ClaimRef.Namespace+"/"+pv.Spec.ClaimRef.Name)
+		}
+		if pv.Spec.NFS != nil {
+			field("  ", "Export", pv.Spec.NFS.Server+":"+pv.Spec.NFS.Path)
+		}
+		field("  ", "Storage Class", pv.Spec.StorageClassName)
+		field("  ", "Reclaim Policy", string(pv.Spec.PersistentVolumeReclaimPolicy))
+		field("  ", "Mount Options", strings.Join(pv.Spec.MountOptions, ","))
+	}
+	field("", "Consumers", strings.Join(consumers, ","))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	events, err := listEvents(ctx, k8sClient, *nfspvc)
+	if err != nil {
+		return err
+	}
+	return printEvents(o.out, events)
+}
+
+// listEvents returns the events of the nfspvc, its PVC and its PV, the oldest first.
+// The events of the cluster-scoped PVs are recorded in the default namespace.
+func listEvents(ctx context.Context, k8sClient client.Client, nfspvc danaiov1alpha1.NfsPvc) ([]corev1.Event, error) {
+	var events []corev1.Event
+	for _, namespace := range []string{nfspvc.Namespace, corev1.NamespaceDefault} {
+		list := corev1.EventList{}
+		if err := k8sClient.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+			return nil, fmt.Errorf("failed to list events: %v", err)
+		}
+		for _, event := range list.Items {
+			involved := event.InvolvedObject
+			switch {
+			case involved.Kind == "NfsPvc" && involved.Namespace == nfspvc.Namespace && involved.Name == nfspvc.Name,
+				involved.Kind == "PersistentVolumeClaim" && involved.Namespace == nfspvc.Namespace && involved.Name == nfspvc.Name,
+				involved.Kind == "PersistentVolume" && involved.Name == resources.PVName(nfspvc):
+				if !slices.ContainsFunc(events, func(e corev1.Event) bool { return e.UID == event.UID }) {
+					events = append(events, event)
+				}
+			}
+		}
+	}
+	slices.SortStableFunc(events, func(a, b corev1.Event) int {
+		return eventTime(a).Compare(eventTime(b))
+	})
+	return events, nil
+}
+
+// printEvents prints the events as a table.
+func printEvents(out io.Writer, events []corev1.Event) error {
+	if len(events) == 0 {
+		_, _ = fmt.Fprintln(out, "Events:\t"+none)
+		return nil
+	}
+	_, _ = fmt.Fprintln(out, "Events:")
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "  LAST SEEN\tTYPE\tREASON\tOBJECT\tMESSAGE")
+	for _, event := range events {
+		_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\t%s/%s\t%s\n", age(eventTime(event)), event.Type, event.Reason,
+			strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name, strings.TrimSpace(event.Message))
+	}
+	return w.Flush()
+}
+
+// eventTime returns the last time the event was seen.
+func eventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// age returns the time elapsed since t, as kubectl prints it.
+func age(t time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(time.Since(t))
+}
+
+// capacity returns the storage capacity of the nfspvc.
+func capacity(nfspvc danaiov1alpha1.NfsPvc) string {
+	storage, ok := nfspvc.Spec.Capacity[corev1.ResourceStorage]
+	if !ok {
+		return ""
+	}
+	return storage.String()
+}
+
+// accessModes returns the access modes as a comma separated list.
+func accessModes(modes []corev1.PersistentVolumeAccessMode) string {
+	names := make([]string, 0, len(modes))
+	for _, mode := range modes {
+		names = append(names, string(mode))
+	}
+	return strings.Join(names, ",")
+}
+
+// ptrValue returns the value of a string pointer, empty when nil.
+func ptrValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
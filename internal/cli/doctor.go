@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/dana-team/nfspvc-operator/internal/controller/diagnostics"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newDoctorCommand(o *options) *cobra.Command {
+	var storageClass string
+	cmd := &cobra.Command{
+		Use:   "doctor NAME",
+		Short: "Explain why an NfsPvc is not bound",
+		Long: `Run the diagnostic checks of the operator against an NfsPvc, its PV and its PVC, and print
+the finding and a suggested fix of every failed check. It exits with an error when a check fails.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k8sClient, namespace, err := o.setup()
+			if err != nil {
+				return err
+			}
+			return doctor(cmd.Context(), o, k8sClient, namespace, args[0], storageClass)
+		},
+	}
+	cmd.Flags().StringVar(&storageClass, "storage-class", "", "The storage class of the operator, not checked when empty.")
+	return cmd
+}
+
+// doctor runs the diagnostic checks against the nfspvc and prints their results.
+func doctor(ctx context.Context, o *options, k8sClient client.Client, namespace, name, storageClass string) error {
+	nfspvc, err := getNfsPvc(ctx, k8sClient, namespace, name)
+	if err != nil {
+		return err
+	}
+	observed, err := resources.Observe(ctx, *nfspvc, k8sClient)
+	if err != nil {
+		return err
+	}
+	subject := diagnostics.Subject{NfsPvc: *nfspvc, Observed: observed, StorageClass: storageClass}
+
+	failed := 0
+	w := tabwriter.NewWriter(o.out, 0, 8, 2, ' ', 0)
+	for _, check := range diagnostics.Checks {
+		finding, fix := check.Run(subject)
+		if finding == "" {
+			_, _ = fmt.Fprintf(w, "PASS\t%s\t%s\n", check.Name, check.Description)
+			continue
+		}
+		failed++
+		_, _ = fmt.Fprintf(w, "FAIL\t%s\t%s\n", check.Name, finding)
+		_, _ = fmt.Fprintf(w, "\t\tfix: %s\n", fix)
+	}
+	_ = w.Flush()
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed for nfspvc %s/%s", failed, len(diagnostics.Checks), namespace, name)
+	}
+	return nil
+}
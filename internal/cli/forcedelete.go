@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func newForceDeleteCommand(o *options) *cobra.Command {
+	var ignoreConsumers bool
+	cmd := &cobra.Command{
+		Use:   "force-delete NAME",
+		Short: "Delete an NfsPvc stuck in deletion by removing the finalizer of the operator",
+		Long: `Delete an NfsPvc, delete its PVC and PV, and remove the nfspvc.dana.io/nfspvc-protection
+finalizer without waiting for the operator. It refuses while running pods mount the PVC.
+The reclaim policy of the operator and the deprovisioning of the export are skipped.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k8sClient, namespace, err := o.setup()
+			if err != nil {
+				return err
+			}
+			return forceDelete(cmd.Context(), o, k8sClient, namespace, args[0], ignoreConsumers)
+		},
+	}
+	cmd.Flags().BoolVar(&ignoreConsumers, "ignore-consumers", false, "Delete the NfsPvc even though running pods mount its PVC.")
+	return cmd
+}
+
+// forceDelete deletes the nfspvc, its pvc and its pv, and removes the finalizer of the nfspvc.
+func forceDelete(ctx context.Context, o *options, k8sClient client.Client, namespace, name string, ignoreConsumers bool) error {
+	nfspvc, err := getNfsPvc(ctx, k8sClient, namespace, name)
+	if err != nil {
+		return err
+	}
+	consumers, err := listConsumers(ctx, k8sClient, *nfspvc)
+	if err != nil {
+		return err
+	}
+	if len(consumers) > 0 && !ignoreConsumers {
+		return fmt.Errorf("pods %s mount the pvc of nfspvc %s/%s, stop them or use --ignore-consumers", strings.Join(consumers, ","), namespace, name)
+	}
+	if nfspvc.Annotations[danaiov1alpha1.MigrationAnnotation] != "" {
+		_, _ = fmt.Fprintf(o.errOut, "Warning: nfspvc %s/%s is being migrated by %q\n", namespace, name, nfspvc.Annotations[danaiov1alpha1.MigrationAnnotation])
+	}
+
+	if nfspvc.DeletionTimestamp == nil {
+		if err := k8sClient.Delete(ctx, nfspvc); err != nil {
+			return fmt.Errorf("failed to delete nfspvc %s/%s: %v", namespace, name, err)
+		}
+	}
+	observed, err := resources.Observe(ctx, *nfspvc, k8sClient)
+	if err != nil {
+		return err
+	}
+	if observed.PVC != nil {
+		if err := client.IgnoreNotFound(k8sClient.Delete(ctx, observed.PVC)); err != nil {
+			return fmt.Errorf("failed to delete pvc %s/%s: %v", namespace, name, err)
+		}
+	}
+	if observed.PV != nil {
+		if err := client.IgnoreNotFound(k8sClient.Delete(ctx, observed.PV)); err != nil {
+			return fmt.Errorf("failed to delete pv %q: %v", observed.PV.Name, err)
+		}
+	}
+
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(nfspvc), nfspvc); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			_, _ = fmt.Fprintf(o.out, "nfspvc %s/%s deleted\n", namespace, name)
+			return nil
+		}
+		return fmt.Errorf("failed to get nfspvc %s/%s: %v", namespace, name, err)
+	}
+	if controllerutil.ContainsFinalizer(nfspvc, utils.NfsPvcDeletionFinalizer) {
+		patch := client.MergeFrom(nfspvc.DeepCopy())
+		controllerutil.RemoveFinalizer(nfspvc, utils.NfsPvcDeletionFinalizer)
+		if err := k8sClient.Patch(ctx, nfspvc, patch); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to remove the finalizer of nfspvc %s/%s: %v", namespace, name, err)
+		}
+	}
+	_, _ = fmt.Fprintf(o.out, "nfspvc %s/%s force-deleted, its export was not reclaimed\n", namespace, name)
+	return nil
+}
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newImportCommand(o *options) *cobra.Command {
+	var (
+		allNamespaces bool
+		apply         bool
+		reportPath    string
+	)
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Generate the NfsPvcs adopting the existing NFS PVs and their bound PVCs",
+		Long: `Scan the PVs with spec.nfs whose PVC is in the namespace, and print the NfsPvc adopting each
+of them, as the adopt command does. With --apply, the NfsPvcs are created and the PVs and the PVCs
+are adopted instead. The PVs that cannot be converted, such as the ones with mount options the
+NfsPvc cannot express or without a bound PVC, are written to the report with the reason.
+The PVs already managed by an NfsPvc are skipped.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			k8sClient, namespace, err := o.setup()
+			if err != nil {
+				return err
+			}
+			if allNamespaces {
+				namespace = ""
+			}
+			report := o.errOut
+			if reportPath != "" {
+				file, err := os.Create(reportPath)
+				if err != nil {
+					return fmt.Errorf("failed to create the report: %v", err)
+				}
+				defer func() { _ = file.Close() }()
+				report = file
+			}
+			return importPVs(cmd.Context(), o, k8sClient, namespace, apply, report)
+		},
+	}
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Import the PVs bound to the PVCs of all the namespaces.")
+	cmd.Flags().BoolVar(&apply, "apply", false, "Create the NfsPvcs and adopt the PVs and the PVCs instead of printing the NfsPvcs.")
+	cmd.Flags().StringVar(&reportPath, "report", "", "Write the report of the PVs that cannot be converted to this file instead of the standard error.")
+	return cmd
+}
+
+// unconverted is an NFS PV that cannot be converted to an NfsPvc.
+type unconverted struct {
+	pv     string
+	claim  string
+	reason string
+}
+
+// importPVs prints, or adopts with apply, the nfspvcs of the NFS PVs bound to the PVCs of the namespace,
+// of all namespaces when it is empty, and writes the PVs it could not convert to the report.
+func importPVs(ctx context.Context, o *options, k8sClient client.Client, namespace string, apply bool, report io.Writer) error {
+	nfspvcs, failed, err := importingNfsPvcs(ctx, k8sClient, namespace)
+	if err != nil {
+		return err
+	}
+	converted := 0
+	for _, nfspvc := range nfspvcs {
+		if !apply {
+			if err := printYAML(o, nfspvc); err != nil {
+				return err
+			}
+			converted++
+			continue
+		}
+		if err := adopt(ctx, o, k8sClient, nfspvc.Namespace, nfspvc.Name, false); err != nil {
+			failed = append(failed, unconverted{
+				pv:     nfspvc.Annotations[danaiov1alpha1.AdoptedPVAnnotation],
+				claim:  nfspvc.Namespace + "/" + nfspvc.Name,
+				reason: err.Error(),
+			})
+			continue
+		}
+		converted++
+	}
+
+	if len(failed) > 0 {
+		w := tabwriter.NewWriter(report, 0, 8, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "PV\tCLAIM\tREASON")
+		for _, pv := range failed {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", pv.pv, orNone(pv.claim), pv.reason)
+		}
+		_ = w.Flush()
+	}
+	_, _ = fmt.Fprintf(o.errOut, "Converted %d NFS PVs, %d could not be converted.\n", converted, len(failed))
+	return nil
+}
+
+// importingNfsPvcs returns the nfspvcs adopting the NFS PVs bound to the PVCs of the namespace, of all
+// namespaces when it is empty, and the PVs that cannot be converted.
+func importingNfsPvcs(ctx context.Context, k8sClient client.Client, namespace string) ([]*danaiov1alpha1.NfsPvc, []unconverted, error) {
+	pvs := corev1.PersistentVolumeList{}
+	if err := k8sClient.List(ctx, &pvs); err != nil {
+		return nil, nil, fmt.Errorf("failed to list pvs: %v", err)
+	}
+	pvcs := corev1.PersistentVolumeClaimList{}
+	if err := k8sClient.List(ctx, &pvcs, client.InNamespace(namespace)); err != nil {
+		return nil, nil, fmt.Errorf("failed to list pvcs: %v", err)
+	}
+	existing := danaiov1alpha1.NfsPvcList{}
+	if err := k8sClient.List(ctx, &existing, client.InNamespace(namespace)); err != nil {
+		return nil, nil, fmt.Errorf("failed to list nfspvcs: %v", err)
+	}
+	claims := map[string]corev1.PersistentVolumeClaim{}
+	for _, pvc := range pvcs.Items {
+		claims[pvc.Namespace+"/"+pvc.Name] = pvc
+	}
+	adopting := map[string]string{}
+	for _, nfspvc := range existing.Items {
+		adopting[nfspvc.Namespace+"/"+nfspvc.Name] = nfspvc.Annotations[danaiov1alpha1.AdoptedPVAnnotation]
+	}
+
+	var nfspvcs []*danaiov1alpha1.NfsPvc
+	var failed []unconverted
+	for _, pv := range pvs.Items {
+		if pv.Spec.NFS == nil {
+			continue
+		}
+		ref := pv.Spec.ClaimRef
+		if ref == nil {
+			if namespace == "" {
+				failed = append(failed, unconverted{pv: pv.Name, reason: "the pv is not bound to a pvc"})
+			}
+			continue
+		}
+		if namespace != "" && ref.Namespace != namespace {
+			continue
+		}
+		if _, ok := pv.Labels[resources.NfsPvcOwnerLabel]; ok {
+			continue
+		}
+		claim := ref.Namespace + "/" + ref.Name
+		pvc, ok := claims[claim]
+		if !ok {
+			failed = append(failed, unconverted{pv: pv.Name, claim: claim, reason: "the pvc does not exist"})
+			continue
+		}
+		if pvc.Status.Phase != corev1.ClaimBound || pvc.Spec.VolumeName != pv.Name {
+			failed = append(failed, unconverted{pv: pv.Name, claim: claim, reason: "the pvc is not bound to the pv"})
+			continue
+		}
+		if adopted, ok := adopting[claim]; ok && adopted != pv.Name {
+			failed = append(failed, unconverted{pv: pv.Name, claim: claim, reason: "an nfspvc with the name of the pvc already exists"})
+			continue
+		}
+		nfspvc, unsupported, err := adoptingNfsPvc(pv, pvc)
+		if err != nil {
+			failed = append(failed, unconverted{pv: pv.Name, claim: claim, reason: err.Error()})
+			continue
+		}
+		if len(unsupported) > 0 {
+			failed = append(failed, unconverted{pv: pv.Name, claim: claim,
+				reason: fmt.Sprintf("the nfspvc cannot express the mount options %s; adopt the pvc to keep them on the pv only", strings.Join(unsupported, ","))})
+			continue
+		}
+		nfspvcs = append(nfspvcs, nfspvc)
+	}
+	return nfspvcs, failed, nil
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// none is printed for an empty value.
+const none = "<none>"
+
+func newListCommand(o *options) *cobra.Command {
+	var allNamespaces bool
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the NfsPvcs with their export, the phases of their PVC and PV and the pods mounting them",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			k8sClient, namespace, err := o.setup()
+			if err != nil {
+				return err
+			}
+			if allNamespaces {
+				namespace = ""
+			}
+			return list(cmd.Context(), o, k8sClient, namespace)
+		},
+	}
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List the NfsPvcs of all the namespaces.")
+	return cmd
+}
+
+// list prints the nfspvcs of the namespace, of all namespaces when it is empty.
+func list(ctx context.Context, o *options, k8sClient client.Client, namespace string) error {
+	nfspvcs := danaiov1alpha1.NfsPvcList{}
+	if err := k8sClient.List(ctx, &nfspvcs, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list nfspvcs: %v", err)
+	}
+	if len(nfspvcs.Items) == 0 {
+		_, _ = fmt.Fprintln(o.errOut, "No NfsPvcs found.")
+		return nil
+	}
+	pods := corev1.PodList{}
+	if err := k8sClient.List(ctx, &pods, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list pods: %v", err)
+	}
+
+	w := tabwriter.NewWriter(o.out, 0, 8, 2, ' ', 0)
+	header := "NAME\tSERVER\tPATH\tPVC\tPV\tCONSUMERS"
+	if namespace == "" {
+		header = "NAMESPACE\t" + header
+	}
+	_, _ = fmt.Fprintln(w, header)
+	for _, nfspvc := range nfspvcs.Items {
+		row := fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s", nfspvc.Name, nfspvc.Spec.Server, orNone(resources.ExportPath(nfspvc)),
+			orNone(nfspvc.Status.PvcPhase), orNone(nfspvc.Status.PvPhase), orNone(strings.Join(consumers(pods.Items, nfspvc), ",")))
+		if namespace == "" {
+			row = nfspvc.Namespace + "\t" + row
+		}
+		_, _ = fmt.Fprintln(w, row)
+	}
+	return w.Flush()
+}
+
+// consumers returns the sorted names of the running pods mounting the PVC of the nfspvc.
+func consumers(pods []corev1.Pod, nfspvc danaiov1alpha1.NfsPvc) []string {
+	var names []string
+	for _, pod := range pods {
+		if pod.Namespace != nfspvc.Namespace || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == nfspvc.Name {
+				names = append(names, pod.Name)
+				break
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// listConsumers returns the sorted names of the running pods mounting the PVC of the nfspvc.
+func listConsumers(ctx context.Context, k8sClient client.Client, nfspvc danaiov1alpha1.NfsPvc) ([]string, error) {
+	pods := corev1.PodList{}
+	if err := k8sClient.List(ctx, &pods, client.InNamespace(nfspvc.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %v", err)
+	}
+	return consumers(pods.Items, nfspvc), nil
+}
+
+// orNone returns the value, or none when it is empty.
+func orNone(value string) string {
+	if value == "" {
+		return none
+	}
+	return value
+}
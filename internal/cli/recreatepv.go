@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// pvProtectionFinalizer keeps a PV bound to a PVC from being deleted.
+const pvProtectionFinalizer = "kubernetes.io/pv-protection"
+
+func newRecreatePVCommand(o *options) *cobra.Command {
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "recreate-pv NAME",
+		Short: "Delete the PV of an NfsPvc so that the operator recreates it from the NfsPvc",
+		Long: `Delete the PV of an NfsPvc, without waiting for its unmount, so that the operator recreates it
+from the spec of the NfsPvc and binds the existing PVC to it again. Running pods keep their mount
+until they are restarted. With --timeout, wait until the PVC is bound to the new PV.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k8sClient, namespace, err := o.setup()
+			if err != nil {
+				return err
+			}
+			return recreatePV(cmd.Context(), o, k8sClient, namespace, args[0], timeout)
+		},
+	}
+	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "How long to wait for the PVC to be bound to the new PV, 0 to return at once.")
+	return cmd
+}
+
+// recreatePV deletes the pv of the nfspvc, and waits for the pvc to be bound to the pv recreated by the operator.
+func recreatePV(ctx context.Context, o *options, k8sClient client.Client, namespace, name string, timeout time.Duration) error {
+	nfspvc, err := getNfsPvc(ctx, k8sClient, namespace, name)
+	if err != nil {
+		return err
+	}
+	if nfspvc.DeletionTimestamp != nil {
+		return fmt.Errorf("nfspvc %s/%s is being deleted", namespace, name)
+	}
+	if migration := nfspvc.Annotations[danaiov1alpha1.MigrationAnnotation]; migration != "" {
+		return fmt.Errorf("nfspvc %s/%s is being migrated by %q", namespace, name, migration)
+	}
+	observed, err := resources.Observe(ctx, *nfspvc, k8sClient)
+	if err != nil {
+		return err
+	}
+
+	var oldUID string
+	if pv := observed.PV; pv != nil {
+		oldUID = string(pv.UID)
+		if pv.DeletionTimestamp == nil {
+			if err := client.IgnoreNotFound(k8sClient.Delete(ctx, pv)); err != nil {
+				return fmt.Errorf("failed to delete pv %q: %v", pv.Name, err)
+			}
+		}
+		if controllerutil.ContainsFinalizer(pv, pvProtectionFinalizer) {
+			patch := client.MergeFrom(pv.DeepCopy())
+			controllerutil.RemoveFinalizer(pv, pvProtectionFinalizer)
+			if err := client.IgnoreNotFound(k8sClient.Patch(ctx, pv, patch)); err != nil {
+				return fmt.Errorf("failed to remove the finalizers of pv %q: %v", pv.Name, err)
+			}
+		}
+		_, _ = fmt.Fprintf(o.out, "pv %q deleted\n", pv.Name)
+	}
+	if timeout <= 0 {
+		return nil
+	}
+
+	err = wait.PollUntilContextTimeout(ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		observed, err := resources.Observe(ctx, *nfspvc, k8sClient)
+		if err != nil {
+			return false, err
+		}
+		return observed.PV != nil && string(observed.PV.UID) != oldUID &&
+			observed.PVC != nil && observed.PVC.Status.Phase == corev1.ClaimBound, nil
+	})
+	if err != nil {
+		return fmt.Errorf("the pvc of nfspvc %s/%s is not bound to a new pv: %v", namespace, name, err)
+	}
+	_, _ = fmt.Fprintf(o.out, "pv %q recreated and bound\n", resources.PVName(*nfspvc))
+	return nil
+}
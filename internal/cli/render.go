@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	danaiov1beta1 "github.com/dana-team/nfspvc-operator/api/v1beta1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	"github.com/dana-team/nfspvc-operator/internal/nfsprobe"
+	webhookv1alpha1 "github.com/dana-team/nfspvc-operator/internal/webhook/v1alpha1"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// defaultNfsVersion is the default of the nfsVersion of the CRD, which the API server applies.
+const defaultNfsVersion = "3"
+
+// renderOptions are the configuration of the operator the PVs and the PVCs are rendered with.
+type renderOptions struct {
+	filenames          []string
+	configPath         string
+	storageClass       string
+	reclaimPolicy      string
+	allowedNfsVersions string
+}
+
+func newRenderCommand(o *options) *cobra.Command {
+	r := renderOptions{}
+	cmd := &cobra.Command{
+		Use:   "render [-f FILE]...",
+		Short: "Print the PV and the PVC the operator would create for NfsPvc manifests, without a cluster",
+		Long: `Read the NfsPvcs of manifests, from files or the standard input, run the validations of the
+webhook against them, and print the PV and the PVC the operator would create for them under the
+configuration of the operator, read from its configuration-nfspvc ConfigMap with --config and
+overridden by the flags. The other kinds of the manifests are skipped. It exits with an error when
+an NfsPvc is invalid, so that it can run as a pre-merge check.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := r.loadConfig(cmd); err != nil {
+				return err
+			}
+			namespace, err := o.Namespace()
+			if err != nil {
+				return err
+			}
+			if len(r.filenames) == 0 {
+				r.filenames = []string{"-"}
+			}
+			invalid := 0
+			for _, filename := range r.filenames {
+				fileInvalid, err := r.renderFile(o, cmd.InOrStdin(), filename, namespace)
+				if err != nil {
+					return err
+				}
+				invalid += fileInvalid
+			}
+			if invalid > 0 {
+				return fmt.Errorf("%d NfsPvcs are invalid", invalid)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVarP(&r.filenames, "filename", "f", nil, `The manifests to render, "-" for the standard input, which is the default.`)
+	cmd.Flags().StringVar(&r.configPath, "config", "", "The configuration-nfspvc ConfigMap of the operator.")
+	cmd.Flags().StringVar(&r.storageClass, "storage-class", "", "The storage class of the operator, "+utils.StorageClassEnv+" of the ConfigMap.")
+	cmd.Flags().StringVar(&r.reclaimPolicy, "reclaim-policy", string(corev1.PersistentVolumeReclaimRetain), "The reclaim policy of the operator, "+utils.ReclaimPolicyEnv+" of the ConfigMap.")
+	cmd.Flags().StringVar(&r.allowedNfsVersions, "allowed-nfs-versions", strings.Join(nfsprobe.Versions, ","), "The NFS versions the \"auto\" NfsPvcs may use, "+utils.AllowedNfsVersionsEnv+" of the ConfigMap.")
+	return cmd
+}
+
+// loadConfig reads the configuration of the ConfigMap, for the flags that are not set, and validates it.
+func (r *renderOptions) loadConfig(cmd *cobra.Command) error {
+	if r.configPath != "" {
+		data, err := os.ReadFile(r.configPath)
+		if err != nil {
+			return fmt.Errorf("failed to read the config: %v", err)
+		}
+		configMap := corev1.ConfigMap{}
+		if err := yaml.Unmarshal(data, &configMap); err != nil {
+			return fmt.Errorf("failed to parse the config: %v", err)
+		}
+		for _, setting := range []struct {
+			flag  string
+			env   string
+			value *string
+		}{
+			{flag: "storage-class", env: utils.StorageClassEnv, value: &r.storageClass},
+			{flag: "reclaim-policy", env: utils.ReclaimPolicyEnv, value: &r.reclaimPolicy},
+			{flag: "allowed-nfs-versions", env: utils.AllowedNfsVersionsEnv, value: &r.allowedNfsVersions},
+		} {
+			if configured, ok := configMap.Data[setting.env]; ok && !cmd.Flags().Changed(setting.flag) {
+				*setting.value = configured
+			}
+		}
+	}
+	if r.storageClass == "" {
+		return errors.New("the storage class is required, set --storage-class or --config")
+	}
+	if !utils.IsReclaimPolicyValid(r.reclaimPolicy) {
+		return fmt.Errorf("%s %q", utils.InvalidReclaimPolicyMsg, r.reclaimPolicy)
+	}
+	r.reclaimPolicy = utils.NormalizeReclaimPolicy(r.reclaimPolicy)
+	if _, valid := utils.ParseNfsVersions(r.allowedNfsVersions); !valid {
+		return fmt.Errorf("%s %q", utils.InvalidAllowedNfsVersionsMsg, r.allowedNfsVersions)
+	}
+	return nil
+}
+
+// renderFile prints the PVs and the PVCs of the nfspvcs of a file, "-" for in, and returns the number
+// of invalid nfspvcs.
+func (r *renderOptions) renderFile(o *options, in io.Reader, filename, namespace string) (int, error) {
+	reader := in
+	if filename != "-" {
+		file, err := os.Open(filename)
+		if err != nil {
+			return 0, fmt.Errorf("failed to open %s: %v", filename, err)
+		}
+		defer func() { _ = file.Close() }()
+		reader = file
+	}
+
+	invalid := 0
+	documents := utilyaml.NewYAMLReader(bufio.NewReader(reader))
+	for i := 1; ; i++ {
+		document, err := documents.Read()
+		if errors.Is(err, io.EOF) {
+			return invalid, nil
+		}
+		if err != nil {
+			return invalid, fmt.Errorf("failed to read %s: %v", filename, err)
+		}
+		typeMeta := metav1.TypeMeta{}
+		if err := yaml.Unmarshal(document, &typeMeta); err != nil {
+			return invalid, fmt.Errorf("failed to parse document %d of %s: %v", i, filename, err)
+		}
+		groupVersion, err := schema.ParseGroupVersion(typeMeta.APIVersion)
+		if err != nil || typeMeta.Kind != "NfsPvc" || groupVersion.Group != danaiov1alpha1.GroupVersion.Group {
+			continue
+		}
+
+		nfspvc, err := decodeNfsPvc(groupVersion, document)
+		if err != nil {
+			_, _ = fmt.Fprintf(o.errOut, "Error: document %d of %s: %v\n", i, filename, err)
+			invalid++
+			continue
+		}
+		if nfspvc.Namespace == "" {
+			nfspvc.Namespace = namespace
+		}
+		if nfspvc.Spec.NfsVersion == "" {
+			nfspvc.Spec.NfsVersion = defaultNfsVersion
+		}
+		warnings, err := webhookv1alpha1.ValidateNfsPvc(nfspvc)
+		for _, warning := range warnings {
+			if err == nil || warning != err.Error() {
+				_, _ = fmt.Fprintf(o.errOut, "Warning: nfspvc %s/%s: %s\n", nfspvc.Namespace, nfspvc.Name, warning)
+			}
+		}
+		if err != nil {
+			_, _ = fmt.Fprintf(o.errOut, "Error: nfspvc %s/%s: %v\n", nfspvc.Namespace, nfspvc.Name, err)
+			invalid++
+			continue
+		}
+		if err := r.render(o, nfspvc); err != nil {
+			return invalid, err
+		}
+	}
+}
+
+// decodeNfsPvc decodes an NfsPvc of any served version, converting a v1beta1 one through the hub
+// into the v1alpha1 the operator renders from.
+func decodeNfsPvc(groupVersion schema.GroupVersion, document []byte) (danaiov1alpha1.NfsPvc, error) {
+	nfspvc := danaiov1alpha1.NfsPvc{}
+	switch groupVersion.Version {
+	case danaiov1alpha1.GroupVersion.Version:
+		if err := yaml.UnmarshalStrict(document, &nfspvc); err != nil {
+			return nfspvc, err
+		}
+	case danaiov1beta1.GroupVersion.Version:
+		hub := danaiov1beta1.NfsPvc{}
+		if err := yaml.UnmarshalStrict(document, &hub); err != nil {
+			return nfspvc, err
+		}
+		if err := nfspvc.ConvertFrom(&hub); err != nil {
+			return nfspvc, fmt.Errorf("failed to convert nfspvc %q from %s: %v", hub.Name, groupVersion, err)
+		}
+	default:
+		return nfspvc, fmt.Errorf("unsupported version %s of NfsPvc", groupVersion)
+	}
+	return nfspvc, nil
+}
+
+// render prints the PVC and the PV of the nfspvc.
+func (r *renderOptions) render(o *options, nfspvc danaiov1alpha1.NfsPvc) error {
+	if nfspvc.Spec.NfsVersion == danaiov1alpha1.NfsVersionAuto {
+		allowed, _ := utils.ParseNfsVersions(r.allowedNfsVersions)
+		version, _ := nfsprobe.Negotiate(nfsprobe.Versions, allowed)
+		nfspvc.Status.NegotiatedVersion = version
+		_, _ = fmt.Fprintf(o.errOut, "Warning: nfspvc %s/%s: the PV is rendered with NFS version %s, the highest allowed one, assuming that the server supports it\n",
+			nfspvc.Namespace, nfspvc.Name, version)
+	}
+	if resources.ExportPath(nfspvc) == "" {
+		_, _ = fmt.Fprintf(o.errOut, "Warning: nfspvc %s/%s: the path is provisioned by the storage backend, so the PV is rendered without it\n",
+			nfspvc.Namespace, nfspvc.Name)
+	}
+	pvc := resources.PreparePVC(nfspvc, r.storageClass)
+	pvc.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"}
+	pv := resources.PreparePV(nfspvc, r.storageClass, utils.PVReclaimPolicyOf(r.reclaimPolicy))
+	pv.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolume"}
+	if err := printYAML(o, &pvc); err != nil {
+		return err
+	}
+	return printYAML(o, &pv)
+}
@@ -0,0 +1,125 @@
+// Package cli implements kubectl-nfspvc, the kubectl plugin for the day-to-day operations on NfsPvcs.
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(danaiov1alpha1.AddToScheme(scheme))
+}
+
+// options are the global flags of the plugin and the clients they resolve to.
+type options struct {
+	kubeconfig  string
+	kubeContext string
+	namespace   string
+
+	out    io.Writer
+	errOut io.Writer
+	// client is created from the kubeconfig on first use, unless it is set beforehand.
+	client client.Client
+}
+
+// NewRootCommand returns the kubectl-nfspvc command.
+func NewRootCommand() *cobra.Command {
+	return newRootCommand(&options{out: os.Stdout, errOut: os.Stderr})
+}
+
+func newRootCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "kubectl-nfspvc",
+		Short:         "Operate the NfsPvcs of the nfspvc-operator",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	cmd.SetOut(o.out)
+	cmd.SetErr(o.errOut)
+	cmd.PersistentFlags().StringVar(&o.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file.")
+	cmd.PersistentFlags().StringVar(&o.kubeContext, "context", "", "The kubeconfig context to use.")
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "", "The namespace of the NfsPvcs, the namespace of the context when empty.")
+
+	cmd.AddCommand(
+		newListCommand(o),
+		newDescribeCommand(o),
+		newCreateCommand(o),
+		newAdoptCommand(o),
+		newForceDeleteCommand(o),
+		newRecreatePVCommand(o),
+		newDoctorCommand(o),
+		newImportCommand(o),
+		newConvertCommand(o),
+		newRenderCommand(o),
+	)
+	return cmd
+}
+
+// clientConfig returns the kubeconfig selected by the flags and the environment.
+func (o *options) clientConfig() clientcmd.ClientConfig {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = o.kubeconfig
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{CurrentContext: o.kubeContext})
+}
+
+// Client returns the client of the cluster.
+func (o *options) Client() (client.Client, error) {
+	if o.client != nil {
+		return o.client, nil
+	}
+	config, err := o.clientConfig().ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the kubeconfig: %v", err)
+	}
+	if o.client, err = client.New(config, client.Options{Scheme: scheme}); err != nil {
+		return nil, fmt.Errorf("failed to create the client: %v", err)
+	}
+	return o.client, nil
+}
+
+// Namespace returns the namespace of the flag, or the namespace of the kubeconfig context.
+func (o *options) Namespace() (string, error) {
+	if o.namespace != "" {
+		return o.namespace, nil
+	}
+	namespace, _, err := o.clientConfig().Namespace()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the namespace of the kubeconfig: %v", err)
+	}
+	return namespace, nil
+}
+
+// setup returns the client and the namespace of a command.
+func (o *options) setup() (client.Client, string, error) {
+	k8sClient, err := o.Client()
+	if err != nil {
+		return nil, "", err
+	}
+	namespace, err := o.Namespace()
+	if err != nil {
+		return nil, "", err
+	}
+	return k8sClient, namespace, nil
+}
+
+// getNfsPvc returns the nfspvc of the namespace.
+func getNfsPvc(ctx context.Context, k8sClient client.Client, namespace, name string) (*danaiov1alpha1.NfsPvc, error) {
+	nfspvc := &danaiov1alpha1.NfsPvc{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, nfspvc); err != nil {
+		return nil, fmt.Errorf("failed to get nfspvc %s/%s: %v", namespace, name, err)
+	}
+	return nfspvc, nil
+}
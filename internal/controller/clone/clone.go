@@ -0,0 +1,183 @@
+package clone
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/jobs"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// Reasons of the Populating condition.
+	SourceNotFoundReason = "SourceNotFound"
+	SourceNotBoundReason = "SourceNotBound"
+	NotGrantedReason     = "NotGranted"
+	CopyingReason        = "Copying"
+	CopyFailedReason     = "CopyFailed"
+	PopulatedReason      = "Populated"
+
+	jobKind     = "clone"
+	namePrefix  = "nfspvc-clone-"
+	sourceMount = "/source"
+	destMount   = "/destination"
+)
+
+// Populate copies the data of the dataSource of the nfspvc into its export and returns
+// the Populating condition of the nfspvc. The copy Job mounts both exports through
+// temporary PVCs, so that it uses the mount options of the nfspvcs.
+func Populate(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, k8sClient client.Client, scheme *runtime.Scheme) (metav1.Condition, error) {
+	source, condition, err := resolveSource(ctx, nfspvc, k8sClient)
+	if err != nil || source == nil {
+		return condition, err
+	}
+
+	sourceClaim := source.Name
+	if source.Namespace != nfspvc.Namespace {
+		claim := sourceClaimNfsPvc(nfspvc, *source)
+		if err := resources.EnsureClaim(ctx, claim, k8sClient); err != nil {
+			return condition, err
+		}
+		sourceClaim = claim.Name
+	}
+	destination := destinationClaimNfsPvc(nfspvc)
+	if err := resources.EnsureClaim(ctx, destination, k8sClient); err != nil {
+		return condition, err
+	}
+
+	job := jobs.PrepareJob(JobName(nfspvc), nfspvc.Namespace, jobKind, utils.DataMoverImage,
+		jobs.CopyScript(jobs.RsyncMethod, sourceMount, destMount, false),
+		jobs.ClaimVolume("source", sourceMount, sourceClaim, true),
+		jobs.ClaimVolume("destination", destMount, destination.Name, false),
+	)
+	if err := controllerutil.SetControllerReference(&nfspvc, &job, scheme); err != nil {
+		return condition, err
+	}
+	job, err = jobs.Ensure(ctx, k8sClient, job)
+	if err != nil {
+		return condition, err
+	}
+	state, err := jobs.Observe(ctx, k8sClient, job)
+	if err != nil {
+		return condition, err
+	}
+
+	switch state.Phase {
+	case jobs.Failed:
+		return populatingCondition(metav1.ConditionTrue, CopyFailedReason, fmt.Sprintf("job %q failed: %s", job.Name, state.Message)), nil
+	case jobs.Succeeded:
+		if err := Cleanup(ctx, nfspvc, k8sClient); err != nil {
+			return condition, err
+		}
+		return populatingCondition(metav1.ConditionFalse, PopulatedReason,
+			fmt.Sprintf("copied %s bytes in %s files from %s/%s", state.Results[jobs.SizeResult], state.Results[jobs.FilesResult], source.Namespace, source.Name)), nil
+	}
+	return populatingCondition(metav1.ConditionTrue, CopyingReason, fmt.Sprintf("job %q is copying the data of %s/%s", job.Name, source.Namespace, source.Name)), nil
+}
+
+// Cleanup deletes the copy Job and the temporary PVCs and PVs of the nfspvc.
+func Cleanup(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, k8sClient client.Client) error {
+	if err := jobs.Delete(ctx, k8sClient, JobName(nfspvc), nfspvc.Namespace); err != nil {
+		return fmt.Errorf("failed to delete job %q: %v", JobName(nfspvc), err)
+	}
+	for _, name := range []string{claimName(nfspvc, "source"), claimName(nfspvc, "destination")} {
+		if err := resources.DeleteClaim(ctx, name, nfspvc.Namespace, k8sClient); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// JobName returns the name of the Job populating the nfspvc.
+func JobName(nfspvc danaiov1alpha1.NfsPvc) string {
+	return utils.ShortenName(namePrefix+nfspvc.Name, utils.MaxLabelValueLength)
+}
+
+// IsGranted returns true if the source nfspvc may be cloned into the namespace.
+func IsGranted(source danaiov1alpha1.NfsPvc, namespace string) bool {
+	if source.Namespace == namespace {
+		return true
+	}
+	grants := strings.Split(source.Annotations[danaiov1alpha1.CloneGrantAnnotation], ",")
+	for i := range grants {
+		grants[i] = strings.TrimSpace(grants[i])
+	}
+	return slices.Contains(grants, namespace) || slices.Contains(grants, "*")
+}
+
+// resolveSource returns the source nfspvc, or nil and the reason it cannot be cloned.
+func resolveSource(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, k8sClient client.Client) (*danaiov1alpha1.NfsPvc, metav1.Condition, error) {
+	key := types.NamespacedName{Name: nfspvc.Spec.DataSource.Name, Namespace: nfspvc.Spec.DataSource.Namespace}
+	if key.Namespace == "" {
+		key.Namespace = nfspvc.Namespace
+	}
+	source := &danaiov1alpha1.NfsPvc{}
+	if err := k8sClient.Get(ctx, key, source); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, populatingCondition(metav1.ConditionTrue, SourceNotFoundReason, fmt.Sprintf("source nfspvc %s not found", key)), nil
+		}
+		return nil, metav1.Condition{}, fmt.Errorf("failed to fetch source nfspvc %s: %v", key, err)
+	}
+	if !IsGranted(*source, nfspvc.Namespace) {
+		return nil, populatingCondition(metav1.ConditionTrue, NotGrantedReason,
+			fmt.Sprintf("source nfspvc %s does not list namespace %q in its %s annotation", key, nfspvc.Namespace, danaiov1alpha1.CloneGrantAnnotation)), nil
+	}
+	if source.Status.PvcPhase != string(corev1.ClaimBound) {
+		return nil, populatingCondition(metav1.ConditionTrue, SourceNotBoundReason, fmt.Sprintf("source nfspvc %s is not bound", key)), nil
+	}
+	return source, metav1.Condition{}, nil
+}
+
+// sourceClaimNfsPvc returns the temporary nfspvc mounting a source from another namespace read-only.
+// The claim mounts the export path of the source, which the storage backend provisioned when the
+// source has no path.
+func sourceClaimNfsPvc(nfspvc, source danaiov1alpha1.NfsPvc) danaiov1alpha1.NfsPvc {
+	claim := danaiov1alpha1.NfsPvc{
+		ObjectMeta: metav1.ObjectMeta{Name: claimName(nfspvc, "source"), Namespace: nfspvc.Namespace},
+		Spec:       *source.Spec.DeepCopy(),
+		Status:     danaiov1alpha1.NfsPvcStatus{NegotiatedVersion: source.Status.NegotiatedVersion},
+	}
+	claim.Spec.Path = resources.ExportPath(source)
+	claim.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadOnlyMany}
+	claim.Spec.DataSource = nil
+	return claim
+}
+
+// destinationClaimNfsPvc returns the temporary nfspvc mounting the export of the nfspvc.
+func destinationClaimNfsPvc(nfspvc danaiov1alpha1.NfsPvc) danaiov1alpha1.NfsPvc {
+	claim := danaiov1alpha1.NfsPvc{
+		ObjectMeta: metav1.ObjectMeta{Name: claimName(nfspvc, "destination"), Namespace: nfspvc.Namespace},
+		Spec:       *nfspvc.Spec.DeepCopy(),
+		Status:     danaiov1alpha1.NfsPvcStatus{NegotiatedVersion: nfspvc.Status.NegotiatedVersion},
+	}
+	claim.Spec.Path = resources.ExportPath(nfspvc)
+	claim.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+	claim.Spec.DataSource = nil
+	return claim
+}
+
+// claimName returns the name of a temporary PVC of the nfspvc.
+func claimName(nfspvc danaiov1alpha1.NfsPvc, role string) string {
+	return utils.ShortenName(namePrefix+role+"-"+nfspvc.Name, utils.MaxLabelValueLength)
+}
+
+// populatingCondition returns the Populating condition of an nfspvc.
+func populatingCondition(status metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    danaiov1alpha1.PopulatingNfsPvcCondition,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+}
@@ -0,0 +1,116 @@
+package clone
+
+import (
+	"context"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("IsGranted", func() {
+	source := danaiov1alpha1.NfsPvc{ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "production"}}
+
+	It("should grant the namespace of the source", func() {
+		Expect(IsGranted(source, "production")).To(BeTrue())
+	})
+
+	It("should only grant the listed namespaces", func() {
+		Expect(IsGranted(source, "testing")).To(BeFalse())
+		source.Annotations = map[string]string{danaiov1alpha1.CloneGrantAnnotation: "staging, testing"}
+		Expect(IsGranted(source, "testing")).To(BeTrue())
+		Expect(IsGranted(source, "other")).To(BeFalse())
+		source.Annotations[danaiov1alpha1.CloneGrantAnnotation] = "*"
+		Expect(IsGranted(source, "other")).To(BeTrue())
+	})
+})
+
+var _ = Describe("Populate", func() {
+	var (
+		k8sClient client.Client
+		scheme    *runtime.Scheme
+		nfspvc    danaiov1alpha1.NfsPvc
+	)
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(danaiov1alpha1.AddToScheme(scheme)).To(Succeed())
+		source := &danaiov1alpha1.NfsPvc{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "production"},
+			Spec:       danaiov1alpha1.NfsPvcSpec{Server: "nfs", Path: "/prod", NfsVersion: "4.1"},
+			Status:     danaiov1alpha1.NfsPvcStatus{PvcPhase: string(corev1.ClaimBound)},
+		}
+		nfspvc = danaiov1alpha1.NfsPvc{
+			ObjectMeta: metav1.ObjectMeta{Name: "copy", Namespace: "testing", UID: "copy-uid"},
+			Spec: danaiov1alpha1.NfsPvcSpec{
+				Server:     "nfs",
+				Path:       "/copy",
+				NfsVersion: "4.1",
+				DataSource: &danaiov1alpha1.NfsPvcDataSource{Name: "prod", Namespace: "production"},
+			},
+		}
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(source).Build()
+	})
+
+	It("should wait for a grant of the source", func() {
+		condition, err := Populate(context.Background(), nfspvc, k8sClient, scheme)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(NotGrantedReason))
+		Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: JobName(nfspvc), Namespace: "testing"}, &batchv1.Job{})).NotTo(Succeed())
+	})
+
+	It("should copy the source and clean up once it is copied", func() {
+		source := &danaiov1alpha1.NfsPvc{}
+		Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: "prod", Namespace: "production"}, source)).To(Succeed())
+		source.Annotations = map[string]string{danaiov1alpha1.CloneGrantAnnotation: "testing"}
+		Expect(k8sClient.Update(context.Background(), source)).To(Succeed())
+
+		condition, err := Populate(context.Background(), nfspvc, k8sClient, scheme)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(condition.Reason).To(Equal(CopyingReason))
+
+		sourceClaim := corev1.PersistentVolumeClaim{}
+		Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: claimName(nfspvc, "source"), Namespace: "testing"}, &sourceClaim)).To(Succeed())
+		Expect(sourceClaim.Spec.AccessModes).To(ConsistOf(corev1.ReadOnlyMany))
+
+		job := batchv1.Job{}
+		Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: JobName(nfspvc), Namespace: "testing"}, &job)).To(Succeed())
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		Expect(k8sClient.Status().Update(context.Background(), &job)).To(Succeed())
+
+		condition, err = Populate(context.Background(), nfspvc, k8sClient, scheme)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(PopulatedReason))
+		Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: claimName(nfspvc, "source"), Namespace: "testing"}, &sourceClaim)).NotTo(Succeed())
+	})
+	It("should mount the exports provisioned by the storage backend", func() {
+		source := &danaiov1alpha1.NfsPvc{}
+		Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: "prod", Namespace: "production"}, source)).To(Succeed())
+		source.Annotations = map[string]string{danaiov1alpha1.CloneGrantAnnotation: "testing"}
+		source.Spec.Path = ""
+		source.Status.Path = "/exports/production_prod"
+		Expect(k8sClient.Update(context.Background(), source)).To(Succeed())
+		nfspvc.Spec.Path = ""
+		nfspvc.Status.Path = "/exports/testing_copy"
+
+		_, err := Populate(context.Background(), nfspvc, k8sClient, scheme)
+		Expect(err).NotTo(HaveOccurred())
+		for role, path := range map[string]string{"source": "/exports/production_prod", "destination": "/exports/testing_copy"} {
+			claim := danaiov1alpha1.NfsPvc{ObjectMeta: metav1.ObjectMeta{Name: claimName(nfspvc, role), Namespace: "testing"}}
+			pv := corev1.PersistentVolume{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: resources.PVName(claim)}, &pv)).To(Succeed())
+			Expect(pv.Spec.NFS.Path).To(Equal(path))
+		}
+	})
+})
@@ -0,0 +1,162 @@
+package clusternfspvc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/sharding"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	ClusterNfsPvcLabel = "nfspvc.dana.io/cluster-nfspvc"
+)
+
+// Sync materializes an NfsPvc in every selected namespace managed by the scope, patches the copies
+// whose mutable spec drifted from the template, and deletes the copies in managed namespaces that are
+// no longer selected. The status is computed from the reader, which
+// must see every namespace, so that all the shards report the same status.
+func Sync(ctx context.Context, clusterNfsPvc danaiov1alpha1.ClusterNfsPvc, k8sClient client.Client, reader client.Reader, scope sharding.Scope, scheme *runtime.Scheme) (danaiov1alpha1.ClusterNfsPvcStatus, error) {
+	logger := log.FromContext(ctx)
+	namespaces, err := selectedNamespaces(ctx, clusterNfsPvc, scope, reader)
+	if err != nil {
+		return clusterNfsPvc.Status, err
+	}
+
+	copies, err := ListCopies(ctx, clusterNfsPvc, reader)
+	if err != nil {
+		return clusterNfsPvc.Status, err
+	}
+	materialized := make(map[string]danaiov1alpha1.NfsPvc, len(copies))
+	for _, nfspvc := range copies {
+		materialized[nfspvc.Namespace] = nfspvc
+	}
+
+	status := danaiov1alpha1.ClusterNfsPvcStatus{ObservedGeneration: clusterNfsPvc.Generation}
+	for namespace, managed := range namespaces {
+		if existing, ok := materialized[namespace]; ok {
+			if managed {
+				if err := patchMutableSpec(ctx, clusterNfsPvc, existing, k8sClient); err != nil {
+					logger.Info(fmt.Sprintf("failed to patch nfspvc in namespace %q: %s", namespace, err.Error()))
+					status.FailedNamespaces = append(status.FailedNamespaces, namespace)
+					continue
+				}
+			}
+			status.Namespaces = append(status.Namespaces, namespace)
+			continue
+		}
+		if !managed {
+			status.FailedNamespaces = append(status.FailedNamespaces, namespace)
+			continue
+		}
+		nfspvc := PrepareNfsPvc(clusterNfsPvc, namespace)
+		if err := controllerutil.SetControllerReference(&clusterNfsPvc, &nfspvc, scheme); err != nil {
+			return clusterNfsPvc.Status, err
+		}
+		if err := k8sClient.Create(ctx, &nfspvc); err != nil {
+			logger.Info(fmt.Sprintf("failed to materialize nfspvc in namespace %q: %s", namespace, err.Error()))
+			status.FailedNamespaces = append(status.FailedNamespaces, namespace)
+			continue
+		}
+		status.Namespaces = append(status.Namespaces, namespace)
+	}
+
+	for _, nfspvc := range copies {
+		if _, selected := namespaces[nfspvc.Namespace]; selected {
+			continue
+		}
+		if managed, err := scope.ManagesNamespace(ctx, reader, nfspvc.Namespace); err != nil || !managed {
+			continue
+		}
+		if err := k8sClient.Delete(ctx, &nfspvc); client.IgnoreNotFound(err) != nil {
+			return clusterNfsPvc.Status, fmt.Errorf("failed to delete nfspvc %q in namespace %q: %v", nfspvc.Name, nfspvc.Namespace, err)
+		}
+	}
+
+	sort.Strings(status.Namespaces)
+	sort.Strings(status.FailedNamespaces)
+	return status, nil
+}
+
+// patchMutableSpec patches the fields of the spec of the copy that may change after its creation,
+// when they drifted from the template. The other fields are immutable, or only changed by an
+// NfsPvcMigration or an NfsServerFailover.
+func patchMutableSpec(ctx context.Context, clusterNfsPvc danaiov1alpha1.ClusterNfsPvc, existing danaiov1alpha1.NfsPvc, k8sClient client.Client) error {
+	template := clusterNfsPvc.Spec.Template.Spec.NfsPvcSpec()
+	desired := existing.DeepCopy()
+	desired.Spec.NfsVersion = template.NfsVersion
+	desired.Spec.CreatePath = template.CreatePath.DeepCopy()
+	desired.Spec.MountInstructions = template.MountInstructions.DeepCopy()
+	if equality.Semantic.DeepEqual(existing.Spec, desired.Spec) {
+		return nil
+	}
+	return k8sClient.Patch(ctx, desired, client.MergeFrom(&existing))
+}
+
+// PrepareNfsPvc returns the NfsPvc the ClusterNfsPvc materializes in the given namespace.
+func PrepareNfsPvc(clusterNfsPvc danaiov1alpha1.ClusterNfsPvc, namespace string) danaiov1alpha1.NfsPvc {
+	labels := map[string]string{}
+	for key, value := range clusterNfsPvc.Spec.Template.Labels {
+		labels[key] = value
+	}
+	labels[ClusterNfsPvcLabel] = clusterNfsPvc.Name
+
+	annotations := map[string]string{}
+	for key, value := range clusterNfsPvc.Spec.Template.Annotations {
+		annotations[key] = value
+	}
+
+	return danaiov1alpha1.NfsPvc{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        clusterNfsPvc.Name,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: clusterNfsPvc.Spec.Template.Spec.NfsPvcSpec(),
+	}
+}
+
+// ListCopies returns the NfsPvcs materialized by the ClusterNfsPvc.
+func ListCopies(ctx context.Context, clusterNfsPvc danaiov1alpha1.ClusterNfsPvc, k8sClient client.Reader) ([]danaiov1alpha1.NfsPvc, error) {
+	nfspvcList := danaiov1alpha1.NfsPvcList{}
+	if err := k8sClient.List(ctx, &nfspvcList, client.MatchingLabels{ClusterNfsPvcLabel: clusterNfsPvc.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list materialized nfspvcs: %v", err)
+	}
+	var copies []danaiov1alpha1.NfsPvc
+	for _, nfspvc := range nfspvcList.Items {
+		if metav1.IsControlledBy(&nfspvc, &clusterNfsPvc) {
+			copies = append(copies, nfspvc)
+		}
+	}
+	return copies, nil
+}
+
+// selectedNamespaces returns the active namespaces matching the namespace selector of the ClusterNfsPvc,
+// mapped to whether the scope manages them.
+func selectedNamespaces(ctx context.Context, clusterNfsPvc danaiov1alpha1.ClusterNfsPvc, scope sharding.Scope, k8sClient client.Reader) (map[string]bool, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&clusterNfsPvc.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespaceSelector: %v", err)
+	}
+	namespaceList := corev1.NamespaceList{}
+	if err := k8sClient.List(ctx, &namespaceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %v", err)
+	}
+	namespaces := make(map[string]bool, len(namespaceList.Items))
+	for _, namespace := range namespaceList.Items {
+		if namespace.Status.Phase == corev1.NamespaceTerminating {
+			continue
+		}
+		namespaces[namespace.Name] = scope.Manages(namespace)
+	}
+	return namespaces, nil
+}
@@ -0,0 +1,146 @@
+package clusternfspvc
+
+import (
+	"context"
+	"errors"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/sharding"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("Sync", func() {
+	var (
+		ctx           context.Context
+		scheme        *runtime.Scheme
+		k8sClient     client.Client
+		clusterNfsPvc danaiov1alpha1.ClusterNfsPvc
+		failCreate    bool
+	)
+
+	namespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+
+	sync := func() danaiov1alpha1.ClusterNfsPvcStatus {
+		status, err := Sync(ctx, clusterNfsPvc, k8sClient, k8sClient, sharding.Scope{}, scheme)
+		Expect(err).NotTo(HaveOccurred())
+		return status
+	}
+
+	getCopy := func(namespace string) (danaiov1alpha1.NfsPvc, error) {
+		nfspvc := danaiov1alpha1.NfsPvc{}
+		err := k8sClient.Get(ctx, client.ObjectKey{Name: clusterNfsPvc.Name, Namespace: namespace}, &nfspvc)
+		return nfspvc, err
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		failCreate = false
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(danaiov1alpha1.AddToScheme(scheme)).To(Succeed())
+		clusterNfsPvc = danaiov1alpha1.ClusterNfsPvc{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared", UID: "shared-uid", Generation: 2},
+			Spec: danaiov1alpha1.ClusterNfsPvcSpec{
+				NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"shared": "true"}},
+				Template: danaiov1alpha1.NfsPvcTemplateSpec{
+					Labels: map[string]string{"app": "web"},
+					Spec: danaiov1alpha1.NfsPvcSpecTemplate{
+						AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+						Capacity:    corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+						Server:      "nas",
+						Path:        "/exports/shared",
+						NfsVersion:  "3",
+					},
+				},
+			},
+		}
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			namespace("team-a", map[string]string{"shared": "true"}),
+			namespace("team-b", map[string]string{"shared": "true"}),
+			namespace("other", nil),
+		).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, ok := obj.(*danaiov1alpha1.NfsPvc); ok && failCreate && obj.GetNamespace() == "team-b" {
+					return errors.New("quota exceeded")
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).Build()
+	})
+
+	It("should materialize an nfspvc in every selected namespace", func() {
+		status := sync()
+		Expect(status.ObservedGeneration).To(BeEquivalentTo(2))
+		Expect(status.Namespaces).To(Equal([]string{"team-a", "team-b"}))
+		Expect(status.FailedNamespaces).To(BeEmpty())
+
+		nfspvc, err := getCopy("team-a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(metav1.IsControlledBy(&nfspvc, &clusterNfsPvc)).To(BeTrue())
+		Expect(nfspvc.Labels).To(HaveKeyWithValue(ClusterNfsPvcLabel, "shared"))
+		Expect(nfspvc.Labels).To(HaveKeyWithValue("app", "web"))
+		Expect(nfspvc.Spec.Path).To(Equal("/exports/shared"))
+		_, err = getCopy("other")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should patch the mutable spec of the copies that drifted from the template", func() {
+		sync()
+		clusterNfsPvc.Spec.Template.Spec.NfsVersion = "4.1"
+		clusterNfsPvc.Spec.Template.Spec.MountInstructions = &danaiov1alpha1.NfsPvcMountInstructions{MountPoint: "/mnt/shared"}
+		clusterNfsPvc.Spec.Template.Spec.Path = "/exports/moved"
+		Expect(sync().Namespaces).To(Equal([]string{"team-a", "team-b"}))
+
+		nfspvc, err := getCopy("team-b")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nfspvc.Spec.NfsVersion).To(Equal("4.1"))
+		Expect(nfspvc.Spec.MountInstructions.MountPoint).To(Equal("/mnt/shared"))
+		Expect(nfspvc.Spec.Path).To(Equal("/exports/shared"))
+	})
+
+	It("should report the namespaces it failed to materialize and retry them", func() {
+		failCreate = true
+		status := sync()
+		Expect(status.Namespaces).To(Equal([]string{"team-a"}))
+		Expect(status.FailedNamespaces).To(Equal([]string{"team-b"}))
+
+		failCreate = false
+		status = sync()
+		Expect(status.Namespaces).To(Equal([]string{"team-a", "team-b"}))
+		Expect(status.FailedNamespaces).To(BeEmpty())
+	})
+
+	It("should report the selected namespaces the scope does not manage", func() {
+		scope, err := sharding.NewScope("team-a", "team-a", "")
+		Expect(err).NotTo(HaveOccurred())
+		status, err := Sync(ctx, clusterNfsPvc, k8sClient, k8sClient, scope, scheme)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Namespaces).To(Equal([]string{"team-a"}))
+		Expect(status.FailedNamespaces).To(Equal([]string{"team-b"}))
+	})
+
+	It("should delete the copies of the namespaces no longer selected", func() {
+		sync()
+		team := corev1.Namespace{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: "team-b"}, &team)).To(Succeed())
+		team.Labels = nil
+		Expect(k8sClient.Update(ctx, &team)).To(Succeed())
+
+		Expect(sync().Namespaces).To(Equal([]string{"team-a"}))
+		_, err := getCopy("team-b")
+		Expect(err).To(HaveOccurred())
+		_, err = getCopy("team-a")
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/clusternfspvc"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	"github.com/dana-team/nfspvc-operator/internal/sharding"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ClusterNfsPvcReconciler reconciles a ClusterNfsPvc object
+type ClusterNfsPvcReconciler struct {
+	client.Client
+	Scheme  *runtime.Scheme
+	Log     logr.Logger
+	Options controller.Options
+	Scope   sharding.Scope
+	// Reader lists the NfsPvcs and the namespaces of the whole cluster. It defaults to the client,
+	// whose cache only holds the namespaces of the scope.
+	Reader client.Reader
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterNfsPvcReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&danaiov1alpha1.ClusterNfsPvc{}).
+		WithOptions(r.Options).
+		Owns(&danaiov1alpha1.NfsPvc{}).
+		Watches(&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueRequestsFromNamespace),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}),
+		).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=nfspvc.dana.io,resources=clusternfspvcs,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=nfspvc.dana.io,resources=clusternfspvcs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+func (r *ClusterNfsPvcReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("ClusterNfsPvc", req.Name)
+	logger.Info("Starting Reconcile")
+	clusterNfsPvc := danaiov1alpha1.ClusterNfsPvc{}
+	if err := r.Get(ctx, req.NamespacedName, &clusterNfsPvc); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("Didn't find ClusterNfsPvc")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get ClusterNfsPvc: %s", err.Error())
+	}
+	if clusterNfsPvc.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	reader := r.Reader
+	if reader == nil {
+		reader = r.Client
+	}
+	newStatus, err := clusternfspvc.Sync(ctx, clusterNfsPvc, r.Client, reader, r.Scope, r.Scheme)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to sync ClusterNfsPvc: %s", err.Error())
+	}
+	if !reflect.DeepEqual(newStatus, clusterNfsPvc.Status) {
+		if err := utils.RetryOnConflictUpdate(ctx, r.Client, &clusterNfsPvc, clusterNfsPvc.Name, "", func(obj *danaiov1alpha1.ClusterNfsPvc) error {
+			obj.Status = newStatus
+			return r.Status().Update(ctx, obj)
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	// The failed namespaces are retried with the backoff of the rate limiter of the controller.
+	if len(newStatus.FailedNamespaces) > 0 {
+		return ctrl.Result{}, fmt.Errorf("failed to materialize ClusterNfsPvc in namespaces %s", strings.Join(newStatus.FailedNamespaces, ", "))
+	}
+	return ctrl.Result{}, nil
+}
+
+// enqueueRequestsFromNamespace reconciles all the clusternfspvcs when a namespace is created or its labels change.
+func (r *ClusterNfsPvcReconciler) enqueueRequestsFromNamespace(ctx context.Context, _ client.Object) []reconcile.Request {
+	clusterNfsPvcList := &danaiov1alpha1.ClusterNfsPvcList{}
+	if err := r.List(ctx, clusterNfsPvcList); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(clusterNfsPvcList.Items))
+	for _, item := range clusterNfsPvcList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: item.Name},
+		})
+	}
+	return requests
+}
@@ -0,0 +1,114 @@
+package createpath
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/jobs"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// Reasons of the PathReady condition.
+	CreatingReason       = "Creating"
+	CreationFailedReason = "CreationFailed"
+	CreatedReason        = "Created"
+
+	// generationAnnotation records the generation of the nfspvc a Job creates the path of.
+	generationAnnotation = "nfspvc.dana.io/generation"
+
+	jobKind     = "createpath"
+	namePrefix  = "nfspvc-createpath-"
+	parentMount = "/export"
+)
+
+// Ensure creates the path of the nfspvc in its parent export with the ownership and the mode of
+// its createPath, through a Job mounting the parent export, and returns the PathReady condition of
+// the nfspvc for its generation. A failed Job, or a Job of a previous generation, is deleted, so that
+// it is created again on the next reconcile.
+func Ensure(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, k8sClient client.Client, scheme *runtime.Scheme) (metav1.Condition, error) {
+	job := prepareJob(nfspvc)
+	if err := controllerutil.SetControllerReference(&nfspvc, &job, scheme); err != nil {
+		return metav1.Condition{}, err
+	}
+	job, err := jobs.Ensure(ctx, k8sClient, job)
+	if err != nil {
+		return metav1.Condition{}, err
+	}
+	if job.Annotations[generationAnnotation] != strconv.FormatInt(nfspvc.Generation, 10) {
+		if err := Cleanup(ctx, nfspvc, k8sClient); err != nil {
+			return metav1.Condition{}, err
+		}
+		return pathReadyCondition(nfspvc, metav1.ConditionFalse, CreatingReason, fmt.Sprintf("job %q ran for a previous generation, restarting", job.Name)), nil
+	}
+	state, err := jobs.Observe(ctx, k8sClient, job)
+	if err != nil {
+		return metav1.Condition{}, err
+	}
+
+	switch state.Phase {
+	case jobs.Failed:
+		if err := Cleanup(ctx, nfspvc, k8sClient); err != nil {
+			return metav1.Condition{}, err
+		}
+		return pathReadyCondition(nfspvc, metav1.ConditionFalse, CreationFailedReason, fmt.Sprintf("job %q failed, retrying: %s", job.Name, state.Message)), nil
+	case jobs.Succeeded:
+		if err := Cleanup(ctx, nfspvc, k8sClient); err != nil {
+			return metav1.Condition{}, err
+		}
+		return pathReadyCondition(nfspvc, metav1.ConditionTrue, CreatedReason, fmt.Sprintf("created %s:%s", nfspvc.Spec.Server, resources.ExportPath(nfspvc))), nil
+	}
+	return pathReadyCondition(nfspvc, metav1.ConditionFalse, CreatingReason, fmt.Sprintf("job %q is creating %s:%s", job.Name, nfspvc.Spec.Server, resources.ExportPath(nfspvc))), nil
+}
+
+// Cleanup deletes the Job creating the path of the nfspvc.
+func Cleanup(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, k8sClient client.Client) error {
+	if err := jobs.Delete(ctx, k8sClient, JobName(nfspvc), nfspvc.Namespace); err != nil {
+		return fmt.Errorf("failed to delete job %q: %v", JobName(nfspvc), err)
+	}
+	return nil
+}
+
+// JobName returns the name of the Job creating the path of the nfspvc.
+func JobName(nfspvc danaiov1alpha1.NfsPvc) string {
+	return utils.ShortenName(namePrefix+nfspvc.Name, utils.MaxLabelValueLength)
+}
+
+// prepareJob returns the Job mounting the parent export of the path of the nfspvc and creating the path in it.
+func prepareJob(nfspvc danaiov1alpha1.NfsPvc) batchv1.Job {
+	parent, directory := path.Split(path.Clean(resources.ExportPath(nfspvc)))
+	createPath := nfspvc.Spec.CreatePath
+	var owner, group string
+	if createPath.UID != nil {
+		owner = strconv.FormatInt(*createPath.UID, 10)
+	}
+	if createPath.GID != nil {
+		group = strconv.FormatInt(*createPath.GID, 10)
+	}
+	job := jobs.PrepareJob(JobName(nfspvc), nfspvc.Namespace, jobKind, utils.DataMoverImage,
+		jobs.CreatePathScript(path.Join(parentMount, directory), owner, group, createPath.Mode),
+		jobs.NFSVolume("export", parentMount, nfspvc.Spec.Server, parent, false),
+	)
+	job.Annotations = map[string]string{generationAnnotation: strconv.FormatInt(nfspvc.Generation, 10)}
+	return job
+}
+
+// pathReadyCondition returns the PathReady condition of the current generation of an nfspvc.
+func pathReadyCondition(nfspvc danaiov1alpha1.NfsPvc, status metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:               danaiov1alpha1.PathReadyNfsPvcCondition,
+		Status:             status,
+		ObservedGeneration: nfspvc.Generation,
+		Reason:             reason,
+		Message:            message,
+	}
+}
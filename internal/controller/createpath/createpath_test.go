@@ -0,0 +1,134 @@
+package createpath
+
+import (
+	"context"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Ensure", func() {
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		scheme    *runtime.Scheme
+		nfspvc    danaiov1alpha1.NfsPvc
+	)
+
+	ensure := func() metav1.Condition {
+		condition, err := Ensure(ctx, nfspvc, k8sClient, scheme)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(condition.Type).To(Equal(danaiov1alpha1.PathReadyNfsPvcCondition))
+		return condition
+	}
+
+	getJob := func() (batchv1.Job, error) {
+		job := batchv1.Job{}
+		err := k8sClient.Get(ctx, client.ObjectKey{Name: JobName(nfspvc), Namespace: nfspvc.Namespace}, &job)
+		return job, err
+	}
+
+	// finishJob marks the Job as complete or failed.
+	finishJob := func(conditionType batchv1.JobConditionType) {
+		job, err := getJob()
+		Expect(err).NotTo(HaveOccurred())
+		job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+		Expect(k8sClient.Status().Update(ctx, &job)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(danaiov1alpha1.AddToScheme(scheme)).To(Succeed())
+		nfspvc = danaiov1alpha1.NfsPvc{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default", UID: "data-uid"},
+			Spec: danaiov1alpha1.NfsPvcSpec{
+				Server:     "nfs",
+				Path:       "/exports/teams/data",
+				NfsVersion: "4.1",
+				CreatePath: &danaiov1alpha1.NfsPvcCreatePath{UID: ptr.To(int64(1000)), GID: ptr.To(int64(2000)), Mode: "0770"},
+			},
+		}
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&batchv1.Job{}).Build()
+	})
+
+	It("should create the path from its parent export", func() {
+		condition := ensure()
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(CreatingReason))
+
+		job, err := getJob()
+		Expect(err).NotTo(HaveOccurred())
+		volume := job.Spec.Template.Spec.Volumes[0]
+		Expect(volume.NFS.Server).To(Equal("nfs"))
+		Expect(volume.NFS.Path).To(Equal("/exports/teams/"))
+		script := job.Spec.Template.Spec.Containers[0].Command[2]
+		Expect(script).To(ContainSubstring("mkdir -p '/export/data'"))
+		Expect(script).To(ContainSubstring("chown '1000' '/export/data'"))
+		Expect(script).To(ContainSubstring("chgrp '2000' '/export/data'"))
+		Expect(script).To(ContainSubstring("chmod '0770' '/export/data'"))
+		Expect(metav1.IsControlledBy(&job, &nfspvc)).To(BeTrue())
+
+		finishJob(batchv1.JobComplete)
+		condition = ensure()
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(CreatedReason))
+		_, err = getJob()
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should run the Job again for a new generation of the nfspvc", func() {
+		nfspvc.Generation = 1
+		Expect(ensure().ObservedGeneration).To(Equal(int64(1)))
+
+		nfspvc.Generation = 2
+		nfspvc.Spec.CreatePath.Mode = "0750"
+		condition := ensure()
+		Expect(condition.Reason).To(Equal(CreatingReason))
+		Expect(condition.ObservedGeneration).To(Equal(int64(2)))
+		_, err := getJob()
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		ensure()
+		job, err := getJob()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(job.Spec.Template.Spec.Containers[0].Command[2]).To(ContainSubstring("chmod '0750' '/export/data'"))
+		finishJob(batchv1.JobComplete)
+		condition = ensure()
+		Expect(condition.Reason).To(Equal(CreatedReason))
+		Expect(condition.ObservedGeneration).To(Equal(int64(2)))
+	})
+
+	It("should leave the ownership unchanged when it is unset", func() {
+		nfspvc.Spec.CreatePath = &danaiov1alpha1.NfsPvcCreatePath{}
+		ensure()
+		job, err := getJob()
+		Expect(err).NotTo(HaveOccurred())
+		script := job.Spec.Template.Spec.Containers[0].Command[2]
+		Expect(script).NotTo(ContainSubstring("chown"))
+		Expect(script).NotTo(ContainSubstring("chgrp"))
+	})
+
+	It("should delete a failed Job so that it is retried", func() {
+		ensure()
+		finishJob(batchv1.JobFailed)
+		condition := ensure()
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(CreationFailedReason))
+		_, err := getJob()
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		Expect(ensure().Reason).To(Equal(CreatingReason))
+	})
+})
@@ -0,0 +1,14 @@
+package createpath
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCreatePath(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "CreatePath Suite")
+}
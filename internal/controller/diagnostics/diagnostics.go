@@ -0,0 +1,268 @@
+package diagnostics
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Subject is the nfspvc, its PV and its PVC the checks run against.
+type Subject struct {
+	NfsPvc   danaiov1alpha1.NfsPvc
+	Observed resources.Observed
+	// StorageClass is the storage class the operator creates the PVs and the PVCs with.
+	// It is not checked when empty.
+	StorageClass string
+}
+
+// Check is a named diagnostic check. It returns an empty finding when it passes, or else what is
+// wrong and how to fix it.
+type Check struct {
+	Name        string
+	Description string
+	Run         func(subject Subject) (finding, fix string)
+}
+
+// Checks is the catalog of the diagnostic checks, in the order they run.
+var Checks = []Check{
+	{Name: "NfsPvcDeletion", Description: "the nfspvc is not stuck in deletion", Run: checkNfsPvcDeletion},
+	{Name: "PVExists", Description: "the PV of the nfspvc exists", Run: checkPVExists},
+	{Name: "PVCExists", Description: "the PVC of the nfspvc exists", Run: checkPVCExists},
+	{Name: "PVDeletion", Description: "the PV is not stuck in deletion", Run: checkPVDeletion},
+	{Name: "PVCDeletion", Description: "the PVC is not stuck in deletion", Run: checkPVCDeletion},
+	{Name: "PVPhase", Description: "the PV is not Released or Failed", Run: checkPVPhase},
+	{Name: "PVCPhase", Description: "the PVC is not Lost", Run: checkPVCPhase},
+	{Name: "ClaimRef", Description: "the claimRef of the PV reserves it for the PVC", Run: checkClaimRef},
+	{Name: "ClaimRefUID", Description: "the claimRef of the PV references the uid of the current PVC", Run: checkClaimRefUID},
+	{Name: "VolumeName", Description: "the PVC requests the PV of the nfspvc", Run: checkVolumeName},
+	{Name: "BindCompleted", Description: "the " + resources.PVCBindStatusAnnotation + " annotation is only set on a bound PVC", Run: checkBindCompleted},
+	{Name: "StorageClass", Description: "the PV and the PVC have the storage class of the operator", Run: checkStorageClass},
+	{Name: "AccessModes", Description: "the PV offers the access modes the PVC requests", Run: checkAccessModes},
+	{Name: "Capacity", Description: "the PV offers the capacity the PVC requests", Run: checkCapacity},
+	{Name: "Export", Description: "the PV mounts the export of the nfspvc", Run: checkExport},
+}
+
+// Run runs the checks against the subject and returns the findings of the failed ones.
+func Run(subject Subject) []danaiov1alpha1.NfsPvcDiagnostic {
+	var diagnostics []danaiov1alpha1.NfsPvcDiagnostic
+	for _, check := range Checks {
+		if finding, fix := check.Run(subject); finding != "" {
+			diagnostics = append(diagnostics, danaiov1alpha1.NfsPvcDiagnostic{Check: check.Name, Finding: finding, Fix: fix})
+		}
+	}
+	return diagnostics
+}
+
+func checkNfsPvcDeletion(subject Subject) (string, string) {
+	nfspvc := subject.NfsPvc
+	if nfspvc.DeletionTimestamp == nil || len(nfspvc.Finalizers) == 0 {
+		return "", ""
+	}
+	return fmt.Sprintf("the nfspvc is being deleted but is held by the finalizers %s", strings.Join(nfspvc.Finalizers, ", ")),
+		"the operator removes its finalizer once the export is reclaimed and the PV and the PVC are deleted; check its logs, or run 'kubectl nfspvc force-delete' when the operator is gone"
+}
+
+func checkPVExists(subject Subject) (string, string) {
+	nfspvc := subject.NfsPvc
+	if subject.Observed.PV != nil || nfspvc.DeletionTimestamp != nil {
+		return "", ""
+	}
+	pvName := resources.PVName(nfspvc)
+	switch {
+	case resources.ExportPath(nfspvc) == "":
+		return fmt.Sprintf("pv %q does not exist because the export of the nfspvc is not provisioned yet", pvName),
+			fmt.Sprintf("see the %s condition of the nfspvc", danaiov1alpha1.ProvisionedNfsPvcCondition)
+	case !resources.IsPathReady(nfspvc):
+		return fmt.Sprintf("pv %q does not exist because the path of the nfspvc is not created yet", pvName),
+			fmt.Sprintf("see the %s condition of the nfspvc", danaiov1alpha1.PathReadyNfsPvcCondition)
+	case !resources.IsPopulated(nfspvc):
+		return fmt.Sprintf("pv %q does not exist because the dataSource of the nfspvc is not copied yet", pvName),
+			fmt.Sprintf("see the %s condition of the nfspvc", danaiov1alpha1.PopulatingNfsPvcCondition)
+	}
+	return fmt.Sprintf("pv %q does not exist", pvName),
+		"the operator creates it on its next reconcile; check that it runs and manages the namespace of the nfspvc"
+}
+
+func checkPVCExists(subject Subject) (string, string) {
+	if subject.Observed.PVC != nil || subject.NfsPvc.DeletionTimestamp != nil {
+		return "", ""
+	}
+	if resources.IsPVDeleting(subject.Observed) {
+		return fmt.Sprintf("pvc %q does not exist", subject.NfsPvc.Name),
+			fmt.Sprintf("the operator recreates it once pv %q is deleted", subject.Observed.PV.Name)
+	}
+	return fmt.Sprintf("pvc %q does not exist", subject.NfsPvc.Name),
+		"the operator creates it on its next reconcile; check that it runs and manages the namespace of the nfspvc"
+}
+
+func checkPVDeletion(subject Subject) (string, string) {
+	pv := subject.Observed.PV
+	if pv == nil || pv.DeletionTimestamp == nil || len(pv.Finalizers) == 0 {
+		return "", ""
+	}
+	return fmt.Sprintf("pv %q is being deleted but is held by the finalizers %s", pv.Name, strings.Join(pv.Finalizers, ", ")),
+		"the kubernetes.io/pv-protection finalizer is removed once the PV is no longer bound to a PVC; delete the PVC, or run 'kubectl nfspvc recreate-pv'"
+}
+
+func checkPVCDeletion(subject Subject) (string, string) {
+	pvc := subject.Observed.PVC
+	if pvc == nil || pvc.DeletionTimestamp == nil || len(pvc.Finalizers) == 0 {
+		return "", ""
+	}
+	return fmt.Sprintf("pvc %q is being deleted but is held by the finalizers %s", pvc.Name, strings.Join(pvc.Finalizers, ", ")),
+		"the kubernetes.io/pvc-protection finalizer is removed once no pod uses the PVC; stop the pods that 'kubectl nfspvc list' shows as its consumers"
+}
+
+func checkPVPhase(subject Subject) (string, string) {
+	pv := subject.Observed.PV
+	if pv == nil {
+		return "", ""
+	}
+	switch pv.Status.Phase {
+	case corev1.VolumeReleased:
+		return fmt.Sprintf("pv %q is Released: the PVC it was bound to was deleted", pv.Name),
+			"the operator clears the uid of its claimRef so that it binds the new PVC; if it stays Released, run 'kubectl nfspvc recreate-pv'"
+	case corev1.VolumeFailed:
+		return fmt.Sprintf("pv %q is Failed: %s", pv.Name, orUnknown(pv.Status.Message)),
+			"fix the cause of the failure, then run 'kubectl nfspvc recreate-pv'"
+	}
+	return "", ""
+}
+
+func checkPVCPhase(subject Subject) (string, string) {
+	pvc := subject.Observed.PVC
+	if pvc == nil || pvc.Status.Phase != corev1.ClaimLost {
+		return "", ""
+	}
+	return fmt.Sprintf("pvc %q is Lost: the PV it was bound to was deleted", pvc.Name),
+		"the operator recreates the PV and removes the " + resources.PVCBindStatusAnnotation + " annotation so that the PVC binds it again; check that it runs"
+}
+
+func checkClaimRef(subject Subject) (string, string) {
+	pv, nfspvc := subject.Observed.PV, subject.NfsPvc
+	if pv == nil {
+		return "", ""
+	}
+	claimRef := pv.Spec.ClaimRef
+	if claimRef == nil {
+		return fmt.Sprintf("pv %q has no claimRef, so any PVC may bind it", pv.Name),
+			"run 'kubectl nfspvc recreate-pv' so that the PV is reserved for the PVC of the nfspvc"
+	}
+	if claimRef.Namespace != nfspvc.Namespace || claimRef.Name != nfspvc.Name {
+		return fmt.Sprintf("pv %q is reserved for pvc %s/%s instead of %s/%s", pv.Name, claimRef.Namespace, claimRef.Name, nfspvc.Namespace, nfspvc.Name),
+			"run 'kubectl nfspvc recreate-pv' so that the PV is reserved for the PVC of the nfspvc"
+	}
+	return "", ""
+}
+
+func checkClaimRefUID(subject Subject) (string, string) {
+	pv, pvc := subject.Observed.PV, subject.Observed.PVC
+	if pv == nil || pvc == nil || pv.Spec.ClaimRef == nil || pv.Spec.ClaimRef.UID == "" || pv.Spec.ClaimRef.UID == pvc.UID {
+		return "", ""
+	}
+	return fmt.Sprintf("the claimRef of pv %q references uid %s, but pvc %q has uid %s: the PV is still bound to a deleted PVC", pv.Name, pv.Spec.ClaimRef.UID, pvc.Name, pvc.UID),
+		"the operator clears the uid of the claimRef once the PV is Released or Failed or the PVC is Pending; otherwise remove spec.claimRef.uid from the PV"
+}
+
+func checkVolumeName(subject Subject) (string, string) {
+	pvc := subject.Observed.PVC
+	pvName := resources.PVName(subject.NfsPvc)
+	if pvc == nil || pvc.Spec.VolumeName == "" || pvc.Spec.VolumeName == pvName {
+		return "", ""
+	}
+	return fmt.Sprintf("pvc %q requests pv %q instead of %q", pvc.Name, pvc.Spec.VolumeName, pvName),
+		"the volumeName of a PVC is immutable; delete the PVC so that the operator recreates it"
+}
+
+func checkBindCompleted(subject Subject) (string, string) {
+	pvc := subject.Observed.PVC
+	if pvc == nil || pvc.Annotations[resources.PVCBindStatusAnnotation] != "yes" || pvc.Status.Phase == corev1.ClaimBound {
+		return "", ""
+	}
+	return fmt.Sprintf("pvc %q has the %s annotation but is %s, so the binder does not bind it again", pvc.Name, resources.PVCBindStatusAnnotation, orUnknown(string(pvc.Status.Phase))),
+		fmt.Sprintf("run 'kubectl annotate pvc -n %s %s %s-'", pvc.Namespace, pvc.Name, resources.PVCBindStatusAnnotation)
+}
+
+func checkStorageClass(subject Subject) (string, string) {
+	pv, pvc := subject.Observed.PV, subject.Observed.PVC
+	pvcStorageClass := ""
+	if pvc != nil && pvc.Spec.StorageClassName != nil {
+		pvcStorageClass = *pvc.Spec.StorageClassName
+	}
+	if pv != nil && pvc != nil && pv.Spec.StorageClassName != pvcStorageClass {
+		return fmt.Sprintf("pv %q has storage class %q but pvc %q requests %q, so they never bind", pv.Name, pv.Spec.StorageClassName, pvc.Name, pvcStorageClass),
+			"delete the PVC so that the operator recreates it, or run 'kubectl nfspvc recreate-pv'"
+	}
+	// an adopted PV keeps the storage class it was created with
+	if subject.StorageClass == "" || subject.NfsPvc.Annotations[danaiov1alpha1.AdoptedPVAnnotation] != "" {
+		return "", ""
+	}
+	if pv != nil && pv.Spec.StorageClassName != subject.StorageClass {
+		return fmt.Sprintf("pv %q has storage class %q instead of %q", pv.Name, pv.Spec.StorageClassName, subject.StorageClass),
+			"run 'kubectl nfspvc recreate-pv' so that the PV is recreated with the storage class of the operator"
+	}
+	if pvc != nil && pvcStorageClass != subject.StorageClass {
+		return fmt.Sprintf("pvc %q has storage class %q instead of %q", pvc.Name, pvcStorageClass, subject.StorageClass),
+			"delete the PVC so that the operator recreates it with the storage class of the operator"
+	}
+	return "", ""
+}
+
+func checkAccessModes(subject Subject) (string, string) {
+	pv, pvc := subject.Observed.PV, subject.Observed.PVC
+	if pv == nil || pvc == nil {
+		return "", ""
+	}
+	var missing []string
+	for _, mode := range pvc.Spec.AccessModes {
+		if !slices.Contains(pv.Spec.AccessModes, mode) {
+			missing = append(missing, string(mode))
+		}
+	}
+	if len(missing) == 0 {
+		return "", ""
+	}
+	return fmt.Sprintf("pvc %q requests the access modes %s that pv %q does not offer", pvc.Name, strings.Join(missing, ", "), pv.Name),
+		"run 'kubectl nfspvc recreate-pv' so that the PV offers the access modes of the nfspvc"
+}
+
+func checkCapacity(subject Subject) (string, string) {
+	pv, pvc := subject.Observed.PV, subject.Observed.PVC
+	if pv == nil || pvc == nil {
+		return "", ""
+	}
+	requested, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if !ok {
+		return "", ""
+	}
+	capacity := pv.Spec.Capacity[corev1.ResourceStorage]
+	if capacity.Cmp(requested) >= 0 {
+		return "", ""
+	}
+	return fmt.Sprintf("pvc %q requests %s but pv %q offers %s", pvc.Name, requested.String(), pv.Name, capacity.String()),
+		"run 'kubectl nfspvc recreate-pv' so that the PV offers the capacity of the nfspvc"
+}
+
+func checkExport(subject Subject) (string, string) {
+	pv, nfspvc := subject.Observed.PV, subject.NfsPvc
+	if pv == nil || pv.Spec.NFS == nil || resources.ExportPath(nfspvc) == "" {
+		return "", ""
+	}
+	if pv.Spec.NFS.Server == nfspvc.Spec.Server && pv.Spec.NFS.Path == resources.ExportPath(nfspvc) {
+		return "", ""
+	}
+	return fmt.Sprintf("pv %q mounts %s:%s instead of %s:%s", pv.Name, pv.Spec.NFS.Server, pv.Spec.NFS.Path, nfspvc.Spec.Server, resources.ExportPath(nfspvc)),
+		"run 'kubectl nfspvc recreate-pv' so that the PV mounts the export of the nfspvc"
+}
+
+// orUnknown returns the value, or "unknown" when it is empty.
+func orUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
@@ -0,0 +1,103 @@
+package diagnostics
+
+import (
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Run", func() {
+	var subject Subject
+
+	checks := func() []string {
+		var names []string
+		for _, diagnostic := range Run(subject) {
+			names = append(names, diagnostic.Check)
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		nfspvc := danaiov1alpha1.NfsPvc{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "team-a"},
+			Spec: danaiov1alpha1.NfsPvcSpec{
+				Server:      "nas",
+				Path:        "/exports/data",
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+				Capacity:    corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		}
+		pvc := resources.PreparePVC(nfspvc, "brown")
+		pvc.UID = "pvc-uid"
+		pvc.Annotations = map[string]string{resources.PVCBindStatusAnnotation: "yes"}
+		pvc.Status.Phase = corev1.ClaimBound
+		pv := resources.PreparePV(nfspvc, "brown", "Retain")
+		pv.Spec.ClaimRef.UID = pvc.UID
+		pv.Status.Phase = corev1.VolumeBound
+		subject = Subject{NfsPvc: nfspvc, Observed: resources.Observed{PV: &pv, PVC: &pvc}, StorageClass: "brown"}
+	})
+
+	It("should pass every check for a bound nfspvc", func() {
+		Expect(Run(subject)).To(BeEmpty())
+	})
+
+	It("should explain that the PV is still bound to a deleted PVC", func() {
+		subject.Observed.PV.Spec.ClaimRef.UID = "old-uid"
+		subject.Observed.PV.Status.Phase = corev1.VolumeReleased
+		subject.Observed.PVC.Status.Phase = corev1.ClaimPending
+		Expect(checks()).To(ConsistOf("PVPhase", "ClaimRefUID", "BindCompleted"))
+		Expect(Run(subject)[1].Finding).To(ContainSubstring("references uid old-uid"))
+	})
+
+	It("should explain that the PVC was lost", func() {
+		subject.Observed.PVC.Status.Phase = corev1.ClaimLost
+		subject.Observed.PV = nil
+		Expect(Run(subject)).To(ConsistOf(
+			HaveField("Check", "PVExists"),
+			HaveField("Check", "PVCPhase"),
+			And(HaveField("Check", "BindCompleted"), HaveField("Fix", "run 'kubectl annotate pvc -n team-a data pv.kubernetes.io/bind-completed-'")),
+		))
+	})
+
+	It("should explain why the PV was not created yet", func() {
+		subject.NfsPvc.Spec.CreatePath = &danaiov1alpha1.NfsPvcCreatePath{}
+		subject.Observed = resources.Observed{}
+		diagnostics := Run(subject)
+		Expect(diagnostics).To(HaveLen(2))
+		Expect(diagnostics[0].Fix).To(ContainSubstring(danaiov1alpha1.PathReadyNfsPvcCondition))
+		Expect(diagnostics[1].Check).To(Equal("PVCExists"))
+	})
+
+	It("should detect the mismatches between the PV and the PVC", func() {
+		subject.Observed.PVC.Spec.StorageClassName = nil
+		subject.Observed.PVC.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOncePod}
+		subject.Observed.PVC.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("20Gi")}
+		subject.Observed.PVC.Spec.VolumeName = "other-pv"
+		subject.Observed.PV.Spec.ClaimRef.Name = "other"
+		subject.Observed.PV.Spec.NFS.Path = "/exports/other"
+		Expect(checks()).To(ConsistOf("ClaimRef", "VolumeName", "StorageClass", "AccessModes", "Capacity", "Export"))
+	})
+
+	It("should only check the storage class of the operator for PVs it created", func() {
+		subject.StorageClass = "gold"
+		Expect(checks()).To(ConsistOf("StorageClass"))
+		subject.NfsPvc.Annotations = map[string]string{danaiov1alpha1.AdoptedPVAnnotation: "data-team-a-pv"}
+		Expect(Run(subject)).To(BeEmpty())
+	})
+
+	It("should report the finalizers holding the deleted objects", func() {
+		now := metav1.Now()
+		subject.NfsPvc.DeletionTimestamp = &now
+		subject.NfsPvc.Finalizers = []string{"nfspvc.dana.io/nfspvc-protection"}
+		subject.Observed.PVC.DeletionTimestamp = &now
+		subject.Observed.PVC.Finalizers = []string{"kubernetes.io/pvc-protection"}
+		diagnostics := Run(subject)
+		Expect(diagnostics).To(HaveLen(2))
+		Expect(diagnostics[0].Finding).To(ContainSubstring("nfspvc.dana.io/nfspvc-protection"))
+		Expect(diagnostics[1].Finding).To(ContainSubstring("kubernetes.io/pvc-protection"))
+	})
+})
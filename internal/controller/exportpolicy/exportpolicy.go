@@ -0,0 +1,151 @@
+package exportpolicy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/storage"
+	"github.com/dana-team/nfspvc-operator/internal/storage/httphook"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Reasons of the InSync condition.
+	SyncedReason                = "Synced"
+	DriftedReason               = "Drifted"
+	SyncFailedReason            = "SyncFailed"
+	NoExportPolicyBackendReason = "NoExportPolicyBackend"
+	NoNodesReason               = "NoNodes"
+)
+
+// Backend returns the backend managing the export policy: its hook, or else the storage backend of the
+// operator when it manages export policies. It returns nil when the export policy cannot be managed.
+func Backend(policy danaiov1alpha1.NfsExportPolicy, storageBackend storage.StorageBackend) storage.ExportPolicyBackend {
+	if policy.Spec.Hook != nil {
+		return httphook.New(policy.Spec.Hook.URL, nil)
+	}
+	if backend, ok := storageBackend.(storage.ExportPolicyBackend); ok {
+		return backend
+	}
+	return nil
+}
+
+// Sync compares the clients of the export policy with the addresses of the selected nodes and, unless
+// dryRun is set, makes the export policy allow exactly these addresses. It returns the new status of the
+// policy, with the drift that is left. An export policy is never emptied, so that a wrong nodeSelector
+// does not cut the access of every node.
+func Sync(ctx context.Context, policy danaiov1alpha1.NfsExportPolicy, reader client.Reader, backend storage.ExportPolicyBackend, now time.Time) (danaiov1alpha1.NfsExportPolicyStatus, error) {
+	status := *policy.Status.DeepCopy()
+	setCondition := func(conditionStatus metav1.ConditionStatus, reason, message string) {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               danaiov1alpha1.InSyncNfsExportPolicyCondition,
+			Status:             conditionStatus,
+			Reason:             reason,
+			Message:            message,
+			ObservedGeneration: policy.Generation,
+		})
+	}
+
+	clients, err := nodeClients(ctx, policy, reader)
+	if err != nil {
+		return status, err
+	}
+	status.Clients = clients
+	status.LastSyncTime = &metav1.Time{Time: now}
+	if backend == nil {
+		setCondition(metav1.ConditionUnknown, NoExportPolicyBackendReason, "the policy has no hook and the storage backend of the operator does not manage export policies")
+		return status, nil
+	}
+
+	current, err := backend.ExportPolicyClients(ctx, policy.Spec.PolicyName)
+	if err != nil {
+		setCondition(metav1.ConditionUnknown, SyncFailedReason, fmt.Sprintf("failed to read export policy %q: %v", policy.Spec.PolicyName, err))
+		return status, nil
+	}
+	missing, unexpected := difference(clients, current), difference(current, clients)
+	status.Missing, status.Unexpected = missing, unexpected
+	switch {
+	case len(missing) == 0 && len(unexpected) == 0:
+		setCondition(metav1.ConditionTrue, SyncedReason, fmt.Sprintf("export policy %q allows the %d addresses of the selected nodes", policy.Spec.PolicyName, len(clients)))
+		return status, nil
+	case policy.Spec.DryRun:
+		setCondition(metav1.ConditionFalse, DriftedReason, driftMessage(policy.Spec.PolicyName, missing, unexpected))
+		return status, nil
+	case len(clients) == 0:
+		setCondition(metav1.ConditionFalse, NoNodesReason, fmt.Sprintf("no selected node has an address, so export policy %q is left unchanged", policy.Spec.PolicyName))
+		return status, nil
+	}
+
+	if err := backend.SetExportPolicyClients(ctx, policy.Spec.PolicyName, clients); err != nil {
+		setCondition(metav1.ConditionFalse, SyncFailedReason, fmt.Sprintf("failed to update export policy %q: %v", policy.Spec.PolicyName, err))
+		return status, nil
+	}
+	status.Missing, status.Unexpected = nil, nil
+	setCondition(metav1.ConditionTrue, SyncedReason, fmt.Sprintf("export policy %q was updated: added %d and removed %d clients", policy.Spec.PolicyName, len(missing), len(unexpected)))
+	return status, nil
+}
+
+// nodeClients returns the sorted addresses of the selected nodes that are not being deleted.
+func nodeClients(ctx context.Context, policy danaiov1alpha1.NfsExportPolicy, reader client.Reader) ([]string, error) {
+	selector := labels.Everything()
+	if policy.Spec.NodeSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(policy.Spec.NodeSelector); err != nil {
+			return nil, fmt.Errorf("invalid nodeSelector: %v", err)
+		}
+	}
+	nodes := corev1.NodeList{}
+	if err := reader.List(ctx, &nodes, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %v", err)
+	}
+	addressTypes := policy.Spec.AddressTypes
+	if len(addressTypes) == 0 {
+		addressTypes = []corev1.NodeAddressType{corev1.NodeInternalIP}
+	}
+
+	var clients []string
+	for _, node := range nodes.Items {
+		if node.DeletionTimestamp != nil {
+			continue
+		}
+		for _, address := range node.Status.Addresses {
+			if slices.Contains(addressTypes, address.Type) && !slices.Contains(clients, address.Address) {
+				clients = append(clients, address.Address)
+			}
+		}
+	}
+	slices.Sort(clients)
+	return clients, nil
+}
+
+// difference returns the sorted clients of a that are not in b.
+func difference(a, b []string) []string {
+	var result []string
+	for _, client := range a {
+		if !slices.Contains(b, client) && !slices.Contains(result, client) {
+			result = append(result, client)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// driftMessage describes the drift between an export policy and the nodes.
+func driftMessage(policy string, missing, unexpected []string) string {
+	var parts []string
+	if len(missing) > 0 {
+		parts = append(parts, fmt.Sprintf("misses %s", strings.Join(missing, ", ")))
+	}
+	if len(unexpected) > 0 {
+		parts = append(parts, fmt.Sprintf("also allows %s", strings.Join(unexpected, ", ")))
+	}
+	return fmt.Sprintf("export policy %q %s", policy, strings.Join(parts, " and "))
+}
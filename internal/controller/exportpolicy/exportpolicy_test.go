@@ -0,0 +1,159 @@
+package exportpolicy
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/storage"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeArray is an in-memory storage array holding a single export policy.
+type fakeArray struct {
+	clients []string
+	err     error
+	sets    int
+}
+
+func (f *fakeArray) ExportPolicyClients(context.Context, string) ([]string, error) {
+	return slices.Clone(f.clients), f.err
+}
+
+func (f *fakeArray) SetExportPolicyClients(_ context.Context, _ string, clients []string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sets++
+	f.clients = slices.Clone(clients)
+	return nil
+}
+
+var _ = Describe("Sync", func() {
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		array     *fakeArray
+		policy    danaiov1alpha1.NfsExportPolicy
+		now       time.Time
+	)
+
+	node := func(name, internalIP string, labels map[string]string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: internalIP},
+				{Type: corev1.NodeHostName, Address: name},
+			}},
+		}
+	}
+
+	sync := func() *metav1.Condition {
+		status, err := Sync(ctx, policy, k8sClient, array, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.LastSyncTime.Time).To(BeTemporally("==", now))
+		policy.Status = status
+		return meta.FindStatusCondition(status.Conditions, danaiov1alpha1.InSyncNfsExportPolicyCondition)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(danaiov1alpha1.AddToScheme(scheme)).To(Succeed())
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			node("worker-1", "10.0.0.1", map[string]string{"role": "worker"}),
+			node("worker-2", "10.0.0.2", map[string]string{"role": "worker"}),
+			node("master-1", "10.0.0.9", map[string]string{"role": "master"}),
+		).Build()
+		array = &fakeArray{clients: []string{"10.0.0.1", "10.0.0.7"}}
+		policy = danaiov1alpha1.NfsExportPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "workers", Generation: 1},
+			Spec: danaiov1alpha1.NfsExportPolicySpec{
+				PolicyName:   "k8s-nodes",
+				NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "worker"}},
+			},
+		}
+	})
+
+	It("should make the export policy allow exactly the addresses of the selected nodes", func() {
+		condition := sync()
+		Expect(array.clients).To(Equal([]string{"10.0.0.1", "10.0.0.2"}))
+		Expect(policy.Status.Clients).To(Equal([]string{"10.0.0.1", "10.0.0.2"}))
+		Expect(policy.Status.Missing).To(BeEmpty())
+		Expect(policy.Status.Unexpected).To(BeEmpty())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(SyncedReason))
+		Expect(condition.Message).To(ContainSubstring("added 1 and removed 1"))
+
+		sync()
+		Expect(array.sets).To(Equal(1))
+	})
+
+	It("should follow the nodes that join and leave", func() {
+		sync()
+		Expect(k8sClient.Create(ctx, node("worker-3", "10.0.0.3", map[string]string{"role": "worker"}))).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}})).To(Succeed())
+		sync()
+		Expect(array.clients).To(Equal([]string{"10.0.0.2", "10.0.0.3"}))
+	})
+
+	It("should only report the drift with dryRun", func() {
+		policy.Spec.DryRun = true
+		condition := sync()
+		Expect(array.clients).To(Equal([]string{"10.0.0.1", "10.0.0.7"}))
+		Expect(array.sets).To(BeZero())
+		Expect(policy.Status.Missing).To(Equal([]string{"10.0.0.2"}))
+		Expect(policy.Status.Unexpected).To(Equal([]string{"10.0.0.7"}))
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(DriftedReason))
+		Expect(condition.Message).To(Equal(`export policy "k8s-nodes" misses 10.0.0.2 and also allows 10.0.0.7`))
+	})
+
+	It("should use the requested address types", func() {
+		policy.Spec.AddressTypes = []corev1.NodeAddressType{corev1.NodeHostName}
+		sync()
+		Expect(array.clients).To(Equal([]string{"worker-1", "worker-2"}))
+	})
+
+	It("should never empty the export policy", func() {
+		policy.Spec.NodeSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"role": "gpu"}}
+		condition := sync()
+		Expect(array.clients).To(Equal([]string{"10.0.0.1", "10.0.0.7"}))
+		Expect(condition.Reason).To(Equal(NoNodesReason))
+	})
+
+	It("should report a failing or missing backend", func() {
+		array.err = errors.New("connection refused")
+		condition := sync()
+		Expect(condition.Status).To(Equal(metav1.ConditionUnknown))
+		Expect(condition.Reason).To(Equal(SyncFailedReason))
+
+		status, err := Sync(ctx, policy, k8sClient, nil, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(meta.FindStatusCondition(status.Conditions, danaiov1alpha1.InSyncNfsExportPolicyCondition).Reason).To(Equal(NoExportPolicyBackendReason))
+	})
+})
+
+var _ = Describe("Backend", func() {
+	It("should prefer the hook over the storage backend", func() {
+		policy := danaiov1alpha1.NfsExportPolicy{Spec: danaiov1alpha1.NfsExportPolicySpec{PolicyName: "k8s-nodes"}}
+		Expect(Backend(policy, nil)).To(BeNil())
+		var backend storage.StorageBackend
+		Expect(Backend(policy, backend)).To(BeNil())
+
+		policy.Spec.Hook = &danaiov1alpha1.NfsExportPolicyHook{URL: "http://hook.storage.svc"}
+		Expect(Backend(policy, nil)).NotTo(BeNil())
+	})
+})
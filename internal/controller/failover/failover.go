@@ -0,0 +1,240 @@
+package failover
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	"github.com/dana-team/nfspvc-operator/internal/sharding"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// pvProtectionFinalizer keeps a PV bound to a PVC from being deleted.
+	pvProtectionFinalizer = "kubernetes.io/pv-protection"
+
+	// PVProtectionRemovedReason is the reason of the event recorded when the pv-protection finalizer of a PV is removed.
+	PVProtectionRemovedReason = "PVProtectionRemoved"
+)
+
+// Sync moves the selected NfsPvcs of the namespaces managed by the scope to the new server, recreating
+// at most batchSize PVs at once, and returns the status of the failover. The status is computed from the
+// reader, which must see every namespace, so that all the shards report the same status.
+func Sync(ctx context.Context, failover danaiov1alpha1.NfsServerFailover, k8sClient client.Client, reader client.Reader, scope sharding.Scope, recorder record.EventRecorder, now time.Time) (danaiov1alpha1.NfsServerFailoverStatus, error) {
+	candidates, err := listCandidates(ctx, failover, reader)
+	if err != nil {
+		return failover.Status, err
+	}
+	source, target := failover.Servers()
+
+	status := danaiov1alpha1.NfsServerFailoverStatus{Phase: danaiov1alpha1.ProgressingNfsServerFailoverPhase, Failback: failover.Spec.Failback}
+	var pending []danaiov1alpha1.NfsPvc
+	for _, nfspvc := range candidates {
+		owner := nfspvc.Annotations[danaiov1alpha1.FailoverAnnotation]
+		switch {
+		case nfspvc.Spec.Server == target && failedOverBy(nfspvc) == failover.Name:
+			status.Total++
+			if owner != failover.Name {
+				status.Moved++
+				continue
+			}
+			moved, err := sync(ctx, failover, nfspvc, target, k8sClient, reader, scope, recorder)
+			if err != nil {
+				return failover.Status, err
+			}
+			if !moved {
+				status.InProgress++
+				continue
+			}
+			if err := finish(ctx, k8sClient, reader, scope, nfspvc); err != nil {
+				return failover.Status, err
+			}
+			status.Moved++
+		case nfspvc.Spec.Server == source && (!failover.Spec.Failback || failedOverBy(nfspvc) == failover.Name):
+			status.Total++
+			pending = append(pending, nfspvc)
+		}
+	}
+
+	for _, nfspvc := range pending {
+		if status.InProgress >= batchSize(failover) {
+			break
+		}
+		if managed, err := scope.ManagesNamespace(ctx, reader, nfspvc.Namespace); err != nil || !managed {
+			continue
+		}
+		if _, migrating := nfspvc.Annotations[danaiov1alpha1.MigrationAnnotation]; migrating {
+			status.Failed = append(status.Failed, key(nfspvc))
+			continue
+		}
+		if err := repoint(ctx, k8sClient, nfspvc, failover.Name, target); err != nil {
+			log.FromContext(ctx).Info(fmt.Sprintf("failed to repoint nfspvc %q in namespace %q: %s", nfspvc.Name, nfspvc.Namespace, err.Error()))
+			status.Failed = append(status.Failed, key(nfspvc))
+			continue
+		}
+		status.InProgress++
+	}
+
+	switch {
+	case status.Moved == status.Total:
+		status.Phase = danaiov1alpha1.CompletedNfsServerFailoverPhase
+		status.CompletionTime = failover.Status.CompletionTime
+		if failover.Status.Phase != danaiov1alpha1.CompletedNfsServerFailoverPhase || failover.Status.Failback != failover.Spec.Failback || status.CompletionTime == nil {
+			status.CompletionTime = &metav1.Time{Time: now}
+		}
+		status.Message = fmt.Sprintf("moved %d nfspvcs to %s", status.Moved, target)
+	case len(status.Failed) > 0:
+		status.Message = fmt.Sprintf("failed to move %d nfspvcs to %s, retrying", len(status.Failed), target)
+	default:
+		status.Message = fmt.Sprintf("moving %d nfspvcs to %s", status.Total-status.Moved, target)
+	}
+	return status, nil
+}
+
+// listCandidates returns the NfsPvcs selected by the failover, sorted by namespace and name.
+func listCandidates(ctx context.Context, failover danaiov1alpha1.NfsServerFailover, reader client.Reader) ([]danaiov1alpha1.NfsPvc, error) {
+	selector := labels.Everything()
+	if failover.Spec.Selector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(failover.Spec.Selector); err != nil {
+			return nil, fmt.Errorf("invalid selector: %v", err)
+		}
+	}
+	nfspvcList := danaiov1alpha1.NfsPvcList{}
+	if err := reader.List(ctx, &nfspvcList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list nfspvcs: %v", err)
+	}
+
+	var namespaces map[string]bool
+	if failover.Spec.NamespaceSelector != nil {
+		namespaceSelector, err := metav1.LabelSelectorAsSelector(failover.Spec.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespaceSelector: %v", err)
+		}
+		namespaceList := corev1.NamespaceList{}
+		if err := reader.List(ctx, &namespaceList, client.MatchingLabelsSelector{Selector: namespaceSelector}); err != nil {
+			return nil, fmt.Errorf("failed to list namespaces: %v", err)
+		}
+		namespaces = make(map[string]bool, len(namespaceList.Items))
+		for _, namespace := range namespaceList.Items {
+			namespaces[namespace.Name] = true
+		}
+	}
+
+	candidates := make([]danaiov1alpha1.NfsPvc, 0, len(nfspvcList.Items))
+	for _, nfspvc := range nfspvcList.Items {
+		if nfspvc.DeletionTimestamp != nil || (namespaces != nil && !namespaces[nfspvc.Namespace]) {
+			continue
+		}
+		candidates = append(candidates, nfspvc)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return key(candidates[i]) < key(candidates[j]) })
+	return candidates, nil
+}
+
+// failedOverBy returns the name of the failover that moved the nfspvc. The NfsPvcs moved before the
+// failed-over-by annotation existed are only annotated with the failover that moved them.
+func failedOverBy(nfspvc danaiov1alpha1.NfsPvc) string {
+	if name, ok := nfspvc.Annotations[danaiov1alpha1.FailedOverAnnotation]; ok {
+		return name
+	}
+	return nfspvc.Annotations[danaiov1alpha1.FailoverAnnotation]
+}
+
+// repoint sets the server of the nfspvc to the target, annotated with the failover allowed to change it.
+func repoint(ctx context.Context, k8sClient client.Client, nfspvc danaiov1alpha1.NfsPvc, name, target string) error {
+	patch := client.MergeFrom(nfspvc.DeepCopy())
+	if nfspvc.Annotations == nil {
+		nfspvc.Annotations = map[string]string{}
+	}
+	nfspvc.Annotations[danaiov1alpha1.FailoverAnnotation] = name
+	nfspvc.Annotations[danaiov1alpha1.FailedOverAnnotation] = name
+	nfspvc.Spec.Server = target
+	return k8sClient.Patch(ctx, &nfspvc, patch)
+}
+
+// finish removes the failover annotation of a moved nfspvc, so that its server can no longer be changed by the failover,
+// keeping the failed-over-by annotation for the failback.
+// The nfspvcs of namespaces the scope does not manage are left to their shard.
+func finish(ctx context.Context, k8sClient client.Client, reader client.Reader, scope sharding.Scope, nfspvc danaiov1alpha1.NfsPvc) error {
+	if managed, err := scope.ManagesNamespace(ctx, reader, nfspvc.Namespace); err != nil || !managed {
+		return err
+	}
+	patch := client.MergeFrom(nfspvc.DeepCopy())
+	nfspvc.Annotations[danaiov1alpha1.FailedOverAnnotation] = nfspvc.Annotations[danaiov1alpha1.FailoverAnnotation]
+	delete(nfspvc.Annotations, danaiov1alpha1.FailoverAnnotation)
+	if err := k8sClient.Patch(ctx, &nfspvc, patch); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to remove the failover annotation of nfspvc %q: %v", nfspvc.Name, err)
+	}
+	return nil
+}
+
+// sync recreates the PV of a repointed nfspvc against the target and returns true once its PVC is bound to it.
+// The PVC is kept: the new PV is pre-bound to it, as UpdatePV does when a PVC is recreated, so that it binds again.
+// The pv-protection finalizer of the old PV is only removed while the failover is progressing.
+// The nfspvcs of namespaces the scope does not manage are only observed.
+func sync(ctx context.Context, failover danaiov1alpha1.NfsServerFailover, nfspvc danaiov1alpha1.NfsPvc, target string, k8sClient client.Client, reader client.Reader, scope sharding.Scope, recorder record.EventRecorder) (bool, error) {
+	observed, err := resources.Observe(ctx, nfspvc, reader)
+	if err != nil {
+		return false, err
+	}
+	pv, pvc := observed.PV, observed.PVC
+	if pv != nil && pv.DeletionTimestamp == nil && pv.Spec.NFS != nil && pv.Spec.NFS.Server == target {
+		return pvc != nil && pvc.Status.Phase == corev1.ClaimBound, nil
+	}
+	if managed, err := scope.ManagesNamespace(ctx, reader, nfspvc.Namespace); err != nil || !managed {
+		return false, err
+	}
+
+	if pv != nil {
+		// the old server may be gone, so the PV is released from its PVC rather than waiting for the unmount
+		if pv.DeletionTimestamp == nil {
+			if err := k8sClient.Delete(ctx, pv); client.IgnoreNotFound(err) != nil {
+				return false, fmt.Errorf("failed to delete pv %q: %v", pv.Name, err)
+			}
+		}
+		if failover.IsProgressing() && controllerutil.ContainsFinalizer(pv, pvProtectionFinalizer) {
+			patch := client.MergeFrom(pv.DeepCopy())
+			controllerutil.RemoveFinalizer(pv, pvProtectionFinalizer)
+			if err := k8sClient.Patch(ctx, pv, patch); client.IgnoreNotFound(err) != nil {
+				return false, fmt.Errorf("failed to remove the finalizers of pv %q: %v", pv.Name, err)
+			}
+			recorder.Event(&nfspvc, corev1.EventTypeWarning, PVProtectionRemovedReason,
+				fmt.Sprintf("removed the %s finalizer of pv %q to move it to %s without waiting for the unmount", pvProtectionFinalizer, pv.Name, target))
+		}
+		return false, nil
+	}
+
+	newPV := resources.PreparePV(nfspvc, utils.StorageClass, utils.PVReclaimPolicy())
+	if pvc != nil {
+		newPV.Spec.ClaimRef.UID = pvc.UID
+	}
+	if err := k8sClient.Create(ctx, &newPV); err != nil && !apierrors.IsAlreadyExists(err) {
+		return false, fmt.Errorf("failed to create pv %q: %v", newPV.Name, err)
+	}
+	return false, nil
+}
+
+// batchSize returns the maximum number of nfspvcs moved at once.
+func batchSize(failover danaiov1alpha1.NfsServerFailover) int32 {
+	if failover.Spec.BatchSize < 1 {
+		return 10
+	}
+	return failover.Spec.BatchSize
+}
+
+// key returns the namespace/name of the nfspvc.
+func key(nfspvc danaiov1alpha1.NfsPvc) string {
+	return nfspvc.Namespace + "/" + nfspvc.Name
+}
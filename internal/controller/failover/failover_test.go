@@ -0,0 +1,175 @@
+package failover
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/dana-team/nfspvc-operator/internal/sharding"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Sync", func() {
+	const namespace = "default"
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		failover  danaiov1alpha1.NfsServerFailover
+		recorder  *record.FakeRecorder
+		now       time.Time
+	)
+
+	sync := func() {
+		status, err := Sync(ctx, failover, k8sClient, k8sClient, sharding.Scope{}, recorder, now)
+		Expect(err).NotTo(HaveOccurred())
+		failover.Status = status
+	}
+
+	getNfsPvc := func(name string) danaiov1alpha1.NfsPvc {
+		nfspvc := danaiov1alpha1.NfsPvc{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &nfspvc)).To(Succeed())
+		return nfspvc
+	}
+
+	getPV := func(name string) *corev1.PersistentVolume {
+		pv := &corev1.PersistentVolume{}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: resources.PVName(getNfsPvc(name))}, pv); err != nil {
+			return nil
+		}
+		return pv
+	}
+
+	// bind marks the PVC of the nfspvc as bound, as the PV controller does once the new PV exists.
+	bind := func(name string) {
+		pvc := corev1.PersistentVolumeClaim{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &pvc)).To(Succeed())
+		pvc.Status.Phase = corev1.ClaimBound
+		Expect(k8sClient.Status().Update(ctx, &pvc)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		recorder = record.NewFakeRecorder(10)
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(danaiov1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		var objects []client.Object
+		for i, server := range []string{"nas-a", "nas-a", "nas-a", "nas-c"} {
+			nfspvc := &danaiov1alpha1.NfsPvc{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("data-%d", i), Namespace: namespace},
+				Spec:       danaiov1alpha1.NfsPvcSpec{Server: server, Path: fmt.Sprintf("/data-%d", i), NfsVersion: "4.1"},
+			}
+			pv := resources.PreparePV(*nfspvc, "", string(corev1.PersistentVolumeReclaimRetain))
+			pv.Finalizers = []string{pvProtectionFinalizer}
+			pvc := resources.PreparePVC(*nfspvc, "")
+			pvc.UID = types.UID(nfspvc.Name + "-uid")
+			pvc.Status.Phase = corev1.ClaimBound
+			objects = append(objects, nfspvc, &pv, &pvc)
+		}
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+			WithStatusSubresource(&corev1.PersistentVolumeClaim{}).Build()
+		failover = danaiov1alpha1.NfsServerFailover{
+			ObjectMeta: metav1.ObjectMeta{Name: "dr"},
+			Spec:       danaiov1alpha1.NfsServerFailoverSpec{From: "nas-a", To: "nas-b", BatchSize: 2},
+		}
+	})
+
+	It("should move the nfspvcs of the server in batches", func() {
+		sync()
+		Expect(failover.Status.Phase).To(Equal(danaiov1alpha1.ProgressingNfsServerFailoverPhase))
+		Expect(failover.Status.Total).To(Equal(int32(3)))
+		Expect(failover.Status.InProgress).To(Equal(int32(2)))
+		Expect(getNfsPvc("data-0").Spec.Server).To(Equal("nas-b"))
+		Expect(getNfsPvc("data-0").Annotations).To(HaveKeyWithValue(danaiov1alpha1.FailoverAnnotation, "dr"))
+		Expect(getNfsPvc("data-0").Spec.Path).To(Equal("/data-0"))
+		Expect(getNfsPvc("data-2").Spec.Server).To(Equal("nas-a"))
+		Expect(getNfsPvc("data-3").Spec.Server).To(Equal("nas-c"))
+
+		By("deleting the old PVs")
+		sync()
+		Expect(getPV("data-0")).To(BeNil())
+		Expect(recorder.Events).To(Receive(ContainSubstring(PVProtectionRemovedReason)))
+
+		By("recreating the PVs against the new server, pre-bound to the existing PVCs")
+		sync()
+		pv := getPV("data-0")
+		Expect(pv).NotTo(BeNil())
+		Expect(pv.Spec.NFS.Server).To(Equal("nas-b"))
+		Expect(pv.Spec.ClaimRef.UID).To(Equal(types.UID("data-0-uid")))
+		Expect(failover.Status.InProgress).To(Equal(int32(2)))
+
+		By("moving the next batch once the PVCs are bound")
+		bind("data-0")
+		bind("data-1")
+		sync()
+		Expect(failover.Status.Moved).To(Equal(int32(2)))
+		Expect(getNfsPvc("data-2").Spec.Server).To(Equal("nas-b"))
+		sync()
+		sync()
+		bind("data-2")
+		sync()
+		Expect(failover.Status.Phase).To(Equal(danaiov1alpha1.CompletedNfsServerFailoverPhase))
+		Expect(failover.Status.Moved).To(Equal(int32(3)))
+		Expect(failover.Status.CompletionTime.Time).To(BeTemporally("==", now))
+
+		By("removing the failover annotation of the moved nfspvcs")
+		for _, name := range []string{"data-0", "data-1", "data-2"} {
+			Expect(getNfsPvc(name).Annotations).NotTo(HaveKey(danaiov1alpha1.FailoverAnnotation))
+			Expect(getNfsPvc(name).Annotations).To(HaveKeyWithValue(danaiov1alpha1.FailedOverAnnotation, "dr"))
+		}
+	})
+
+	It("should keep the pv-protection finalizer once the failover completed", func() {
+		nfspvc := getNfsPvc("data-0")
+		nfspvc.Spec.Server = "nas-b"
+		nfspvc.Annotations = map[string]string{danaiov1alpha1.FailoverAnnotation: "dr", danaiov1alpha1.FailedOverAnnotation: "dr"}
+		Expect(k8sClient.Update(ctx, &nfspvc)).To(Succeed())
+		failover.Status = danaiov1alpha1.NfsServerFailoverStatus{Phase: danaiov1alpha1.CompletedNfsServerFailoverPhase}
+		Expect(failover.IsProgressing()).To(BeFalse())
+
+		sync()
+		pv := getPV("data-0")
+		Expect(pv).NotTo(BeNil())
+		Expect(pv.DeletionTimestamp).NotTo(BeNil())
+		Expect(pv.Finalizers).To(ContainElement(pvProtectionFinalizer))
+		Expect(recorder.Events).NotTo(Receive())
+	})
+
+	It("should only move the nfspvcs it failed over back on failback", func() {
+		failover.Spec.BatchSize = 10
+		for range 3 {
+			sync()
+		}
+		for _, name := range []string{"data-0", "data-1", "data-2"} {
+			bind(name)
+		}
+		sync()
+		Expect(failover.Status.Phase).To(Equal(danaiov1alpha1.CompletedNfsServerFailoverPhase))
+
+		other := getNfsPvc("data-3")
+		other.Spec.Server = "nas-b"
+		Expect(k8sClient.Update(ctx, &other)).To(Succeed())
+
+		failover.Spec.Failback = true
+		sync()
+		Expect(failover.Status.Failback).To(BeTrue())
+		Expect(failover.Status.Phase).To(Equal(danaiov1alpha1.ProgressingNfsServerFailoverPhase))
+		Expect(failover.Status.Total).To(Equal(int32(3)))
+		Expect(getNfsPvc("data-0").Spec.Server).To(Equal("nas-a"))
+		Expect(getNfsPvc("data-0").Annotations).To(HaveKeyWithValue(danaiov1alpha1.FailoverAnnotation, "dr"))
+		Expect(getNfsPvc("data-3").Spec.Server).To(Equal("nas-b"))
+	})
+})
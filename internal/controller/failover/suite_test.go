@@ -0,0 +1,14 @@
+package failover
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestFailover(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "Failover Suite")
+}
@@ -11,21 +11,30 @@ import (
 )
 
 // Remove removes a finalizer from the nfspvc object.
-func Remove(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, k8sClient client.Client) error {
-	controllerutil.RemoveFinalizer(&nfspvc, utils.NfsPvcDeletionFinalizer)
-	if err := k8sClient.Update(ctx, &nfspvc); err != nil {
-		return err
-	}
-	return nil
+// The finalizers are merge-patched with an optimistic lock, so that a concurrent change to them is not lost.
+func Remove(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc, k8sClient client.Client) error {
+	return RemoveFrom(ctx, nfspvc, utils.NfsPvcDeletionFinalizer, k8sClient)
 }
 
 // Ensure adds a finalizer to the nfspvc object if one does not exist.
-func Ensure(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, k8sClient client.Client) error {
-	if !controllerutil.ContainsFinalizer(&nfspvc, utils.NfsPvcDeletionFinalizer) {
-		controllerutil.AddFinalizer(&nfspvc, utils.NfsPvcDeletionFinalizer)
-		if err := k8sClient.Update(ctx, &nfspvc); err != nil {
-			return err
-		}
+func Ensure(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc, k8sClient client.Client) error {
+	return AddTo(ctx, nfspvc, utils.NfsPvcDeletionFinalizer, k8sClient)
+}
+
+// RemoveFrom removes the finalizer from the object, merge-patched with an optimistic lock like Remove.
+func RemoveFrom(ctx context.Context, obj client.Object, finalizer string, k8sClient client.Client) error {
+	original := obj.DeepCopyObject().(client.Object)
+	if !controllerutil.RemoveFinalizer(obj, finalizer) {
+		return nil
+	}
+	return client.IgnoreNotFound(k8sClient.Patch(ctx, obj, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})))
+}
+
+// AddTo adds the finalizer to the object if it does not have it yet, merge-patched with an optimistic lock like Ensure.
+func AddTo(ctx context.Context, obj client.Object, finalizer string, k8sClient client.Client) error {
+	original := obj.DeepCopyObject().(client.Object)
+	if !controllerutil.AddFinalizer(obj, finalizer) {
+		return nil
 	}
-	return nil
+	return k8sClient.Patch(ctx, obj, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}))
 }
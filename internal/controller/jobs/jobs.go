@@ -0,0 +1,185 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// JobLabel marks the data mover Jobs of the operator with the kind of work they do.
+	JobLabel = "nfspvc.dana.io/job"
+
+	containerName = "data-mover"
+	backoffLimit  = 2
+)
+
+// DefaultImage is the image of the data mover Jobs when none is configured. An image must provide sh, rsync, tar, du and find.
+const DefaultImage = "docker.io/instrumentisto/rsync-ssh:latest"
+
+// Phase is the progress of a data mover Job.
+type Phase string
+
+const (
+	Running   Phase = "Running"
+	Succeeded Phase = "Succeeded"
+	Failed    Phase = "Failed"
+)
+
+// Volume is a volume mounted in a data mover Job.
+type Volume struct {
+	Name      string
+	MountPath string
+	ReadOnly  bool
+	Source    corev1.VolumeSource
+}
+
+// ClaimVolume returns a volume of the given PVC.
+func ClaimVolume(name, mountPath, claimName string, readOnly bool) Volume {
+	return Volume{
+		Name:      name,
+		MountPath: mountPath,
+		ReadOnly:  readOnly,
+		Source: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName, ReadOnly: readOnly},
+		},
+	}
+}
+
+// NFSVolume returns a volume of the given NFS export.
+func NFSVolume(name, mountPath, server, path string, readOnly bool) Volume {
+	return Volume{
+		Name:      name,
+		MountPath: mountPath,
+		ReadOnly:  readOnly,
+		Source: corev1.VolumeSource{
+			NFS: &corev1.NFSVolumeSource{Server: server, Path: path, ReadOnly: readOnly},
+		},
+	}
+}
+
+// PrepareJob returns a Job running the shell script in the image with the given volumes. The script reports its
+// results as key=value lines written to /dev/termination-log.
+func PrepareJob(name, namespace, kind, image, script string, volumes ...Volume) batchv1.Job {
+	podVolumes := make([]corev1.Volume, 0, len(volumes))
+	mounts := make([]corev1.VolumeMount, 0, len(volumes))
+	for _, volume := range volumes {
+		podVolumes = append(podVolumes, corev1.Volume{Name: volume.Name, VolumeSource: volume.Source})
+		mounts = append(mounts, corev1.VolumeMount{Name: volume.Name, MountPath: volume.MountPath, ReadOnly: volume.ReadOnly})
+	}
+
+	labels := map[string]string{JobLabel: kind}
+	return batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To(int32(backoffLimit)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:                     containerName,
+						Image:                    image,
+						Command:                  []string{"/bin/sh", "-ec", script},
+						VolumeMounts:             mounts,
+						TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+					}},
+					Volumes: podVolumes,
+				},
+			},
+		},
+	}
+}
+
+// Ensure creates the Job if it does not exist and returns the Job from the cluster.
+func Ensure(ctx context.Context, k8sClient client.Client, job batchv1.Job) (batchv1.Job, error) {
+	existing := batchv1.Job{}
+	err := k8sClient.Get(ctx, types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, &existing)
+	if err == nil {
+		return existing, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return existing, fmt.Errorf("failed to fetch job %q: %v", job.Name, err)
+	}
+	if err := k8sClient.Create(ctx, &job); err != nil && !apierrors.IsAlreadyExists(err) {
+		return job, fmt.Errorf("failed to create job %q: %v", job.Name, err)
+	}
+	return job, nil
+}
+
+// Delete deletes the Job and its pods.
+func Delete(ctx context.Context, k8sClient client.Client, name, namespace string) error {
+	job := batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	return client.IgnoreNotFound(k8sClient.Delete(ctx, &job, client.PropagationPolicy(metav1.DeletePropagationBackground)))
+}
+
+// State is the observed progress of a data mover Job.
+type State struct {
+	Phase          Phase
+	StartTime      *metav1.Time
+	CompletionTime *metav1.Time
+	Message        string
+	// Results are the key=value lines reported by the script of a succeeded Job.
+	Results map[string]string
+}
+
+// Observe returns the state of the Job, including the results reported by its succeeded pod.
+func Observe(ctx context.Context, k8sClient client.Client, job batchv1.Job) (State, error) {
+	state := State{Phase: Running, StartTime: job.Status.StartTime}
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			state.Phase = Succeeded
+			state.CompletionTime = job.Status.CompletionTime
+		case batchv1.JobFailed:
+			state.Phase = Failed
+			state.CompletionTime = &condition.LastTransitionTime
+			state.Message = condition.Message
+		}
+	}
+	if state.Phase != Succeeded {
+		return state, nil
+	}
+
+	pods := corev1.PodList{}
+	if err := k8sClient.List(ctx, &pods, client.InNamespace(job.Namespace), client.MatchingLabels{batchv1.JobNameLabel: job.Name}); err != nil {
+		return state, fmt.Errorf("failed to list the pods of job %q: %v", job.Name, err)
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == containerName && status.State.Terminated != nil {
+				state.Results = parseResults(status.State.Terminated.Message)
+			}
+		}
+	}
+	return state, nil
+}
+
+// parseResults parses the key=value lines of a termination message.
+func parseResults(message string) map[string]string {
+	results := map[string]string{}
+	for _, line := range strings.Split(message, "\n") {
+		if key, value, ok := strings.Cut(strings.TrimSpace(line), "="); ok {
+			results[key] = value
+		}
+	}
+	return results
+}
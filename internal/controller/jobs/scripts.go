@@ -0,0 +1,90 @@
+package jobs
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// SizeResult is the result holding the size in bytes of the copied data.
+	SizeResult = "size"
+	// FilesResult is the result holding the number of copied files.
+	FilesResult = "files"
+
+	RsyncMethod = "rsync"
+	TarMethod   = "tar"
+)
+
+// CopyScript returns a script copying the content of the source directory into the destination directory
+// with rsync or tar, and reporting the size and the number of files of the destination.
+// With mirror, files missing from the source are removed from the destination; it requires rsync.
+func CopyScript(method, source, destination string, mirror bool) string {
+	var copyCommand string
+	switch {
+	case method == TarMethod:
+		copyCommand = fmt.Sprintf("tar -C %s -cf - . | tar -C %s -xpf -", quote(source), quote(destination))
+	case mirror:
+		copyCommand = fmt.Sprintf("rsync -a --delete %s/ %s/", quote(source), quote(destination))
+	default:
+		copyCommand = fmt.Sprintf("rsync -a %s/ %s/", quote(source), quote(destination))
+	}
+	return strings.Join([]string{
+		fmt.Sprintf("mkdir -p %s", quote(destination)),
+		copyCommand,
+		reportScript(destination),
+	}, "\n")
+}
+
+// RemoveScript returns a script removing the directory and its content.
+func RemoveScript(directory string) string {
+	return fmt.Sprintf("rm -rf %s", quote(directory))
+}
+
+// CreatePathScript returns a script creating the directory with its parents, and setting its owner,
+// group and mode. An empty owner or group is left unchanged.
+func CreatePathScript(directory, owner, group, mode string) string {
+	commands := []string{fmt.Sprintf("mkdir -p %s", quote(directory))}
+	if owner != "" {
+		commands = append(commands, fmt.Sprintf("chown %s %s", quote(owner), quote(directory)))
+	}
+	if group != "" {
+		commands = append(commands, fmt.Sprintf("chgrp %s %s", quote(group), quote(directory)))
+	}
+	if mode != "" {
+		commands = append(commands, fmt.Sprintf("chmod %s %s", quote(mode), quote(directory)))
+	}
+	return strings.Join(commands, "\n")
+}
+
+// ScrubScript returns a script reporting the size and the number of files of the directory, then removing its content.
+func ScrubScript(directory string) string {
+	return strings.Join([]string{
+		reportScript(directory),
+		fmt.Sprintf("find %s -mindepth 1 -delete", quote(directory)),
+	}, "\n")
+}
+
+// ArchiveScript returns a script writing the content of the directory into a gzipped tar archive, reporting
+// the size of the archive and the number of archived files, then removing the content of the directory.
+func ArchiveScript(directory, archive string) string {
+	return strings.Join([]string{
+		fmt.Sprintf(`mkdir -p "$(dirname %s)"`, quote(archive)),
+		fmt.Sprintf("tar -C %s -czf %s .", quote(directory), quote(archive)),
+		fmt.Sprintf(`echo "%s=$(wc -c < %s)" > /dev/termination-log`, SizeResult, quote(archive)),
+		fmt.Sprintf(`echo "%s=$(find %s -type f | wc -l)" >> /dev/termination-log`, FilesResult, quote(directory)),
+		fmt.Sprintf("find %s -mindepth 1 -delete", quote(directory)),
+	}, "\n")
+}
+
+// reportScript returns the commands reporting the size and the number of files of the directory.
+func reportScript(directory string) string {
+	return strings.Join([]string{
+		fmt.Sprintf(`echo "%s=$(( $(du -sk %s | cut -f1) * 1024 ))" > /dev/termination-log`, SizeResult, quote(directory)),
+		fmt.Sprintf(`echo "%s=$(find %s -type f | wc -l)" >> /dev/termination-log`, FilesResult, quote(directory)),
+	}, "\n")
+}
+
+// quote quotes a path for the shell.
+func quote(path string) string {
+	return "'" + strings.ReplaceAll(path, "'", `'\''`) + "'"
+}
@@ -0,0 +1,472 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/jobs"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	jobKind     = "migration"
+	namePrefix  = "nfspvcmigration-"
+	sourceMount = "/source"
+	destMount   = "/destination"
+
+	defaultTimeout = 10 * time.Minute
+)
+
+// steps are the steps of a migration, in order.
+var steps = []danaiov1alpha1.NfsPvcMigrationStepName{
+	danaiov1alpha1.CopyNfsPvcMigrationStep,
+	danaiov1alpha1.QuiesceNfsPvcMigrationStep,
+	danaiov1alpha1.FinalSyncNfsPvcMigrationStep,
+	danaiov1alpha1.SwitchNfsPvcMigrationStep,
+}
+
+// migrator advances a migration, recording the progress in a copy of its status.
+type migrator struct {
+	client    client.Client
+	scheme    *runtime.Scheme
+	migration danaiov1alpha1.NfsPvcMigration
+	status    *danaiov1alpha1.NfsPvcMigrationStatus
+	now       time.Time
+}
+
+// Sync advances the migration by one step and returns its status. It returns true when the migration
+// waits for something it does not watch, such as the consumers of the PVC, and must be reconciled again.
+func Sync(ctx context.Context, migration danaiov1alpha1.NfsPvcMigration, k8sClient client.Client, scheme *runtime.Scheme, now time.Time) (danaiov1alpha1.NfsPvcMigrationStatus, bool, error) {
+	m := migrator{client: k8sClient, scheme: scheme, migration: migration, status: migration.Status.DeepCopy(), now: now}
+	var requeue bool
+	var err error
+	switch m.status.Phase {
+	case "":
+		requeue, err = m.start(ctx)
+	case danaiov1alpha1.CopyingNfsPvcMigrationPhase:
+		requeue, err = m.copy(ctx, danaiov1alpha1.CopyNfsPvcMigrationStep)
+	case danaiov1alpha1.QuiescingNfsPvcMigrationPhase:
+		requeue, err = m.quiesce(ctx)
+	case danaiov1alpha1.SyncingNfsPvcMigrationPhase:
+		requeue, err = m.copy(ctx, danaiov1alpha1.FinalSyncNfsPvcMigrationStep)
+	case danaiov1alpha1.SwitchingNfsPvcMigrationPhase:
+		requeue, err = m.switchOver(ctx)
+	case danaiov1alpha1.RollingBackNfsPvcMigrationPhase:
+		requeue, err = m.rollback(ctx)
+	}
+	return *m.status, requeue, err
+}
+
+// IsFinished returns true if the migration succeeded or was rolled back.
+func IsFinished(migration danaiov1alpha1.NfsPvcMigration) bool {
+	return migration.Status.Phase == danaiov1alpha1.SucceededNfsPvcMigrationPhase ||
+		migration.Status.Phase == danaiov1alpha1.RolledBackNfsPvcMigrationPhase
+}
+
+// Abort is called when an unfinished migration is deleted. It scales the quiesced workloads back up and
+// removes the Jobs and the temporary PVCs, but it does not revert a switch in progress.
+func Abort(ctx context.Context, migration danaiov1alpha1.NfsPvcMigration, k8sClient client.Client) error {
+	if err := resume(ctx, k8sClient, migration.Namespace, migration.Status.QuiescedWorkloads); err != nil {
+		return err
+	}
+	return cleanup(ctx, migration, k8sClient)
+}
+
+// JobName returns the name of the Job copying the data during the step.
+func JobName(migration danaiov1alpha1.NfsPvcMigration, step danaiov1alpha1.NfsPvcMigrationStepName) string {
+	return utils.ShortenName(namePrefix+migration.Name+"-"+strings.ToLower(string(step)), utils.MaxLabelValueLength)
+}
+
+// start records the current server and path of the nfspvc and claims it with the migration annotation.
+func (m *migrator) start(ctx context.Context) (bool, error) {
+	nfspvc, err := m.nfspvc(ctx)
+	if err != nil || nfspvc == nil {
+		return true, err
+	}
+	if owner, ok := nfspvc.Annotations[danaiov1alpha1.MigrationAnnotation]; ok && owner != m.migration.Name {
+		m.status.Message = fmt.Sprintf("nfspvc %q is being moved by migration %q", nfspvc.Name, owner)
+		return true, nil
+	}
+	if nfspvc.Spec.Server == m.migration.Spec.Server && resources.ExportPath(*nfspvc) == m.migration.Spec.Path {
+		m.status.Phase = danaiov1alpha1.SucceededNfsPvcMigrationPhase
+		m.status.Message = "the nfspvc already uses the target server and path"
+		return false, nil
+	}
+	if err := setAnnotation(ctx, m.client, nfspvc, m.migration.Name); err != nil {
+		return false, err
+	}
+
+	m.status.SourceServer = nfspvc.Spec.Server
+	m.status.SourcePath = resources.ExportPath(*nfspvc)
+	m.status.Steps = make([]danaiov1alpha1.NfsPvcMigrationStep, 0, len(steps))
+	for _, name := range steps {
+		m.status.Steps = append(m.status.Steps, danaiov1alpha1.NfsPvcMigrationStep{Name: name, State: danaiov1alpha1.PendingNfsPvcMigrationStepState})
+	}
+	m.startStep(danaiov1alpha1.CopyNfsPvcMigrationStep, danaiov1alpha1.CopyingNfsPvcMigrationPhase)
+	return true, nil
+}
+
+// copy runs the Job mirroring the source export into the target export during the Copy or the FinalSync step.
+func (m *migrator) copy(ctx context.Context, step danaiov1alpha1.NfsPvcMigrationStepName) (bool, error) {
+	nfspvc, err := m.nfspvc(ctx)
+	if err != nil {
+		return false, err
+	}
+	if nfspvc == nil {
+		m.fail(step, fmt.Sprintf("nfspvc %q not found", m.migration.Spec.NfsPvcName))
+		return true, nil
+	}
+	source, target := m.claims(*nfspvc)
+	for _, claim := range []danaiov1alpha1.NfsPvc{source, target} {
+		if err := resources.EnsureClaim(ctx, claim, m.client); err != nil {
+			return false, err
+		}
+	}
+
+	job := jobs.PrepareJob(JobName(m.migration, step), m.migration.Namespace, jobKind, utils.DataMoverImage,
+		jobs.CopyScript(jobs.RsyncMethod, sourceMount, destMount, true),
+		jobs.ClaimVolume("source", sourceMount, source.Name, true),
+		jobs.ClaimVolume("destination", destMount, target.Name, false),
+	)
+	if err := controllerutil.SetControllerReference(&m.migration, &job, m.scheme); err != nil {
+		return false, err
+	}
+	job, err = jobs.Ensure(ctx, m.client, job)
+	if err != nil {
+		return false, err
+	}
+	state, err := jobs.Observe(ctx, m.client, job)
+	if err != nil {
+		return false, err
+	}
+
+	switch state.Phase {
+	case jobs.Failed:
+		m.fail(step, fmt.Sprintf("job %q failed: %s", job.Name, state.Message))
+		return true, nil
+	case jobs.Succeeded:
+		m.completeStep(step, danaiov1alpha1.SucceededNfsPvcMigrationStepState,
+			fmt.Sprintf("copied %s bytes in %s files", state.Results[jobs.SizeResult], state.Results[jobs.FilesResult]))
+		switch {
+		case step == danaiov1alpha1.FinalSyncNfsPvcMigrationStep:
+			m.startStep(danaiov1alpha1.SwitchNfsPvcMigrationStep, danaiov1alpha1.SwitchingNfsPvcMigrationPhase)
+		case m.migration.Spec.Quiesce:
+			m.startStep(danaiov1alpha1.QuiesceNfsPvcMigrationStep, danaiov1alpha1.QuiescingNfsPvcMigrationPhase)
+		default:
+			m.completeStep(danaiov1alpha1.QuiesceNfsPvcMigrationStep, danaiov1alpha1.SkippedNfsPvcMigrationStepState, "quiesce is disabled")
+			m.startStep(danaiov1alpha1.FinalSyncNfsPvcMigrationStep, danaiov1alpha1.SyncingNfsPvcMigrationPhase)
+		}
+		return true, nil
+	}
+	m.setStepMessage(step, fmt.Sprintf("job %q is copying the data", job.Name))
+	return false, nil
+}
+
+// quiesce scales the workloads mounting the PVC down to zero and waits for their pods to be gone.
+func (m *migrator) quiesce(ctx context.Context) (bool, error) {
+	step := danaiov1alpha1.QuiesceNfsPvcMigrationStep
+	pods, err := podsUsing(ctx, m.client, m.migration.Namespace, m.migration.Spec.NfsPvcName)
+	if err != nil {
+		return false, err
+	}
+	m.status.QuiescedWorkloads, err = quiesce(ctx, m.client, m.migration.Namespace, pods, m.status.QuiescedWorkloads)
+	if err != nil {
+		return false, err
+	}
+	if len(pods) == 0 {
+		m.completeStep(step, danaiov1alpha1.SucceededNfsPvcMigrationStepState, fmt.Sprintf("scaled down %d workloads", len(m.status.QuiescedWorkloads)))
+		m.startStep(danaiov1alpha1.FinalSyncNfsPvcMigrationStep, danaiov1alpha1.SyncingNfsPvcMigrationPhase)
+		return true, nil
+	}
+	m.waitForConsumers(step, pods)
+	return true, nil
+}
+
+// switchOver points the nfspvc at the target, then deletes its PV and PVC so that the nfspvc controller
+// recreates them against the target with the same names, and waits for the PVC to be bound again.
+func (m *migrator) switchOver(ctx context.Context) (bool, error) {
+	step := danaiov1alpha1.SwitchNfsPvcMigrationStep
+	nfspvc, err := m.nfspvc(ctx)
+	if err != nil {
+		return false, err
+	}
+	if nfspvc == nil {
+		m.fail(step, fmt.Sprintf("nfspvc %q not found", m.migration.Spec.NfsPvcName))
+		return true, nil
+	}
+	observed, err := resources.Observe(ctx, *nfspvc, m.client)
+	if err != nil {
+		return false, err
+	}
+	if isBoundTo(observed, m.migration.Spec.Server, m.migration.Spec.Path) {
+		if err := m.finish(ctx); err != nil {
+			return false, err
+		}
+		m.completeStep(step, danaiov1alpha1.SucceededNfsPvcMigrationStepState, fmt.Sprintf("pvc %q is bound to %s:%s", nfspvc.Name, m.migration.Spec.Server, m.migration.Spec.Path))
+		m.status.Phase = danaiov1alpha1.SucceededNfsPvcMigrationPhase
+		m.status.Message = ""
+		return false, nil
+	}
+
+	pods, err := podsUsing(ctx, m.client, m.migration.Namespace, nfspvc.Name)
+	if err != nil {
+		return false, err
+	}
+	if len(pods) > 0 {
+		m.waitForConsumers(step, pods)
+		return true, nil
+	}
+	if err := m.repoint(ctx, nfspvc, observed, m.migration.Spec.Server, m.migration.Spec.Path); err != nil {
+		return false, err
+	}
+	if m.timedOut(step) {
+		m.fail(step, fmt.Sprintf("pvc %q was not bound to the target within %s", nfspvc.Name, m.timeout()))
+		return true, nil
+	}
+	m.setStepMessage(step, fmt.Sprintf("waiting for pvc %q to be bound to the target", nfspvc.Name))
+	return true, nil
+}
+
+// rollback points the nfspvc back at the source, scales the quiesced workloads back up and removes
+// the Jobs and the temporary PVCs. The data already copied to the target is left in place.
+func (m *migrator) rollback(ctx context.Context) (bool, error) {
+	nfspvc, err := m.nfspvc(ctx)
+	if err != nil {
+		return false, err
+	}
+	if nfspvc != nil && m.status.SourceServer != "" {
+		observed, err := resources.Observe(ctx, *nfspvc, m.client)
+		if err != nil {
+			return false, err
+		}
+		if observed.PV == nil || !usesExport(observed.PV, m.status.SourceServer, m.status.SourcePath) || resources.IsPVDeleting(observed) {
+			if err := m.repoint(ctx, nfspvc, observed, m.status.SourceServer, m.status.SourcePath); err != nil {
+				return false, err
+			}
+			m.status.Message = fmt.Sprintf("waiting for the pv of nfspvc %q to use %s:%s again", nfspvc.Name, m.status.SourceServer, m.status.SourcePath)
+			return true, nil
+		}
+	}
+
+	if err := m.finish(ctx); err != nil {
+		return false, err
+	}
+	for i := range m.status.Steps {
+		switch m.status.Steps[i].State {
+		case danaiov1alpha1.SucceededNfsPvcMigrationStepState, danaiov1alpha1.RunningNfsPvcMigrationStepState:
+			m.status.Steps[i].State = danaiov1alpha1.RolledBackNfsPvcMigrationStepState
+			m.status.Steps[i].CompletionTime = &metav1.Time{Time: m.now}
+		}
+	}
+	m.status.Phase = danaiov1alpha1.RolledBackNfsPvcMigrationPhase
+	return false, nil
+}
+
+// repoint sets the server and the path of the nfspvc, then deletes its PV and PVC if the PV uses another export.
+// The PV is retained first, so that its deletion never touches the data.
+func (m *migrator) repoint(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc, observed resources.Observed, server, path string) error {
+	if nfspvc.Spec.Server != server || nfspvc.Spec.Path != path {
+		patch := client.MergeFrom(nfspvc.DeepCopy())
+		nfspvc.Spec.Server = server
+		nfspvc.Spec.Path = path
+		if err := m.client.Patch(ctx, nfspvc, patch); err != nil {
+			return fmt.Errorf("failed to update the server and the path of nfspvc %q: %v", nfspvc.Name, err)
+		}
+	}
+	if observed.PV == nil || usesExport(observed.PV, server, path) || resources.IsPVDeleting(observed) {
+		return nil
+	}
+
+	if observed.PV.Spec.PersistentVolumeReclaimPolicy != corev1.PersistentVolumeReclaimRetain {
+		patch := client.MergeFrom(observed.PV.DeepCopy())
+		observed.PV.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimRetain
+		if err := m.client.Patch(ctx, observed.PV, patch); err != nil {
+			return fmt.Errorf("failed to retain pv %q: %v", observed.PV.Name, err)
+		}
+	}
+	if observed.PVC != nil {
+		if err := m.client.Delete(ctx, observed.PVC); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete pvc %q: %v", observed.PVC.Name, err)
+		}
+	}
+	if err := m.client.Delete(ctx, observed.PV); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete pv %q: %v", observed.PV.Name, err)
+	}
+	return nil
+}
+
+// finish scales the quiesced workloads back up, removes the Jobs and the temporary PVCs and releases the nfspvc.
+func (m *migrator) finish(ctx context.Context) error {
+	if err := resume(ctx, m.client, m.migration.Namespace, m.status.QuiescedWorkloads); err != nil {
+		return err
+	}
+	return cleanup(ctx, m.migration, m.client)
+}
+
+// cleanup deletes the Jobs and the temporary PVCs of the migration and removes the migration annotation from the nfspvc.
+func cleanup(ctx context.Context, migration danaiov1alpha1.NfsPvcMigration, k8sClient client.Client) error {
+	for _, step := range []danaiov1alpha1.NfsPvcMigrationStepName{danaiov1alpha1.CopyNfsPvcMigrationStep, danaiov1alpha1.FinalSyncNfsPvcMigrationStep} {
+		if err := jobs.Delete(ctx, k8sClient, JobName(migration, step), migration.Namespace); err != nil {
+			return fmt.Errorf("failed to delete job %q: %v", JobName(migration, step), err)
+		}
+	}
+	for _, role := range []string{"source", "target"} {
+		if err := resources.DeleteClaim(ctx, claimName(migration, role), migration.Namespace, k8sClient); err != nil {
+			return err
+		}
+	}
+
+	nfspvc := danaiov1alpha1.NfsPvc{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: migration.Spec.NfsPvcName, Namespace: migration.Namespace}, &nfspvc); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if nfspvc.Annotations[danaiov1alpha1.MigrationAnnotation] != migration.Name {
+		return nil
+	}
+	return setAnnotation(ctx, k8sClient, &nfspvc, "")
+}
+
+// setAnnotation sets the migration annotation of the nfspvc, or removes it when the name is empty.
+func setAnnotation(ctx context.Context, k8sClient client.Client, nfspvc *danaiov1alpha1.NfsPvc, name string) error {
+	patch := client.MergeFrom(nfspvc.DeepCopy())
+	if name == "" {
+		delete(nfspvc.Annotations, danaiov1alpha1.MigrationAnnotation)
+	} else {
+		if nfspvc.Annotations == nil {
+			nfspvc.Annotations = map[string]string{}
+		}
+		nfspvc.Annotations[danaiov1alpha1.MigrationAnnotation] = name
+	}
+	if err := k8sClient.Patch(ctx, nfspvc, patch); err != nil {
+		return fmt.Errorf("failed to update the %s annotation of nfspvc %q: %v", danaiov1alpha1.MigrationAnnotation, nfspvc.Name, err)
+	}
+	return nil
+}
+
+// nfspvc returns the migrated nfspvc, or nil with a message when it does not exist.
+func (m *migrator) nfspvc(ctx context.Context) (*danaiov1alpha1.NfsPvc, error) {
+	nfspvc := &danaiov1alpha1.NfsPvc{}
+	if err := m.client.Get(ctx, types.NamespacedName{Name: m.migration.Spec.NfsPvcName, Namespace: m.migration.Namespace}, nfspvc); err != nil {
+		if apierrors.IsNotFound(err) {
+			m.status.Message = fmt.Sprintf("nfspvc %q not found", m.migration.Spec.NfsPvcName)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch nfspvc %q: %v", m.migration.Spec.NfsPvcName, err)
+	}
+	return nfspvc, nil
+}
+
+// claims returns the temporary nfspvcs mounting the source export read-only and the target export.
+func (m *migrator) claims(nfspvc danaiov1alpha1.NfsPvc) (danaiov1alpha1.NfsPvc, danaiov1alpha1.NfsPvc) {
+	source := danaiov1alpha1.NfsPvc{
+		ObjectMeta: metav1.ObjectMeta{Name: claimName(m.migration, "source"), Namespace: m.migration.Namespace},
+		Spec:       *nfspvc.Spec.DeepCopy(),
+		Status:     danaiov1alpha1.NfsPvcStatus{NegotiatedVersion: nfspvc.Status.NegotiatedVersion},
+	}
+	source.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadOnlyMany}
+	source.Spec.DataSource = nil
+	source.Spec.Server = m.status.SourceServer
+	source.Spec.Path = m.status.SourcePath
+
+	target := *source.DeepCopy()
+	target.Name = claimName(m.migration, "target")
+	target.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+	target.Spec.Server = m.migration.Spec.Server
+	target.Spec.Path = m.migration.Spec.Path
+	return source, target
+}
+
+// claimName returns the name of a temporary PVC of the migration.
+func claimName(migration danaiov1alpha1.NfsPvcMigration, role string) string {
+	return utils.ShortenName(namePrefix+role+"-"+migration.Name, utils.MaxLabelValueLength)
+}
+
+// waitForConsumers records the pods still mounting the PVC and fails the step once it timed out.
+func (m *migrator) waitForConsumers(step danaiov1alpha1.NfsPvcMigrationStepName, pods []corev1.Pod) {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	message := fmt.Sprintf("pvc %q is still mounted by pods %s", m.migration.Spec.NfsPvcName, strings.Join(names, ", "))
+	if m.timedOut(step) {
+		m.fail(step, message)
+		return
+	}
+	m.setStepMessage(step, message)
+}
+
+// fail marks the step as failed and starts the rollback.
+func (m *migrator) fail(step danaiov1alpha1.NfsPvcMigrationStepName, message string) {
+	m.completeStep(step, danaiov1alpha1.FailedNfsPvcMigrationStepState, message)
+	m.status.Phase = danaiov1alpha1.RollingBackNfsPvcMigrationPhase
+	m.status.Message = fmt.Sprintf("step %s failed: %s", step, message)
+}
+
+// startStep marks the step as running and moves the migration to the phase.
+func (m *migrator) startStep(name danaiov1alpha1.NfsPvcMigrationStepName, phase danaiov1alpha1.NfsPvcMigrationPhase) {
+	step := m.step(name)
+	step.State = danaiov1alpha1.RunningNfsPvcMigrationStepState
+	step.StartTime = &metav1.Time{Time: m.now}
+	m.status.Phase = phase
+	m.status.Message = ""
+}
+
+// completeStep ends the step in the state.
+func (m *migrator) completeStep(name danaiov1alpha1.NfsPvcMigrationStepName, state danaiov1alpha1.NfsPvcMigrationStepState, message string) {
+	step := m.step(name)
+	step.State = state
+	step.CompletionTime = &metav1.Time{Time: m.now}
+	step.Message = message
+}
+
+// setStepMessage explains what a running step is waiting for.
+func (m *migrator) setStepMessage(name danaiov1alpha1.NfsPvcMigrationStepName, message string) {
+	m.step(name).Message = message
+}
+
+// timedOut returns true if the step has been running for longer than the timeout of the migration.
+func (m *migrator) timedOut(name danaiov1alpha1.NfsPvcMigrationStepName) bool {
+	step := m.step(name)
+	return step.StartTime != nil && m.now.Sub(step.StartTime.Time) > m.timeout()
+}
+
+// timeout returns the timeout of the quiesce and the switch steps.
+func (m *migrator) timeout() time.Duration {
+	if m.migration.Spec.Timeout == nil {
+		return defaultTimeout
+	}
+	return m.migration.Spec.Timeout.Duration
+}
+
+// step returns the status of the step, adding it when it is missing.
+func (m *migrator) step(name danaiov1alpha1.NfsPvcMigrationStepName) *danaiov1alpha1.NfsPvcMigrationStep {
+	for i := range m.status.Steps {
+		if m.status.Steps[i].Name == name {
+			return &m.status.Steps[i]
+		}
+	}
+	m.status.Steps = append(m.status.Steps, danaiov1alpha1.NfsPvcMigrationStep{Name: name, State: danaiov1alpha1.PendingNfsPvcMigrationStepState})
+	return &m.status.Steps[len(m.status.Steps)-1]
+}
+
+// isBoundTo returns true if the PVC is bound to a PV using the export.
+func isBoundTo(observed resources.Observed, server, path string) bool {
+	return observed.PV != nil && !resources.IsPVDeleting(observed) && usesExport(observed.PV, server, path) &&
+		observed.PVC != nil && observed.PVC.Status.Phase == corev1.ClaimBound
+}
+
+// usesExport returns true if the PV mounts the export.
+func usesExport(pv *corev1.PersistentVolume, server, path string) bool {
+	return pv.Spec.NFS != nil && pv.Spec.NFS.Server == server && pv.Spec.NFS.Path == path
+}
@@ -0,0 +1,230 @@
+package migration
+
+import (
+	"context"
+	"time"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/jobs"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Sync", func() {
+	const namespace = "default"
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		scheme    *runtime.Scheme
+		nfspvc    *danaiov1alpha1.NfsPvc
+		migration danaiov1alpha1.NfsPvcMigration
+		now       time.Time
+	)
+
+	// sync advances the migration and keeps its new status.
+	sync := func() bool {
+		status, requeue, err := Sync(ctx, migration, k8sClient, scheme, now)
+		Expect(err).NotTo(HaveOccurred())
+		migration.Status = status
+		return requeue
+	}
+
+	// finishJob marks the Job of the step as complete or failed.
+	finishJob := func(step danaiov1alpha1.NfsPvcMigrationStepName, conditionType batchv1.JobConditionType) {
+		job := batchv1.Job{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: JobName(migration, step), Namespace: namespace}, &job)).To(Succeed())
+		job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+		Expect(k8sClient.Status().Update(ctx, &job)).To(Succeed())
+	}
+
+	stepState := func(name danaiov1alpha1.NfsPvcMigrationStepName) danaiov1alpha1.NfsPvcMigrationStepState {
+		for _, step := range migration.Status.Steps {
+			if step.Name == name {
+				return step.State
+			}
+		}
+		return ""
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(danaiov1alpha1.AddToScheme(scheme)).To(Succeed())
+		nfspvc = &danaiov1alpha1.NfsPvc{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: namespace},
+			Spec: danaiov1alpha1.NfsPvcSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+				Server:      "old-nas",
+				Path:        "/old",
+				NfsVersion:  "4.1",
+			},
+		}
+		pv := resources.PreparePV(*nfspvc, "", string(corev1.PersistentVolumeReclaimDelete))
+		pvc := resources.PreparePVC(*nfspvc, "")
+		pvc.Status.Phase = corev1.ClaimBound
+		migration = danaiov1alpha1.NfsPvcMigration{
+			ObjectMeta: metav1.ObjectMeta{Name: "move", Namespace: namespace, UID: "move-uid"},
+			Spec: danaiov1alpha1.NfsPvcMigrationSpec{
+				NfsPvcName: "data",
+				Server:     "new-nas",
+				Path:       "/new",
+				Timeout:    &metav1.Duration{Duration: time.Minute},
+			},
+		}
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(nfspvc, &pv, &pvc).Build()
+	})
+
+	It("should copy, sync and switch the nfspvc to the target", func() {
+		Expect(sync()).To(BeTrue())
+		Expect(migration.Status.Phase).To(Equal(danaiov1alpha1.CopyingNfsPvcMigrationPhase))
+		Expect(migration.Status.SourceServer).To(Equal("old-nas"))
+		Expect(migration.Status.SourcePath).To(Equal("/old"))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(nfspvc), nfspvc)).To(Succeed())
+		Expect(nfspvc.Annotations).To(HaveKeyWithValue(danaiov1alpha1.MigrationAnnotation, "move"))
+
+		Expect(sync()).To(BeFalse())
+		Expect(stepState(danaiov1alpha1.CopyNfsPvcMigrationStep)).To(Equal(danaiov1alpha1.RunningNfsPvcMigrationStepState))
+		target := corev1.PersistentVolume{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: claimName(migration, "target") + "-default-pv"}, &target)).To(Succeed())
+		Expect(target.Spec.NFS.Server).To(Equal("new-nas"))
+		Expect(target.Spec.PersistentVolumeReclaimPolicy).To(Equal(corev1.PersistentVolumeReclaimRetain))
+		job := batchv1.Job{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: JobName(migration, danaiov1alpha1.CopyNfsPvcMigrationStep), Namespace: namespace}, &job)).To(Succeed())
+		Expect(job.Labels).To(HaveKeyWithValue(jobs.JobLabel, jobKind))
+		Expect(job.Spec.Template.Spec.Containers[0].Image).To(Equal(utils.DataMoverImage))
+
+		finishJob(danaiov1alpha1.CopyNfsPvcMigrationStep, batchv1.JobComplete)
+		Expect(sync()).To(BeTrue())
+		Expect(stepState(danaiov1alpha1.CopyNfsPvcMigrationStep)).To(Equal(danaiov1alpha1.SucceededNfsPvcMigrationStepState))
+		Expect(stepState(danaiov1alpha1.QuiesceNfsPvcMigrationStep)).To(Equal(danaiov1alpha1.SkippedNfsPvcMigrationStepState))
+		Expect(migration.Status.Phase).To(Equal(danaiov1alpha1.SyncingNfsPvcMigrationPhase))
+
+		Expect(sync()).To(BeFalse())
+		finishJob(danaiov1alpha1.FinalSyncNfsPvcMigrationStep, batchv1.JobComplete)
+		Expect(sync()).To(BeTrue())
+		Expect(migration.Status.Phase).To(Equal(danaiov1alpha1.SwitchingNfsPvcMigrationPhase))
+
+		Expect(sync()).To(BeTrue())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(nfspvc), nfspvc)).To(Succeed())
+		Expect(nfspvc.Spec.Server).To(Equal("new-nas"))
+		Expect(nfspvc.Spec.Path).To(Equal("/new"))
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: resources.PVName(*nfspvc)}, &corev1.PersistentVolume{})).NotTo(Succeed())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(nfspvc), &corev1.PersistentVolumeClaim{})).NotTo(Succeed())
+
+		// the nfspvc controller recreates the PV and the PVC against the target
+		pv := resources.PreparePV(*nfspvc, "", string(corev1.PersistentVolumeReclaimDelete))
+		pvc := resources.PreparePVC(*nfspvc, "")
+		Expect(k8sClient.Create(ctx, &pv)).To(Succeed())
+		Expect(k8sClient.Create(ctx, &pvc)).To(Succeed())
+		pvc.Status.Phase = corev1.ClaimBound
+		Expect(k8sClient.Status().Update(ctx, &pvc)).To(Succeed())
+
+		Expect(sync()).To(BeFalse())
+		Expect(migration.Status.Phase).To(Equal(danaiov1alpha1.SucceededNfsPvcMigrationPhase))
+		Expect(stepState(danaiov1alpha1.SwitchNfsPvcMigrationStep)).To(Equal(danaiov1alpha1.SucceededNfsPvcMigrationStepState))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(nfspvc), nfspvc)).To(Succeed())
+		Expect(nfspvc.Annotations).NotTo(HaveKey(danaiov1alpha1.MigrationAnnotation))
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: JobName(migration, danaiov1alpha1.CopyNfsPvcMigrationStep), Namespace: namespace}, &batchv1.Job{})).NotTo(Succeed())
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: claimName(migration, "target"), Namespace: namespace}, &corev1.PersistentVolumeClaim{})).NotTo(Succeed())
+	})
+
+	It("should roll back when the copy fails", func() {
+		sync()
+		sync()
+		finishJob(danaiov1alpha1.CopyNfsPvcMigrationStep, batchv1.JobFailed)
+		Expect(sync()).To(BeTrue())
+		Expect(migration.Status.Phase).To(Equal(danaiov1alpha1.RollingBackNfsPvcMigrationPhase))
+		Expect(stepState(danaiov1alpha1.CopyNfsPvcMigrationStep)).To(Equal(danaiov1alpha1.FailedNfsPvcMigrationStepState))
+
+		Expect(sync()).To(BeFalse())
+		Expect(migration.Status.Phase).To(Equal(danaiov1alpha1.RolledBackNfsPvcMigrationPhase))
+		Expect(stepState(danaiov1alpha1.CopyNfsPvcMigrationStep)).To(Equal(danaiov1alpha1.FailedNfsPvcMigrationStepState))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(nfspvc), nfspvc)).To(Succeed())
+		Expect(nfspvc.Spec.Server).To(Equal("old-nas"))
+		Expect(nfspvc.Annotations).NotTo(HaveKey(danaiov1alpha1.MigrationAnnotation))
+	})
+
+	It("should wait for another migration of the nfspvc", func() {
+		nfspvc.Annotations = map[string]string{danaiov1alpha1.MigrationAnnotation: "other"}
+		Expect(k8sClient.Update(ctx, nfspvc)).To(Succeed())
+		Expect(sync()).To(BeTrue())
+		Expect(migration.Status.Phase).To(BeEmpty())
+		Expect(migration.Status.Message).To(ContainSubstring(`migration "other"`))
+	})
+
+	Context("when quiescing the workloads", func() {
+		BeforeEach(func() {
+			migration.Spec.Quiesce = true
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: namespace},
+				Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(3))},
+			}
+			replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+				Name: "app-1234", Namespace: namespace,
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "app", UID: "app-uid", Controller: ptr.To(true)}},
+			}}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "app-1234-abcd", Namespace: namespace,
+					OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "app-1234", UID: "rs-uid", Controller: ptr.To(true)}},
+				},
+				Spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+					Name:         "data",
+					VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data"}},
+				}}},
+			}
+			for _, object := range []client.Object{deployment, replicaSet, pod} {
+				Expect(k8sClient.Create(ctx, object)).To(Succeed())
+			}
+			sync()
+			sync()
+			finishJob(danaiov1alpha1.CopyNfsPvcMigrationStep, batchv1.JobComplete)
+			sync()
+			Expect(migration.Status.Phase).To(Equal(danaiov1alpha1.QuiescingNfsPvcMigrationPhase))
+		})
+
+		It("should scale the workloads down and wait for their pods", func() {
+			Expect(sync()).To(BeTrue())
+			Expect(migration.Status.QuiescedWorkloads).To(ConsistOf(danaiov1alpha1.QuiescedWorkload{Kind: "Deployment", Name: "app", Replicas: 3}))
+			deployment := appsv1.Deployment{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: "app", Namespace: namespace}, &deployment)).To(Succeed())
+			Expect(*deployment.Spec.Replicas).To(BeZero())
+			Expect(deployment.Annotations).To(HaveKeyWithValue(QuiescedReplicasAnnotation, "3"))
+			Expect(stepState(danaiov1alpha1.QuiesceNfsPvcMigrationStep)).To(Equal(danaiov1alpha1.RunningNfsPvcMigrationStepState))
+
+			Expect(k8sClient.Delete(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "app-1234-abcd", Namespace: namespace}})).To(Succeed())
+			Expect(sync()).To(BeTrue())
+			Expect(stepState(danaiov1alpha1.QuiesceNfsPvcMigrationStep)).To(Equal(danaiov1alpha1.SucceededNfsPvcMigrationStepState))
+			Expect(migration.Status.Phase).To(Equal(danaiov1alpha1.SyncingNfsPvcMigrationPhase))
+		})
+
+		It("should roll back and scale the workloads up when the pods do not stop in time", func() {
+			sync()
+			now = now.Add(2 * time.Minute)
+			Expect(sync()).To(BeTrue())
+			Expect(migration.Status.Phase).To(Equal(danaiov1alpha1.RollingBackNfsPvcMigrationPhase))
+			Expect(stepState(danaiov1alpha1.QuiesceNfsPvcMigrationStep)).To(Equal(danaiov1alpha1.FailedNfsPvcMigrationStepState))
+
+			Expect(sync()).To(BeFalse())
+			Expect(migration.Status.Phase).To(Equal(danaiov1alpha1.RolledBackNfsPvcMigrationPhase))
+			Expect(stepState(danaiov1alpha1.CopyNfsPvcMigrationStep)).To(Equal(danaiov1alpha1.RolledBackNfsPvcMigrationStepState))
+			deployment := appsv1.Deployment{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: "app", Namespace: namespace}, &deployment)).To(Succeed())
+			Expect(*deployment.Spec.Replicas).To(Equal(int32(3)))
+			Expect(deployment.Annotations).NotTo(HaveKey(QuiescedReplicasAnnotation))
+		})
+	})
+})
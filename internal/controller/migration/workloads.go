@@ -0,0 +1,143 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// QuiescedReplicasAnnotation keeps the replicas of a workload scaled down by a migration,
+	// so that they survive a lost status update.
+	QuiescedReplicasAnnotation = "nfspvc.dana.io/quiesced-replicas"
+
+	deploymentKind  = "Deployment"
+	statefulSetKind = "StatefulSet"
+)
+
+// podsUsing returns the pods of the namespace that mount the PVC and have not terminated.
+func podsUsing(ctx context.Context, k8sClient client.Client, namespace, claim string) ([]corev1.Pod, error) {
+	podList := corev1.PodList{}
+	if err := k8sClient.List(ctx, &podList, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %v", err)
+	}
+	var pods []corev1.Pod
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if slices.ContainsFunc(pod.Spec.Volumes, func(volume corev1.Volume) bool {
+			return volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == claim
+		}) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, nil
+}
+
+// owningWorkload returns the kind and the name of the Deployment or StatefulSet controlling the pod.
+func owningWorkload(ctx context.Context, k8sClient client.Client, pod corev1.Pod) (string, string, bool, error) {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return "", "", false, nil
+	}
+	switch owner.Kind {
+	case statefulSetKind:
+		return statefulSetKind, owner.Name, true, nil
+	case "ReplicaSet":
+		replicaSet := appsv1.ReplicaSet{}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: owner.Name, Namespace: pod.Namespace}, &replicaSet); err != nil {
+			return "", "", false, client.IgnoreNotFound(err)
+		}
+		if deployment := metav1.GetControllerOf(&replicaSet); deployment != nil && deployment.Kind == deploymentKind {
+			return deploymentKind, deployment.Name, true, nil
+		}
+	}
+	return "", "", false, nil
+}
+
+// quiesce scales the workloads of the pods down to zero and returns the quiesced workloads.
+func quiesce(ctx context.Context, k8sClient client.Client, namespace string, pods []corev1.Pod, quiesced []danaiov1alpha1.QuiescedWorkload) ([]danaiov1alpha1.QuiescedWorkload, error) {
+	for _, pod := range pods {
+		kind, name, ok, err := owningWorkload(ctx, k8sClient, pod)
+		if err != nil {
+			return quiesced, fmt.Errorf("failed to find the workload of pod %q: %v", pod.Name, err)
+		}
+		if !ok || slices.ContainsFunc(quiesced, func(w danaiov1alpha1.QuiescedWorkload) bool { return w.Kind == kind && w.Name == name }) {
+			continue
+		}
+		replicas, err := scaleDown(ctx, k8sClient, kind, name, namespace)
+		if err != nil {
+			return quiesced, fmt.Errorf("failed to scale down %s %q: %v", kind, name, err)
+		}
+		quiesced = append(quiesced, danaiov1alpha1.QuiescedWorkload{Kind: kind, Name: name, Replicas: replicas})
+	}
+	return quiesced, nil
+}
+
+// resume scales the quiesced workloads back to their replicas.
+func resume(ctx context.Context, k8sClient client.Client, namespace string, quiesced []danaiov1alpha1.QuiescedWorkload) error {
+	for _, workload := range quiesced {
+		object, replicas := workloadObject(workload.Kind)
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: workload.Name, Namespace: namespace}, object); err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				continue
+			}
+			return fmt.Errorf("failed to fetch %s %q: %v", workload.Kind, workload.Name, err)
+		}
+		if _, ok := object.GetAnnotations()[QuiescedReplicasAnnotation]; !ok {
+			continue
+		}
+		patch := client.MergeFrom(object.DeepCopyObject().(client.Object))
+		*replicas = ptr.To(workload.Replicas)
+		annotations := object.GetAnnotations()
+		delete(annotations, QuiescedReplicasAnnotation)
+		object.SetAnnotations(annotations)
+		if err := k8sClient.Patch(ctx, object, patch); err != nil {
+			return fmt.Errorf("failed to scale up %s %q: %v", workload.Kind, workload.Name, err)
+		}
+	}
+	return nil
+}
+
+// scaleDown scales the workload down to zero and returns its replicas before, which are kept in an annotation.
+func scaleDown(ctx context.Context, k8sClient client.Client, kind, name, namespace string) (int32, error) {
+	object, replicas := workloadObject(kind)
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, object); err != nil {
+		return 0, err
+	}
+	annotations := object.GetAnnotations()
+	if value, ok := annotations[QuiescedReplicasAnnotation]; ok {
+		previous, err := strconv.ParseInt(value, 10, 32)
+		return int32(previous), err
+	}
+	previous := ptr.Deref(*replicas, 1)
+
+	patch := client.MergeFrom(object.DeepCopyObject().(client.Object))
+	*replicas = ptr.To(int32(0))
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[QuiescedReplicasAnnotation] = strconv.Itoa(int(previous))
+	object.SetAnnotations(annotations)
+	return previous, k8sClient.Patch(ctx, object, patch)
+}
+
+// workloadObject returns an empty workload of the kind and a pointer to its replicas.
+func workloadObject(kind string) (client.Object, **int32) {
+	if kind == statefulSetKind {
+		statefulSet := &appsv1.StatefulSet{}
+		return statefulSet, &statefulSet.Spec.Replicas
+	}
+	deployment := &appsv1.Deployment{}
+	return deployment, &deployment.Spec.Replicas
+}
@@ -0,0 +1,196 @@
+package mountinstructions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Keys of the ConfigMap.
+	FstabKey           = "fstab"
+	SystemdUnitKey     = "systemd.mount"
+	SystemdUnitNameKey = "systemd-unit-name"
+	AutofsKey          = "autofs"
+	CloudInitKey       = "cloud-init.yaml"
+
+	// Reasons of the MountInstructionsReady condition.
+	RenderedReason          = "Rendered"
+	ConfigMapConflictReason = "ConfigMapConflict"
+
+	nameSuffix = "-mount"
+)
+
+// ConfigMapName returns the name of the ConfigMap of the mount instructions of the nfspvc.
+func ConfigMapName(nfspvc danaiov1alpha1.NfsPvc) string {
+	return utils.ShortenName(nfspvc.Name+nameSuffix, validation.DNS1123SubdomainMaxLength)
+}
+
+// IsRenderable returns true once the export and the NFS version of the nfspvc are known.
+func IsRenderable(nfspvc danaiov1alpha1.NfsPvc) bool {
+	return resources.ExportPath(nfspvc) != "" && resources.NfsVersion(nfspvc) != ""
+}
+
+// Ensure creates or updates the ConfigMap of the mount instructions of the nfspvc, owned by the nfspvc,
+// and returns the MountInstructionsReady condition. A ConfigMap of the same name that the nfspvc does
+// not own is left untouched and reported in the condition. The cache only holds the ConfigMaps with
+// the owner label, so a ConfigMap without it is only found when it fails the creation.
+func Ensure(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, k8sClient client.Client, scheme *runtime.Scheme) (metav1.Condition, error) {
+	desired := prepareConfigMap(nfspvc)
+	if err := controllerutil.SetControllerReference(&nfspvc, &desired, scheme); err != nil {
+		return metav1.Condition{}, err
+	}
+	conflict := mountInstructionsReadyCondition(metav1.ConditionFalse, ConfigMapConflictReason,
+		fmt.Sprintf("configmap %q already exists and is not owned by the nfspvc", desired.Name))
+	rendered := mountInstructionsReadyCondition(metav1.ConditionTrue, RenderedReason, fmt.Sprintf("rendered in configmap %q", desired.Name))
+
+	existing := corev1.ConfigMap{}
+	err := k8sClient.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, &existing)
+	if apierrors.IsNotFound(err) {
+		if err := k8sClient.Create(ctx, &desired); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return conflict, nil
+			}
+			return metav1.Condition{}, fmt.Errorf("failed to create configmap %q: %v", desired.Name, err)
+		}
+		return rendered, nil
+	}
+	if err != nil {
+		return metav1.Condition{}, fmt.Errorf("failed to fetch configmap %q: %v", desired.Name, err)
+	}
+	if !metav1.IsControlledBy(&existing, &nfspvc) {
+		return conflict, nil
+	}
+	if equality.Semantic.DeepEqual(existing.Data, desired.Data) {
+		return rendered, nil
+	}
+	existing.Data = desired.Data
+	if err := k8sClient.Update(ctx, &existing); err != nil {
+		return metav1.Condition{}, fmt.Errorf("failed to update configmap %q: %v", desired.Name, err)
+	}
+	return rendered, nil
+}
+
+// Cleanup deletes the ConfigMap of the mount instructions of the nfspvc, when the nfspvc owns it.
+func Cleanup(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, k8sClient client.Client) error {
+	existing := corev1.ConfigMap{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: ConfigMapName(nfspvc), Namespace: nfspvc.Namespace}, &existing); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(&existing, &nfspvc) {
+		return nil
+	}
+	if err := client.IgnoreNotFound(k8sClient.Delete(ctx, &existing)); err != nil {
+		return fmt.Errorf("failed to delete configmap %q: %v", existing.Name, err)
+	}
+	return nil
+}
+
+// Render returns the mount instructions of the nfspvc, by key of its ConfigMap.
+func Render(nfspvc danaiov1alpha1.NfsPvc) map[string]string {
+	instructions := nfspvc.Spec.MountInstructions
+	what := export(nfspvc)
+	options := strings.Join(append(resources.MountOptions(nfspvc), instructions.Options...), ",")
+	unitName := UnitName(instructions.MountPoint)
+
+	cloudInit, _ := yaml.Marshal(map[string]any{
+		"mounts": [][]string{{what, instructions.MountPoint, "nfs", options, "0", "0"}},
+	})
+	return map[string]string{
+		FstabKey:           fmt.Sprintf("%s %s nfs %s 0 0\n", fstabEscaper.Replace(what), fstabEscaper.Replace(instructions.MountPoint), options),
+		AutofsKey:          fmt.Sprintf("%s -fstype=nfs,%s %s\n", autofsEscaper.Replace(instructions.MountPoint), options, autofsEscaper.Replace(what)),
+		SystemdUnitNameKey: unitName,
+		SystemdUnitKey: fmt.Sprintf(`[Unit]
+Description=NFS mount of NfsPvc %s/%s
+Wants=network-online.target
+After=network-online.target
+
+[Mount]
+What=%s
+Where=%s
+Type=nfs
+Options=%s
+
+[Install]
+WantedBy=remote-fs.target
+`, nfspvc.Namespace, nfspvc.Name, what, instructions.MountPoint, options),
+		CloudInitKey: "#cloud-config\n" + string(cloudInit),
+	}
+}
+
+// fstabEscaper escapes the whitespace of the fields of an fstab.
+var fstabEscaper = strings.NewReplacer(`\`, `\134`, " ", `\040`, "\t", `\011`)
+
+// autofsEscaper escapes the whitespace and the quotes of the key and the location of an autofs map entry.
+var autofsEscaper = strings.NewReplacer(`\`, `\\`, " ", `\ `, "\t", "\\\t", `"`, `\"`)
+
+// UnitName returns the name of the systemd mount unit of the mount point, as systemd-escape --path does.
+func UnitName(mountPoint string) string {
+	var parts []string
+	for _, part := range strings.Split(mountPoint, "/") {
+		if part == "" {
+			continue
+		}
+		var escaped strings.Builder
+		for i := 0; i < len(part); i++ {
+			c := part[i]
+			if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' || c == ':' || (c == '.' && (i > 0 || len(parts) > 0)) {
+				escaped.WriteByte(c)
+				continue
+			}
+			fmt.Fprintf(&escaped, `\x%02x`, c)
+		}
+		parts = append(parts, escaped.String())
+	}
+	if len(parts) == 0 {
+		return "-.mount"
+	}
+	return strings.Join(parts, "-") + ".mount"
+}
+
+// export returns the export of the nfspvc as server:/path, with a bracketed IPv6 server.
+func export(nfspvc danaiov1alpha1.NfsPvc) string {
+	server := nfspvc.Spec.Server
+	if strings.Contains(server, ":") {
+		server = "[" + server + "]"
+	}
+	return server + ":" + resources.ExportPath(nfspvc)
+}
+
+// mountInstructionsReadyCondition returns the MountInstructionsReady condition of an nfspvc.
+func mountInstructionsReadyCondition(status metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    danaiov1alpha1.MountInstructionsReadyNfsPvcCondition,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// prepareConfigMap returns the ConfigMap of the mount instructions of the nfspvc.
+func prepareConfigMap(nfspvc danaiov1alpha1.NfsPvc) corev1.ConfigMap {
+	return corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName(nfspvc),
+			Namespace: nfspvc.Namespace,
+			Labels: map[string]string{
+				resources.NfsPvcOwnerLabel: nfspvc.Name,
+			},
+		},
+		Data: Render(nfspvc),
+	}
+}
@@ -0,0 +1,152 @@
+package mountinstructions
+
+import (
+	"context"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("Render", func() {
+	var nfspvc danaiov1alpha1.NfsPvc
+
+	BeforeEach(func() {
+		nfspvc = danaiov1alpha1.NfsPvc{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "team-a"},
+			Spec: danaiov1alpha1.NfsPvcSpec{
+				Server:            "nas",
+				Path:              "/exports/data",
+				NfsVersion:        "4.1",
+				Security:          &danaiov1alpha1.NfsSecurity{Sec: danaiov1alpha1.Krb5pNfsSecurityFlavor},
+				MountInstructions: &danaiov1alpha1.NfsPvcMountInstructions{MountPoint: "/mnt/team data", Options: []string{"_netdev"}},
+			},
+		}
+	})
+
+	It("should render the exact export and options in every format", func() {
+		instructions := Render(nfspvc)
+		Expect(instructions[FstabKey]).To(Equal("nas:/exports/data /mnt/team\\040data nfs nfsvers=4.1,sec=krb5p,_netdev 0 0\n"))
+		Expect(instructions[AutofsKey]).To(Equal("/mnt/team\\ data -fstype=nfs,nfsvers=4.1,sec=krb5p,_netdev nas:/exports/data\n"))
+		Expect(instructions[SystemdUnitNameKey]).To(Equal(`mnt-team\x20data.mount`))
+		Expect(instructions[SystemdUnitKey]).To(ContainSubstring("What=nas:/exports/data\nWhere=/mnt/team data\nType=nfs\nOptions=nfsvers=4.1,sec=krb5p,_netdev\n"))
+		Expect(instructions[CloudInitKey]).To(Equal("#cloud-config\nmounts:\n- - nas:/exports/data\n  - /mnt/team data\n  - nfs\n  - nfsvers=4.1,sec=krb5p,_netdev\n  - \"0\"\n  - \"0\"\n"))
+	})
+
+	It("should bracket an IPv6 server", func() {
+		nfspvc.Spec.Server = "fd00::1"
+		Expect(Render(nfspvc)[FstabKey]).To(HavePrefix("[fd00::1]:/exports/data "))
+	})
+
+	It("should escape the whitespace of the export", func() {
+		nfspvc.Spec.Path = "/exports/team data"
+		instructions := Render(nfspvc)
+		Expect(instructions[FstabKey]).To(HavePrefix("nas:/exports/team\\040data /mnt/team\\040data nfs "))
+		Expect(instructions[AutofsKey]).To(HaveSuffix(" nas:/exports/team\\ data\n"))
+	})
+
+	It("should escape the mount points like systemd-escape", func() {
+		Expect(UnitName("/")).To(Equal("-.mount"))
+		Expect(UnitName("/srv//my-data/")).To(Equal(`srv-my\x2ddata.mount`))
+		Expect(UnitName("/.hidden/a.b")).To(Equal(`\x2ehidden-a.b.mount`))
+	})
+})
+
+var _ = Describe("Ensure and Cleanup", func() {
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		scheme    *runtime.Scheme
+		nfspvc    danaiov1alpha1.NfsPvc
+	)
+
+	// ensure renders the mount instructions and returns the reason of the MountInstructionsReady condition.
+	ensure := func() string {
+		condition, err := Ensure(ctx, nfspvc, k8sClient, scheme)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(condition.Type).To(Equal(danaiov1alpha1.MountInstructionsReadyNfsPvcCondition))
+		return condition.Reason
+	}
+
+	getConfigMap := func() (corev1.ConfigMap, error) {
+		configMap := corev1.ConfigMap{}
+		err := k8sClient.Get(ctx, client.ObjectKey{Name: ConfigMapName(nfspvc), Namespace: nfspvc.Namespace}, &configMap)
+		return configMap, err
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(danaiov1alpha1.AddToScheme(scheme)).To(Succeed())
+		nfspvc = danaiov1alpha1.NfsPvc{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "team-a", UID: "data-uid"},
+			Spec: danaiov1alpha1.NfsPvcSpec{
+				Server:            "nas",
+				Path:              "/exports/data",
+				NfsVersion:        "3",
+				MountInstructions: &danaiov1alpha1.NfsPvcMountInstructions{MountPoint: "/mnt/data"},
+			},
+		}
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+	})
+
+	It("should keep the ConfigMap owned by the nfspvc in sync with its spec", func() {
+		Expect(ensure()).To(Equal(RenderedReason))
+		configMap, err := getConfigMap()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configMap.Name).To(Equal("data-mount"))
+		Expect(metav1.IsControlledBy(&configMap, &nfspvc)).To(BeTrue())
+		Expect(configMap.Data[FstabKey]).To(Equal("nas:/exports/data /mnt/data nfs nfsvers=3 0 0\n"))
+
+		nfspvc.Spec.NfsVersion = "4.2"
+		Expect(ensure()).To(Equal(RenderedReason))
+		configMap, err = getConfigMap()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configMap.Data[FstabKey]).To(ContainSubstring("nfsvers=4.2"))
+
+		Expect(Cleanup(ctx, nfspvc, k8sClient)).To(Succeed())
+		_, err = getConfigMap()
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should leave a ConfigMap it does not own untouched", func() {
+		foreign := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName(nfspvc), Namespace: nfspvc.Namespace}, Data: map[string]string{"app": "config"}}
+		Expect(k8sClient.Create(ctx, &foreign)).To(Succeed())
+		Expect(ensure()).To(Equal(ConfigMapConflictReason))
+		Expect(Cleanup(ctx, nfspvc, k8sClient)).To(Succeed())
+		configMap, err := getConfigMap()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configMap.Data).To(Equal(foreign.Data))
+	})
+
+	It("should report a ConfigMap missing from the cache as a conflict", func() {
+		foreign := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName(nfspvc), Namespace: nfspvc.Namespace}}
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(&foreign).WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if _, ok := obj.(*corev1.ConfigMap); ok {
+					return apierrors.NewNotFound(corev1.Resource("configmaps"), key.Name)
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		}).Build()
+		Expect(ensure()).To(Equal(ConfigMapConflictReason))
+	})
+
+	It("should wait for the export and the NFS version", func() {
+		Expect(IsRenderable(nfspvc)).To(BeTrue())
+		nfspvc.Spec.NfsVersion = danaiov1alpha1.NfsVersionAuto
+		Expect(IsRenderable(nfspvc)).To(BeFalse())
+		nfspvc.Status.NegotiatedVersion = "4.1"
+		nfspvc.Spec.Path = ""
+		Expect(IsRenderable(nfspvc)).To(BeFalse())
+	})
+})
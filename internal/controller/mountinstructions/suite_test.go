@@ -0,0 +1,14 @@
+package mountinstructions
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestMountInstructions(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "MountInstructions Suite")
+}
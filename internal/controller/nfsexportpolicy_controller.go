@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"time"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/exportpolicy"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	"github.com/dana-team/nfspvc-operator/internal/sharding"
+	"github.com/dana-team/nfspvc-operator/internal/storage"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DriftCheckIntervalMinutes is the interval between two comparisons of an export policy with the nodes,
+// so that changes made on the storage array are detected.
+const DriftCheckIntervalMinutes = 5
+
+// NfsExportPolicyReconciler reconciles a NfsExportPolicy object
+type NfsExportPolicyReconciler struct {
+	client.Client
+	Scheme  *runtime.Scheme
+	Log     logr.Logger
+	Options controller.Options
+	Scope   sharding.Scope
+	// Backend manages the export policies without hook, when it implements storage.ExportPolicyBackend.
+	Backend storage.StorageBackend
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// Every export policy is reconciled when a node joins, leaves, or changes its labels or addresses.
+func (r *NfsExportPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&danaiov1alpha1.NfsExportPolicy{}).
+		WithOptions(r.Options).
+		Watches(&corev1.Node{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueRequestsFromNode),
+			builder.WithPredicates(predicate.Funcs{UpdateFunc: nodeAddressesOrLabelsChanged}),
+		).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=nfspvc.dana.io,resources=nfsexportpolicies,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=nfspvc.dana.io,resources=nfsexportpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+
+func (r *NfsExportPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("NfsExportPolicy", req.Name)
+	logger.Info("Starting Reconcile")
+	nfsExportPolicy := danaiov1alpha1.NfsExportPolicy{}
+	if err := r.Get(ctx, req.NamespacedName, &nfsExportPolicy); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("Didn't find NfsExportPolicy")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get NfsExportPolicy: %s", err.Error())
+	}
+	if nfsExportPolicy.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+	if nfsExportPolicy.Spec.Shard != r.Scope.Shard {
+		logger.Info(fmt.Sprintf("the NfsExportPolicy is managed by shard %q", nfsExportPolicy.Spec.Shard))
+		return ctrl.Result{}, nil
+	}
+
+	backend := exportpolicy.Backend(nfsExportPolicy, r.Backend)
+	newStatus, err := exportpolicy.Sync(ctx, nfsExportPolicy, r.Client, backend, time.Now())
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to sync NfsExportPolicy: %s", err.Error())
+	}
+	if !reflect.DeepEqual(newStatus, nfsExportPolicy.Status) {
+		if err := utils.RetryOnConflictUpdate(ctx, r.Client, &nfsExportPolicy, nfsExportPolicy.Name, "", func(obj *danaiov1alpha1.NfsExportPolicy) error {
+			obj.Status = newStatus
+			return r.Status().Update(ctx, obj)
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	condition := meta.FindStatusCondition(newStatus.Conditions, danaiov1alpha1.InSyncNfsExportPolicyCondition)
+	if condition != nil && condition.Reason == exportpolicy.SyncFailedReason {
+		logger.Info(fmt.Sprintf("%s, so trying again in a few seconds", condition.Message))
+		return ctrl.Result{RequeueAfter: time.Second * RequeueIntervalSeconds}, nil
+	}
+	return ctrl.Result{RequeueAfter: time.Minute * DriftCheckIntervalMinutes}, nil
+}
+
+// enqueueRequestsFromNode reconciles every export policy when a node changes.
+func (r *NfsExportPolicyReconciler) enqueueRequestsFromNode(ctx context.Context, _ client.Object) []reconcile.Request {
+	policyList := &danaiov1alpha1.NfsExportPolicyList{}
+	if err := r.List(ctx, policyList); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(policyList.Items))
+	for _, item := range policyList.Items {
+		if item.Spec.Shard != r.Scope.Shard {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: item.Name}})
+	}
+	return requests
+}
+
+// nodeAddressesOrLabelsChanged ignores the frequent status updates of the nodes that keep their addresses and labels.
+func nodeAddressesOrLabelsChanged(e event.UpdateEvent) bool {
+	oldNode, ok := e.ObjectOld.(*corev1.Node)
+	if !ok {
+		return true
+	}
+	newNode, ok := e.ObjectNew.(*corev1.Node)
+	if !ok {
+		return true
+	}
+	return !maps.Equal(oldNode.Labels, newNode.Labels) ||
+		!reflect.DeepEqual(oldNode.Status.Addresses, newNode.Status.Addresses) ||
+		(oldNode.DeletionTimestamp == nil) != (newNode.DeletionTimestamp == nil)
+}
@@ -20,20 +20,36 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/clone"
+	"github.com/dana-team/nfspvc-operator/internal/controller/createpath"
+	"github.com/dana-team/nfspvc-operator/internal/controller/diagnostics"
 	"github.com/dana-team/nfspvc-operator/internal/controller/finalizer"
+	"github.com/dana-team/nfspvc-operator/internal/controller/mountinstructions"
+	"github.com/dana-team/nfspvc-operator/internal/controller/provision"
+	"github.com/dana-team/nfspvc-operator/internal/controller/reclaim"
 	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
 	"github.com/dana-team/nfspvc-operator/internal/controller/status"
+	"github.com/dana-team/nfspvc-operator/internal/controller/usage"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	"github.com/dana-team/nfspvc-operator/internal/nfsprobe"
+	"github.com/dana-team/nfspvc-operator/internal/sharding"
+	"github.com/dana-team/nfspvc-operator/internal/storage"
 	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -42,43 +58,99 @@ import (
 
 const RequeueIntervalSeconds = 4
 
+// VersionProber finds the NFS versions supported by a server.
+type VersionProber interface {
+	Supported(ctx context.Context, server string) ([]string, error)
+}
+
 // NfsPvcReconciler reconciles a NfsPvc object
 type NfsPvcReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	Log    logr.Logger
+	Scheme  *runtime.Scheme
+	Log     logr.Logger
+	Options controller.Options
+	Scope   sharding.Scope
+	Prober  VersionProber
+	// Recorder records the results of the Scrub and Archive reclaim policies as events.
+	Recorder record.EventRecorder
+	// Backend provisions the exports of the nfspvcs without path, none when nil.
+	Backend storage.StorageBackend
 }
 
 // SetupWithManager sets up the controller with the Manager.
+// Status writes do not change the generation, so they do not trigger another reconcile. The usage
+// reported by the usage agent is handled by the NfsPvcUsageReconciler.
 func (r *NfsPvcReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&danaiov1alpha1.NfsPvc{}).
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&danaiov1alpha1.NfsPvc{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+		))).
+		WithOptions(r.Options).
+		Owns(&batchv1.Job{}).
+		Owns(&corev1.ConfigMap{}).
 		Watches(&corev1.PersistentVolumeClaim{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueRequestsFromPersistentVolumeClaim),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		).
-		Complete(r)
+		Watches(&corev1.PersistentVolume{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueRequestsFromPersistentVolume),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		)
+	return r.Scope.WatchNamespaces(b, r.enqueueRequestsFromNamespace).Complete(r)
 }
 
-// +kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get;list;watch;create;update;delete
-// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=nfspvc.dana.io,resources=nfspvcs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=nfspvc.dana.io,resources=nfspvcs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=nfspvc.dana.io,resources=nfspvcs/finalizers,verbs=update
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *NfsPvcReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx).WithValues("NfsPvc", req.Name, "NfsPvcNamespace", req.Namespace)
 	logger.Info("Starting Reconcile")
+	if managed, err := r.Scope.ManagesNamespace(ctx, r.Client, req.Namespace); err != nil || !managed {
+		return ctrl.Result{}, err
+	}
 	nfspvc := danaiov1alpha1.NfsPvc{}
 	if err := r.Get(ctx, req.NamespacedName, &nfspvc); err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Info("Didn't find NfsPvc")
+			usage.ForgetMetrics(req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, fmt.Errorf("failed to get NfsPvc: %s", err.Error())
 	}
+	observed, err := resources.Observe(ctx, nfspvc, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
 	if nfspvc.DeletionTimestamp != nil {
-		deleted, err := resources.HandleDelete(ctx, nfspvc, r.Client)
+		if nfspvc.Spec.CreatePath != nil {
+			if err := createpath.Cleanup(ctx, nfspvc, r.Client); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to clean up the path creation of NfsPvc: %s", err.Error())
+			}
+		}
+		if nfspvc.Spec.DataSource != nil {
+			if err := clone.Cleanup(ctx, nfspvc, r.Client); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to clean up the population of NfsPvc: %s", err.Error())
+			}
+		}
+		if controllerutil.ContainsFinalizer(&nfspvc, utils.NfsPvcDeletionFinalizer) {
+			reclaimed, err := reclaim.Run(ctx, &nfspvc, observed, r.Client, r.Scheme, r.Recorder)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to reclaim the export of NfsPvc: %s", err.Error())
+			}
+			if !reclaimed {
+				logger.Info(fmt.Sprintf("the export of the NfsPvc is being reclaimed with the %s policy, so trying again in a few seconds", utils.ReclaimPolicy))
+				return ctrl.Result{RequeueAfter: time.Second * RequeueIntervalSeconds}, nil
+			}
+		}
+		deleted, err := resources.HandleDelete(ctx, nfspvc, observed, r.Client)
 		if err != nil {
 			if errors.Is(err, resources.ErrFailedCleanup) {
 				logger.Info(fmt.Sprintf("failed to handle NfsPvc deletion: %s, so trying again in a few seconds", err.Error()))
@@ -87,61 +159,204 @@ func (r *NfsPvcReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 			return ctrl.Result{}, fmt.Errorf("failed to handle NfsPvc deletion: %s", err.Error())
 		}
 		if deleted {
-			if err := finalizer.Remove(ctx, nfspvc, r.Client); err != nil {
+			usage.ForgetMetrics(nfspvc.Namespace, nfspvc.Name)
+			if err := provision.Deprovision(ctx, nfspvc, r.Backend); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to deprovision the export of NfsPvc: %s", err.Error())
+			}
+			if err := finalizer.Remove(ctx, &nfspvc, r.Client); err != nil {
 				return ctrl.Result{}, err
 			}
 			return ctrl.Result{}, nil
 		}
 	}
 
-	if err := finalizer.Ensure(ctx, nfspvc, r.Client); err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to ensure finalizer in NfsPvc: %s", err.Error())
+	if nfspvc.DeletionTimestamp == nil && nfspvc.Spec.NfsVersion == danaiov1alpha1.NfsVersionAuto && nfspvc.Status.NegotiatedVersion == "" {
+		if err := r.negotiateNfsVersion(ctx, &nfspvc); err != nil {
+			logger.Info(fmt.Sprintf("failed to negotiate the NFS version: %s, so trying again in a few seconds", err.Error()))
+			return ctrl.Result{RequeueAfter: time.Second * RequeueIntervalSeconds}, nil
+		}
+	}
+
+	if nfspvc.DeletionTimestamp == nil {
+		if err := finalizer.Ensure(ctx, &nfspvc, r.Client); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to ensure finalizer in NfsPvc: %s", err.Error())
+		}
 	}
-	if err := r.Update(ctx, nfspvc); err != nil {
+	retryProvisioning := false
+	if nfspvc.DeletionTimestamp == nil && resources.ExportPath(nfspvc) == "" {
+		if retryProvisioning, err = r.provision(ctx, &nfspvc); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to provision the export of NfsPvc: %s", err.Error())
+		}
+	}
+	hasExport := resources.ExportPath(nfspvc) != ""
+	retryPathCreation := false
+	if nfspvc.DeletionTimestamp == nil && hasExport && !resources.IsPathReady(nfspvc) {
+		if retryPathCreation, err = r.createPath(ctx, &nfspvc); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create the path of NfsPvc: %s", err.Error())
+		}
+	}
+	retryPopulation := false
+	if nfspvc.DeletionTimestamp == nil && hasExport && resources.IsPathReady(nfspvc) && !resources.IsPopulated(nfspvc) {
+		if retryPopulation, err = r.populate(ctx, &nfspvc); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to populate NfsPvc: %s", err.Error())
+		}
+	}
+	if err := r.Update(ctx, &nfspvc, observed); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to sync NfsPvc: %s", err.Error())
 	}
+	if nfspvc.DeletionTimestamp == nil {
+		if err := r.renderMountInstructions(ctx, &nfspvc); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to render the mount instructions of NfsPvc: %s", err.Error())
+		}
+	}
+	if retryProvisioning {
+		logger.Info("the export of the NfsPvc could not be provisioned, so trying again in a few seconds")
+		return ctrl.Result{RequeueAfter: time.Second * RequeueIntervalSeconds}, nil
+	}
+	if retryPathCreation {
+		logger.Info("the path of the NfsPvc could not be created, so trying again in a few seconds")
+		return ctrl.Result{RequeueAfter: time.Second * RequeueIntervalSeconds}, nil
+	}
+	if retryPopulation {
+		logger.Info("the dataSource of the NfsPvc cannot be copied yet, so trying again in a few seconds")
+		return ctrl.Result{RequeueAfter: time.Second * RequeueIntervalSeconds}, nil
+	}
 
 	return ctrl.Result{}, nil
 
 }
 
 // enqueueRequestsFromPersistentVolumeClaim reconciles the nfspvc when the associated pvc changes.
-func (r *NfsPvcReconciler) enqueueRequestsFromPersistentVolumeClaim(ctx context.Context, pvc client.Object) []reconcile.Request {
-	nfspvcList := &danaiov1alpha1.NfsPvcList{}
-	listOps := &client.ListOptions{
-		Namespace: pvc.GetNamespace(),
-	}
-	err := r.List(ctx, nfspvcList, listOps)
-	if err != nil {
+func (r *NfsPvcReconciler) enqueueRequestsFromPersistentVolumeClaim(_ context.Context, pvc client.Object) []reconcile.Request {
+	name, ok := pvc.GetLabels()[resources.NfsPvcOwnerLabel]
+	if !ok || name != pvc.GetName() {
 		return []reconcile.Request{}
 	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name, Namespace: pvc.GetNamespace()}}}
+}
 
-	requests := make([]reconcile.Request, len(nfspvcList.Items))
-	for i, item := range nfspvcList.Items {
-		if item.GetName() == pvc.GetName() {
-			requests[i] = reconcile.Request{
-				NamespacedName: types.NamespacedName{
-					Name:      item.GetName(),
-					Namespace: item.GetNamespace(),
-				},
-			}
-		}
+// enqueueRequestsFromPersistentVolume reconciles the nfspvc when the associated pv changes.
+func (r *NfsPvcReconciler) enqueueRequestsFromPersistentVolume(_ context.Context, object client.Object) []reconcile.Request {
+	pv, ok := object.(*corev1.PersistentVolume)
+	if !ok || pv.Spec.ClaimRef == nil {
+		return []reconcile.Request{}
 	}
+	name, ok := pv.Labels[resources.NfsPvcOwnerLabel]
+	if !ok || name != pv.Spec.ClaimRef.Name {
+		return []reconcile.Request{}
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name, Namespace: pv.Spec.ClaimRef.Namespace}}}
+}
 
-	if len(requests) == 0 {
+// enqueueRequestsFromNamespace reconciles the nfspvcs of a namespace when its labels change.
+func (r *NfsPvcReconciler) enqueueRequestsFromNamespace(ctx context.Context, namespace client.Object) []reconcile.Request {
+	nfspvcList := &danaiov1alpha1.NfsPvcList{}
+	if err := r.List(ctx, nfspvcList, client.InNamespace(namespace.GetName())); err != nil {
 		return []reconcile.Request{}
 	}
+
+	requests := make([]reconcile.Request, 0, len(nfspvcList.Items))
+	for _, item := range nfspvcList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: item.Name, Namespace: item.Namespace},
+		})
+	}
 	return requests
 }
 
+// negotiateNfsVersion probes the server of an "auto" nfspvc and records the highest version allowed.
+// Transport security requires NFSv4, so version 3 is never negotiated for an nfspvc that uses it.
+func (r *NfsPvcReconciler) negotiateNfsVersion(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc) error {
+	supported, err := r.Prober.Supported(ctx, nfspvc.Spec.Server)
+	if err != nil {
+		return err
+	}
+	allowed := utils.AllowedNfsVersions
+	if security := nfspvc.Spec.Security; security != nil && security.TransportSecurity != "" && security.TransportSecurity != danaiov1alpha1.NoneNfsTransportSecurity {
+		allowed = slices.DeleteFunc(slices.Clone(allowed), func(version string) bool { return version == "3" })
+	}
+	version, err := nfsprobe.Negotiate(supported, allowed)
+	if err != nil {
+		return err
+	}
+	return status.SetNegotiatedVersion(ctx, nfspvc, version, r.Client)
+}
+
+// provision creates the export of an nfspvc without path on the storage backend, and records its path
+// and the Provisioned condition. It returns true when the export could not be provisioned.
+func (r *NfsPvcReconciler) provision(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc) (bool, error) {
+	path, condition := provision.Provision(ctx, *nfspvc, r.Backend)
+	condition.ObservedGeneration = nfspvc.Generation
+	if err := status.SetProvisioned(ctx, nfspvc, path, condition, r.Client); err != nil {
+		return false, err
+	}
+	return path == "", nil
+}
+
+// createPath creates the path of the nfspvc in its parent export and records the progress in the
+// PathReady condition. It returns true when the creation failed and is retried.
+func (r *NfsPvcReconciler) createPath(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc) (bool, error) {
+	condition, err := createpath.Ensure(ctx, *nfspvc, r.Client, r.Scheme)
+	if err != nil {
+		return false, err
+	}
+	if err := status.SetCondition(ctx, nfspvc, condition, r.Client); err != nil {
+		return false, err
+	}
+	return condition.Reason == createpath.CreationFailedReason, nil
+}
+
+// populate fills the export of the nfspvc from its dataSource and records the progress in the
+// Populating condition. It returns true when the dataSource cannot be copied yet.
+func (r *NfsPvcReconciler) populate(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc) (bool, error) {
+	condition, err := clone.Populate(ctx, *nfspvc, r.Client, r.Scheme)
+	if err != nil {
+		return false, err
+	}
+	condition.ObservedGeneration = nfspvc.Generation
+	if err := status.SetCondition(ctx, nfspvc, condition, r.Client); err != nil {
+		return false, err
+	}
+	switch condition.Reason {
+	case clone.SourceNotFoundReason, clone.SourceNotBoundReason, clone.NotGrantedReason:
+		return true, nil
+	}
+	return false, nil
+}
+
+// renderMountInstructions keeps the ConfigMap of the mount instructions of the nfspvc in sync with its
+// spec and records the outcome in the MountInstructionsReady condition. The ConfigMap and the condition
+// are removed when the nfspvc no longer asks for mount instructions.
+func (r *NfsPvcReconciler) renderMountInstructions(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc) error {
+	if nfspvc.Spec.MountInstructions == nil {
+		if err := mountinstructions.Cleanup(ctx, *nfspvc, r.Client); err != nil {
+			return err
+		}
+		return status.RemoveCondition(ctx, nfspvc, danaiov1alpha1.MountInstructionsReadyNfsPvcCondition, r.Client)
+	}
+	if !mountinstructions.IsRenderable(*nfspvc) {
+		return nil
+	}
+	condition, err := mountinstructions.Ensure(ctx, *nfspvc, r.Client, r.Scheme)
+	if err != nil {
+		return err
+	}
+	condition.ObservedGeneration = nfspvc.Generation
+	return status.SetCondition(ctx, nfspvc, condition, r.Client)
+}
+
 // Update handles any update to an NFSPVC.
-func (r *NfsPvcReconciler) Update(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc) error {
+func (r *NfsPvcReconciler) Update(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc, observed resources.Observed) error {
 	if nfspvc.DeletionTimestamp == nil {
-		if err := resources.HandleStorageObjectState(ctx, nfspvc, r.Client); err != nil {
+		if err := resources.HandleStorageObjectState(ctx, *nfspvc, observed, r.Client); err != nil {
 			return err
 		}
 	}
-	if err := status.Update(ctx, nfspvc, r.Client); err != nil {
+	if err := status.Update(ctx, nfspvc, observed, r.Client); err != nil {
+		return err
+	}
+	subject := diagnostics.Subject{NfsPvc: *nfspvc, Observed: observed, StorageClass: utils.StorageClass}
+	if err := status.SetDiagnostics(ctx, nfspvc, diagnostics.Run(subject), r.Client); err != nil {
 		return err
 	}
 	return nil
@@ -0,0 +1,155 @@
+package controller_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clientgotesting "k8s.io/client-go/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const benchmarkNfsPvcs = 1000
+
+// apiCalls counts the reads and the writes sent through the client.
+type apiCalls struct {
+	reads  atomic.Int64
+	writes atomic.Int64
+}
+
+// newBenchmarkReconciler returns a reconciler backed by a fake client holding count nfspvcs.
+func newBenchmarkReconciler(b *testing.B, count int) (*controller.NfsPvcReconciler, []ctrl.Request, *apiCalls) {
+	b.Helper()
+	utils.StorageClass = "benchmark"
+	utils.ReclaimPolicy = string(corev1.PersistentVolumeReclaimRetain)
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		b.Fatal(err)
+	}
+	if err := danaiov1alpha1.AddToScheme(scheme); err != nil {
+		b.Fatal(err)
+	}
+
+	objects := make([]client.Object, 0, count)
+	requests := make([]ctrl.Request, 0, count)
+	for i := 0; i < count; i++ {
+		nfspvc := &danaiov1alpha1.NfsPvc{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("nfspvc-%d", i), Namespace: "benchmark"},
+			Spec: danaiov1alpha1.NfsPvcSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+				Capacity:    corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+				Server:      "nfs.example.com",
+				Path:        fmt.Sprintf("/exports/%d", i),
+				NfsVersion:  "4.1",
+			},
+		}
+		objects = append(objects, nfspvc)
+		requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Name: nfspvc.Name, Namespace: nfspvc.Namespace}})
+	}
+
+	calls := &apiCalls{}
+	// a plain object tracker keeps the managed fields bookkeeping of the fake client out of the measurements
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjectTracker(clientgotesting.NewObjectTracker(scheme, serializer.NewCodecFactory(scheme).UniversalDecoder())).
+		WithObjects(objects...).
+		WithStatusSubresource(&danaiov1alpha1.NfsPvc{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				calls.reads.Add(1)
+				return c.Get(ctx, key, obj, opts...)
+			},
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				calls.writes.Add(1)
+				return c.Create(ctx, obj, opts...)
+			},
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				calls.writes.Add(1)
+				return c.Update(ctx, obj, opts...)
+			},
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				calls.writes.Add(1)
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				calls.writes.Add(1)
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+			SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+				calls.writes.Add(1)
+				return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	return &controller.NfsPvcReconciler{Client: k8sClient, Scheme: scheme}, requests, calls
+}
+
+// reportAPICalls reports the api calls per reconcile.
+func reportAPICalls(b *testing.B, calls *apiCalls) {
+	b.ReportMetric(float64(calls.reads.Load())/float64(b.N), "reads/op")
+	b.ReportMetric(float64(calls.writes.Load())/float64(b.N), "writes/op")
+}
+
+// BenchmarkReconcileProvision measures the first reconcile of an nfspvc, which creates its PV and PVC.
+func BenchmarkReconcileProvision(b *testing.B) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	total := &apiCalls{}
+	b.StopTimer()
+	for done := 0; done < b.N; {
+		reconciler, requests, calls := newBenchmarkReconciler(b, min(benchmarkNfsPvcs, b.N-done))
+		b.StartTimer()
+		for _, request := range requests {
+			if _, err := reconciler.Reconcile(ctx, request); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.StopTimer()
+		done += len(requests)
+		total.reads.Add(calls.reads.Load())
+		total.writes.Add(calls.writes.Load())
+	}
+	reportAPICalls(b, total)
+}
+
+// BenchmarkReconcileSteadyState measures the reconcile of a provisioned nfspvc whose PV and PVC are up to date,
+// which is what a resync of tens of thousands of nfspvcs consists of.
+func BenchmarkReconcileSteadyState(b *testing.B) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	reconciler, requests, calls := newBenchmarkReconciler(b, benchmarkNfsPvcs)
+	for pass := 0; pass < 2; pass++ {
+		for _, request := range requests {
+			if _, err := reconciler.Reconcile(ctx, request); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	calls.reads.Store(0)
+	calls.writes.Store(0)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := reconciler.Reconcile(ctx, requests[i%len(requests)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+	reportAPICalls(b, calls)
+}
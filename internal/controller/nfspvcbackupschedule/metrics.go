@@ -0,0 +1,51 @@
+package nfspvcbackupschedule
+
+import (
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	metricLabels = []string{"namespace", "schedule", "nfspvc"}
+
+	lastSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "nfspvc_backup_last_success_timestamp_seconds",
+		Help: "Completion time of the last successful scheduled backup of an NfsPvc.",
+	}, metricLabels)
+	lastFailure = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "nfspvc_backup_last_failure_timestamp_seconds",
+		Help: "Completion time of the last failed scheduled backup of an NfsPvc.",
+	}, metricLabels)
+	backupsKept = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "nfspvc_backup_count",
+		Help: "Number of successful scheduled backups kept for an NfsPvc.",
+	}, metricLabels)
+)
+
+func init() {
+	metrics.Registry.MustRegister(lastSuccess, lastFailure, backupsKept)
+}
+
+// RecordMetrics exports the status of the targets of the schedule.
+func RecordMetrics(schedule danaiov1alpha1.NfsPvcBackupSchedule) {
+	ForgetMetrics(schedule.Namespace, schedule.Name)
+	for _, target := range schedule.Status.Targets {
+		labels := prometheus.Labels{"namespace": schedule.Namespace, "schedule": schedule.Name, "nfspvc": target.Name}
+		backupsKept.With(labels).Set(float64(target.Backups))
+		if target.LastSuccessTime != nil {
+			lastSuccess.With(labels).Set(float64(target.LastSuccessTime.Unix()))
+		}
+		if target.LastFailureTime != nil {
+			lastFailure.With(labels).Set(float64(target.LastFailureTime.Unix()))
+		}
+	}
+}
+
+// ForgetMetrics removes the metrics of the targets of the schedule.
+func ForgetMetrics(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "schedule": name}
+	lastSuccess.DeletePartialMatch(labels)
+	lastFailure.DeletePartialMatch(labels)
+	backupsKept.DeletePartialMatch(labels)
+}
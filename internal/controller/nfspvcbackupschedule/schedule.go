@@ -0,0 +1,230 @@
+package nfspvcbackupschedule
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	"github.com/robfig/cron/v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ScheduleLabel marks the NfsPvcSnapshots created by a schedule with its name.
+	ScheduleLabel = "nfspvc.dana.io/nfspvcbackupschedule"
+	// TargetLabel marks the NfsPvcSnapshots created by a schedule with the name of the NfsPvc they back up.
+	TargetLabel = "nfspvc.dana.io/nfspvcbackup-target"
+	// ScheduledAtAnnotation is the time a backup was scheduled for.
+	ScheduledAtAnnotation = "nfspvc.dana.io/scheduled-at"
+
+	// maxNameLength keeps the names of the Jobs of a backup within the 63 characters of a label value.
+	maxNameLength = 40
+)
+
+// Sync creates the backups that are due, prunes the backups beyond the retention
+// and returns the status of the schedule at the given time.
+func Sync(ctx context.Context, schedule danaiov1alpha1.NfsPvcBackupSchedule, k8sClient client.Client, now time.Time) (danaiov1alpha1.NfsPvcBackupScheduleStatus, error) {
+	status := *schedule.Status.DeepCopy()
+	sched, err := cron.ParseStandard(schedule.Spec.Schedule)
+	if err != nil {
+		status.Message = fmt.Sprintf("invalid schedule %q: %v", schedule.Spec.Schedule, err)
+		status.NextScheduleTime = nil
+		return status, nil
+	}
+	status.Message = ""
+
+	targets, err := ListTargets(ctx, schedule, k8sClient)
+	if err != nil {
+		return status, err
+	}
+	backups, err := ListBackups(ctx, schedule, k8sClient)
+	if err != nil {
+		return status, err
+	}
+
+	since := schedule.CreationTimestamp.Time
+	if status.LastScheduleTime != nil {
+		since = status.LastScheduleTime.Time
+	}
+	last, next := Runs(sched, since, now)
+	if !last.IsZero() && !schedule.Spec.Suspend {
+		for _, target := range targets {
+			backup := PrepareBackup(schedule, target.Name, last)
+			if err := k8sClient.Create(ctx, &backup); err != nil {
+				if !apierrors.IsAlreadyExists(err) {
+					return status, fmt.Errorf("failed to create backup %q: %v", backup.Name, err)
+				}
+				continue
+			}
+			backups = append(backups, backup)
+		}
+		status.LastScheduleTime = &metav1.Time{Time: last}
+	}
+	status.NextScheduleTime = nil
+	if !next.IsZero() && !schedule.Spec.Suspend {
+		status.NextScheduleTime = &metav1.Time{Time: next}
+	}
+
+	kept, err := prune(ctx, schedule, backups, k8sClient)
+	if err != nil {
+		return status, err
+	}
+	status.Targets = targetStatuses(schedule.Status.Targets, targets, kept)
+	return status, nil
+}
+
+// Runs returns the last time the schedule fired after since and up to now, which is zero
+// if it did not fire, and the next time it fires after now. Runs missed while the operator
+// was down collapse into the last one.
+func Runs(sched cron.Schedule, since, now time.Time) (time.Time, time.Time) {
+	var last time.Time
+	for run := sched.Next(since); !run.IsZero() && !run.After(now); run = sched.Next(run) {
+		last = run
+	}
+	return last, sched.Next(now)
+}
+
+// BackupName returns the name of the backup of the target scheduled at the given time.
+// The name is deterministic, so that a run creates every backup exactly once.
+func BackupName(schedule, target string, scheduled time.Time) string {
+	suffix := strconv.FormatInt(scheduled.Unix()/60, 10)
+	return utils.ShortenName(schedule+"-"+target, maxNameLength-len(suffix)-1) + "-" + suffix
+}
+
+// PrepareBackup returns the NfsPvcSnapshot backing up the target at the given time.
+// Its data is deleted with it, so that pruning frees the space on the destination.
+func PrepareBackup(schedule danaiov1alpha1.NfsPvcBackupSchedule, target string, scheduled time.Time) danaiov1alpha1.NfsPvcSnapshot {
+	return danaiov1alpha1.NfsPvcSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      BackupName(schedule.Name, target, scheduled),
+			Namespace: schedule.Namespace,
+			Labels: map[string]string{
+				ScheduleLabel: schedule.Name,
+				TargetLabel:   target,
+			},
+			Annotations: map[string]string{
+				ScheduledAtAnnotation: scheduled.UTC().Format(time.RFC3339),
+			},
+		},
+		Spec: danaiov1alpha1.NfsPvcSnapshotSpec{
+			Source:         target,
+			Destination:    schedule.Spec.Destination,
+			Method:         schedule.Spec.Method,
+			DeletionPolicy: danaiov1alpha1.DeleteNfsPvcSnapshotDeletionPolicy,
+		},
+	}
+}
+
+// ListTargets returns the NfsPvcs selected by the schedule.
+func ListTargets(ctx context.Context, schedule danaiov1alpha1.NfsPvcBackupSchedule, k8sClient client.Client) ([]danaiov1alpha1.NfsPvc, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&schedule.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector: %v", err)
+	}
+	nfspvcList := danaiov1alpha1.NfsPvcList{}
+	if err := k8sClient.List(ctx, &nfspvcList, client.InNamespace(schedule.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list nfspvcs: %v", err)
+	}
+	targets := make([]danaiov1alpha1.NfsPvc, 0, len(nfspvcList.Items))
+	for _, nfspvc := range nfspvcList.Items {
+		if nfspvc.DeletionTimestamp == nil {
+			targets = append(targets, nfspvc)
+		}
+	}
+	return targets, nil
+}
+
+// ListBackups returns the NfsPvcSnapshots created by the schedule.
+func ListBackups(ctx context.Context, schedule danaiov1alpha1.NfsPvcBackupSchedule, k8sClient client.Client) ([]danaiov1alpha1.NfsPvcSnapshot, error) {
+	snapshotList := danaiov1alpha1.NfsPvcSnapshotList{}
+	if err := k8sClient.List(ctx, &snapshotList, client.InNamespace(schedule.Namespace), client.MatchingLabels{ScheduleLabel: schedule.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list backups: %v", err)
+	}
+	return snapshotList.Items, nil
+}
+
+// prune deletes, for every target, the successful backups beyond the retention and the failed
+// backups older than a successful one or beyond the retention. It returns the backups that are kept, newest first.
+func prune(ctx context.Context, schedule danaiov1alpha1.NfsPvcBackupSchedule, backups []danaiov1alpha1.NfsPvcSnapshot, k8sClient client.Client) ([]danaiov1alpha1.NfsPvcSnapshot, error) {
+	slices.SortStableFunc(backups, func(a, b danaiov1alpha1.NfsPvcSnapshot) int {
+		return scheduledAt(b).Compare(scheduledAt(a))
+	})
+
+	succeeded, failed := map[string]int32{}, map[string]int32{}
+	kept := make([]danaiov1alpha1.NfsPvcSnapshot, 0, len(backups))
+	for _, backup := range backups {
+		if backup.DeletionTimestamp != nil {
+			continue
+		}
+		target := backup.Labels[TargetLabel]
+		remove := false
+		switch backup.Status.Phase {
+		case danaiov1alpha1.SucceededNfsPvcSnapshotPhase:
+			succeeded[target]++
+			remove = succeeded[target] > schedule.Spec.Retention
+		case danaiov1alpha1.FailedNfsPvcSnapshotPhase:
+			failed[target]++
+			remove = succeeded[target] > 0 || failed[target] > schedule.Spec.Retention
+		}
+		if !remove {
+			kept = append(kept, backup)
+			continue
+		}
+		if err := k8sClient.Delete(ctx, &backup); client.IgnoreNotFound(err) != nil {
+			return nil, fmt.Errorf("failed to delete backup %q: %v", backup.Name, err)
+		}
+	}
+	return kept, nil
+}
+
+// targetStatuses returns the status of every target from its kept backups, newest first. The times
+// of the last success and failure survive the pruning of the backups they were taken from.
+func targetStatuses(previous []danaiov1alpha1.NfsPvcBackupTargetStatus, targets []danaiov1alpha1.NfsPvc, backups []danaiov1alpha1.NfsPvcSnapshot) []danaiov1alpha1.NfsPvcBackupTargetStatus {
+	statuses := make([]danaiov1alpha1.NfsPvcBackupTargetStatus, 0, len(targets))
+	for _, target := range targets {
+		status := danaiov1alpha1.NfsPvcBackupTargetStatus{Name: target.Name}
+		if index := slices.IndexFunc(previous, func(s danaiov1alpha1.NfsPvcBackupTargetStatus) bool { return s.Name == target.Name }); index >= 0 {
+			status = *previous[index].DeepCopy()
+			status.Backups = 0
+		}
+		first := true
+		for _, backup := range backups {
+			if backup.Labels[TargetLabel] != target.Name {
+				continue
+			}
+			if first {
+				status.LastBackup = backup.Name
+				first = false
+			}
+			completion := backup.Status.CompletionTime
+			switch backup.Status.Phase {
+			case danaiov1alpha1.SucceededNfsPvcSnapshotPhase:
+				status.Backups++
+				if completion != nil && (status.LastSuccessTime == nil || status.LastSuccessTime.Before(completion)) {
+					status.LastSuccessTime = completion.DeepCopy()
+				}
+			case danaiov1alpha1.FailedNfsPvcSnapshotPhase:
+				if completion != nil && (status.LastFailureTime == nil || status.LastFailureTime.Before(completion)) {
+					status.LastFailureTime = completion.DeepCopy()
+					status.LastFailureMessage = backup.Status.Message
+				}
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// scheduledAt returns the time the backup was scheduled for.
+func scheduledAt(backup danaiov1alpha1.NfsPvcSnapshot) time.Time {
+	if scheduled, err := time.Parse(time.RFC3339, backup.Annotations[ScheduledAtAnnotation]); err == nil {
+		return scheduled
+	}
+	return backup.CreationTimestamp.Time
+}
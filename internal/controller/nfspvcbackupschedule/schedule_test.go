@@ -0,0 +1,130 @@
+package nfspvcbackupschedule
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Runs", func() {
+	daily, _ := cron.ParseStandard("0 2 * * *")
+	since := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	It("should not fire before the first run", func() {
+		last, next := Runs(daily, since, since.Add(time.Hour))
+		Expect(last.IsZero()).To(BeTrue())
+		Expect(next).To(Equal(time.Date(2025, 1, 2, 2, 0, 0, 0, time.UTC)))
+	})
+
+	It("should collapse missed runs into the last one", func() {
+		last, next := Runs(daily, since, time.Date(2025, 1, 5, 3, 0, 0, 0, time.UTC))
+		Expect(last).To(Equal(time.Date(2025, 1, 5, 2, 0, 0, 0, time.UTC)))
+		Expect(next).To(Equal(time.Date(2025, 1, 6, 2, 0, 0, 0, time.UTC)))
+	})
+})
+
+var _ = Describe("BackupName", func() {
+	scheduled := time.Date(2025, 1, 5, 2, 0, 0, 0, time.UTC)
+
+	It("should be deterministic", func() {
+		Expect(BackupName("nightly", "data", scheduled)).To(Equal(BackupName("nightly", "data", scheduled)))
+		Expect(BackupName("nightly", "data", scheduled)).NotTo(Equal(BackupName("nightly", "data", scheduled.Add(time.Hour))))
+	})
+
+	It("should shorten long names", func() {
+		name := BackupName("a-very-long-schedule-name", "a-very-long-nfspvc-name", scheduled)
+		Expect(len(name)).To(BeNumerically("<=", maxNameLength))
+		Expect(name).NotTo(Equal(BackupName("a-very-long-schedule-name", "a-very-long-nfspvc-other", scheduled)))
+	})
+})
+
+var _ = Describe("Sync", func() {
+	var (
+		k8sClient client.Client
+		schedule  danaiov1alpha1.NfsPvcBackupSchedule
+		created   = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(danaiov1alpha1.AddToScheme(scheme)).To(Succeed())
+		schedule = danaiov1alpha1.NfsPvcBackupSchedule{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "backups", CreationTimestamp: metav1.NewTime(created)},
+			Spec: danaiov1alpha1.NfsPvcBackupScheduleSpec{
+				Schedule:    "0 2 * * *",
+				Selector:    metav1.LabelSelector{MatchLabels: map[string]string{"backup": "true"}},
+				Destination: danaiov1alpha1.NfsPvcSnapshotDestination{Server: "backup", Path: "/backups"},
+				Retention:   2,
+			},
+		}
+		target := &danaiov1alpha1.NfsPvc{ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "backups", Labels: map[string]string{"backup": "true"}}}
+		other := &danaiov1alpha1.NfsPvc{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "backups"}}
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(target, other).Build()
+	})
+
+	listBackups := func() []danaiov1alpha1.NfsPvcSnapshot {
+		backups, err := ListBackups(context.Background(), schedule, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		return backups
+	}
+
+	complete := func(name string, phase danaiov1alpha1.NfsPvcSnapshotPhase, at time.Time) {
+		backup := danaiov1alpha1.NfsPvcSnapshot{}
+		Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: name, Namespace: "backups"}, &backup)).To(Succeed())
+		backup.Status.Phase = phase
+		backup.Status.CompletionTime = &metav1.Time{Time: at}
+		Expect(k8sClient.Update(context.Background(), &backup)).To(Succeed())
+	}
+
+	It("should create one backup per selected NfsPvc and run", func() {
+		now := time.Date(2025, 1, 2, 2, 30, 0, 0, time.UTC)
+		status, err := Sync(context.Background(), schedule, k8sClient, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.LastScheduleTime.Time).To(Equal(time.Date(2025, 1, 2, 2, 0, 0, 0, time.UTC)))
+		Expect(status.NextScheduleTime.Time).To(Equal(time.Date(2025, 1, 3, 2, 0, 0, 0, time.UTC)))
+
+		backups := listBackups()
+		Expect(backups).To(HaveLen(1))
+		Expect(backups[0].Spec.Source).To(Equal("data"))
+		Expect(backups[0].Spec.DeletionPolicy).To(Equal(danaiov1alpha1.DeleteNfsPvcSnapshotDeletionPolicy))
+
+		schedule.Status = status
+		_, err = Sync(context.Background(), schedule, k8sClient, now.Add(time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listBackups()).To(HaveLen(1))
+	})
+
+	It("should keep the retention of successful backups", func() {
+		for day := 2; day <= 4; day++ {
+			now := time.Date(2025, 1, day, 2, 30, 0, 0, time.UTC)
+			status, err := Sync(context.Background(), schedule, k8sClient, now)
+			Expect(err).NotTo(HaveOccurred())
+			schedule.Status = status
+			complete(status.Targets[0].LastBackup, danaiov1alpha1.SucceededNfsPvcSnapshotPhase, now)
+		}
+
+		status, err := Sync(context.Background(), schedule, k8sClient, time.Date(2025, 1, 4, 3, 0, 0, 0, time.UTC))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listBackups()).To(HaveLen(2))
+		Expect(status.Targets).To(HaveLen(1))
+		Expect(status.Targets[0].Backups).To(BeEquivalentTo(2))
+		Expect(status.Targets[0].LastSuccessTime.Time).To(BeTemporally("==", time.Date(2025, 1, 4, 2, 30, 0, 0, time.UTC)))
+	})
+
+	It("should report an invalid schedule", func() {
+		schedule.Spec.Schedule = "every day"
+		status, err := Sync(context.Background(), schedule, k8sClient, time.Now())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.HasPrefix(status.Message, "invalid schedule")).To(BeTrue())
+		Expect(listBackups()).To(BeEmpty())
+	})
+})
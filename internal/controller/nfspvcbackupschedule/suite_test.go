@@ -0,0 +1,14 @@
+package nfspvcbackupschedule
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestNfsPvcBackupSchedule(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "NfsPvcBackupSchedule Suite")
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/nfspvcbackupschedule"
+	"github.com/dana-team/nfspvc-operator/internal/sharding"
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NfsPvcBackupScheduleReconciler reconciles a NfsPvcBackupSchedule object.
+// Controllers only run in the leader, so every scheduled backup is created once.
+type NfsPvcBackupScheduleReconciler struct {
+	client.Client
+	Scheme  *runtime.Scheme
+	Log     logr.Logger
+	Options controller.Options
+	Scope   sharding.Scope
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NfsPvcBackupScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&danaiov1alpha1.NfsPvcBackupSchedule{}).
+		WithOptions(r.Options).
+		Watches(&danaiov1alpha1.NfsPvcSnapshot{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueRequestsFromNfsPvcSnapshot),
+		).
+		Watches(&danaiov1alpha1.NfsPvc{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueRequestsFromNfsPvc),
+		)
+	return r.Scope.WatchNamespaces(b, r.enqueueRequestsFromNamespace).Complete(r)
+}
+
+// +kubebuilder:rbac:groups=nfspvc.dana.io,resources=nfspvcbackupschedules,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nfspvc.dana.io,resources=nfspvcbackupschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=nfspvc.dana.io,resources=nfspvcsnapshots,verbs=get;list;watch;create;delete
+
+func (r *NfsPvcBackupScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("NfsPvcBackupSchedule", req.Name, "NfsPvcBackupScheduleNamespace", req.Namespace)
+	logger.Info("Starting Reconcile")
+	if managed, err := r.Scope.ManagesNamespace(ctx, r.Client, req.Namespace); err != nil || !managed {
+		return ctrl.Result{}, err
+	}
+	schedule := danaiov1alpha1.NfsPvcBackupSchedule{}
+	if err := r.Get(ctx, req.NamespacedName, &schedule); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("Didn't find NfsPvcBackupSchedule")
+			nfspvcbackupschedule.ForgetMetrics(req.Namespace, req.Name)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get NfsPvcBackupSchedule: %s", err.Error())
+	}
+	if schedule.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+	newStatus, err := nfspvcbackupschedule.Sync(ctx, schedule, r.Client, now)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to sync NfsPvcBackupSchedule: %s", err.Error())
+	}
+	if !reflect.DeepEqual(newStatus, schedule.Status) {
+		patch := client.MergeFrom(schedule.DeepCopy())
+		schedule.Status = newStatus
+		if err := r.Status().Patch(ctx, &schedule, patch); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update NfsPvcBackupSchedule status: %s", err.Error())
+		}
+	}
+	nfspvcbackupschedule.RecordMetrics(schedule)
+
+	if schedule.Status.NextScheduleTime == nil {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: schedule.Status.NextScheduleTime.Sub(now)}, nil
+}
+
+// enqueueRequestsFromNfsPvcSnapshot reconciles the schedule of a backup when its progress changes.
+func (r *NfsPvcBackupScheduleReconciler) enqueueRequestsFromNfsPvcSnapshot(_ context.Context, snapshot client.Object) []reconcile.Request {
+	name, ok := snapshot.GetLabels()[nfspvcbackupschedule.ScheduleLabel]
+	if !ok {
+		return []reconcile.Request{}
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name, Namespace: snapshot.GetNamespace()}}}
+}
+
+// enqueueRequestsFromNfsPvc reconciles the schedules whose selector matches a changed nfspvc.
+func (r *NfsPvcBackupScheduleReconciler) enqueueRequestsFromNfsPvc(ctx context.Context, nfspvc client.Object) []reconcile.Request {
+	scheduleList := &danaiov1alpha1.NfsPvcBackupScheduleList{}
+	if err := r.List(ctx, scheduleList, client.InNamespace(nfspvc.GetNamespace())); err != nil {
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for _, schedule := range scheduleList.Items {
+		selector, err := metav1.LabelSelectorAsSelector(&schedule.Spec.Selector)
+		if err != nil || !selector.Matches(labels.Set(nfspvc.GetLabels())) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: schedule.Name, Namespace: schedule.Namespace},
+		})
+	}
+	return requests
+}
+
+// enqueueRequestsFromNamespace reconciles the nfspvcbackupschedules of a namespace when its labels change.
+func (r *NfsPvcBackupScheduleReconciler) enqueueRequestsFromNamespace(ctx context.Context, namespace client.Object) []reconcile.Request {
+	scheduleList := &danaiov1alpha1.NfsPvcBackupScheduleList{}
+	if err := r.List(ctx, scheduleList, client.InNamespace(namespace.GetName())); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(scheduleList.Items))
+	for _, item := range scheduleList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: item.Name, Namespace: item.Namespace},
+		})
+	}
+	return requests
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/migration"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	"github.com/dana-team/nfspvc-operator/internal/sharding"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// NfsPvcMigrationReconciler reconciles a NfsPvcMigration object
+type NfsPvcMigrationReconciler struct {
+	client.Client
+	Scheme  *runtime.Scheme
+	Log     logr.Logger
+	Options controller.Options
+	Scope   sharding.Scope
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// The consumers and the PV of the migrated nfspvc are polled rather than watched.
+func (r *NfsPvcMigrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&danaiov1alpha1.NfsPvcMigration{}).
+		WithOptions(r.Options).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=nfspvc.dana.io,resources=nfspvcmigrations,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=nfspvc.dana.io,resources=nfspvcmigrations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=nfspvc.dana.io,resources=nfspvcmigrations/finalizers,verbs=update
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch
+
+func (r *NfsPvcMigrationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("NfsPvcMigration", req.Name, "NfsPvcMigrationNamespace", req.Namespace)
+	logger.Info("Starting Reconcile")
+	if managed, err := r.Scope.ManagesNamespace(ctx, r.Client, req.Namespace); err != nil || !managed {
+		return ctrl.Result{}, err
+	}
+	nfspvcMigration := danaiov1alpha1.NfsPvcMigration{}
+	if err := r.Get(ctx, req.NamespacedName, &nfspvcMigration); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("Didn't find NfsPvcMigration")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get NfsPvcMigration: %s", err.Error())
+	}
+
+	if nfspvcMigration.DeletionTimestamp != nil {
+		if !controllerutil.ContainsFinalizer(&nfspvcMigration, utils.NfsPvcMigrationDeletionFinalizer) {
+			return ctrl.Result{}, nil
+		}
+		if !migration.IsFinished(nfspvcMigration) {
+			if err := migration.Abort(ctx, nfspvcMigration, r.Client); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to abort NfsPvcMigration: %s", err.Error())
+			}
+		}
+		patch := client.MergeFromWithOptions(nfspvcMigration.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		controllerutil.RemoveFinalizer(&nfspvcMigration, utils.NfsPvcMigrationDeletionFinalizer)
+		return ctrl.Result{}, client.IgnoreNotFound(r.Patch(ctx, &nfspvcMigration, patch))
+	}
+	if migration.IsFinished(nfspvcMigration) {
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(&nfspvcMigration, utils.NfsPvcMigrationDeletionFinalizer) {
+		patch := client.MergeFromWithOptions(nfspvcMigration.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		controllerutil.AddFinalizer(&nfspvcMigration, utils.NfsPvcMigrationDeletionFinalizer)
+		if err := r.Patch(ctx, &nfspvcMigration, patch); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to ensure finalizer in NfsPvcMigration: %s", err.Error())
+		}
+	}
+
+	newStatus, requeue, err := migration.Sync(ctx, nfspvcMigration, r.Client, r.Scheme, time.Now())
+	if !reflect.DeepEqual(newStatus, nfspvcMigration.Status) {
+		// the progress made before an error is recorded too, such as the workloads already scaled down
+		patch := client.MergeFrom(nfspvcMigration.DeepCopy())
+		nfspvcMigration.Status = newStatus
+		if err := r.Status().Patch(ctx, &nfspvcMigration, patch); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update NfsPvcMigration status: %s", err.Error())
+		}
+	}
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to sync NfsPvcMigration: %s", err.Error())
+	}
+	if requeue {
+		return ctrl.Result{RequeueAfter: time.Second * RequeueIntervalSeconds}, nil
+	}
+	return ctrl.Result{}, nil
+}
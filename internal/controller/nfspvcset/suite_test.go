@@ -0,0 +1,14 @@
+package nfspvcset
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestNfsPvcSet(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "NfsPvcSet Suite")
+}
@@ -0,0 +1,225 @@
+package nfspvcset
+
+import (
+	"context"
+	"fmt"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Sync creates the NfsPvcs the set should generate, patches the mutable fields of the existing ones
+// that drifted from the template, and applies the scale down retention policy to the ones it no longer generates.
+func Sync(ctx context.Context, set danaiov1alpha1.NfsPvcSet, k8sClient client.Client, scheme *runtime.Scheme) (danaiov1alpha1.NfsPvcSetStatus, error) {
+	items, err := DesiredItems(ctx, set, k8sClient)
+	if err != nil {
+		return set.Status, err
+	}
+
+	owned, err := ListOwned(ctx, set, k8sClient)
+	if err != nil {
+		return set.Status, err
+	}
+	existing := make(map[string]danaiov1alpha1.NfsPvc, len(owned))
+	for _, nfspvc := range owned {
+		existing[nfspvc.Name] = nfspvc
+	}
+
+	desired := make(map[string]bool, len(items))
+	var outdated int32
+	for _, item := range items {
+		desired[item.NfsPvcName()] = true
+		nfspvc, err := RenderNfsPvc(set, item)
+		if err != nil {
+			return set.Status, err
+		}
+		if current, ok := existing[item.NfsPvcName()]; ok {
+			if err := patchMutableFields(ctx, current, nfspvc, k8sClient); err != nil {
+				return set.Status, fmt.Errorf("failed to patch nfspvc %q: %v", current.Name, err)
+			}
+			if isOutdated(current, nfspvc) {
+				outdated++
+			}
+			continue
+		}
+		if err := createOrAdopt(ctx, set, nfspvc, k8sClient, scheme); err != nil {
+			return set.Status, err
+		}
+	}
+
+	for _, nfspvc := range owned {
+		if desired[nfspvc.Name] {
+			continue
+		}
+		if err := release(ctx, nfspvc, whenScaled(set), k8sClient); err != nil {
+			return set.Status, err
+		}
+	}
+
+	status := computeStatus(set, items, owned)
+	status.OutdatedReplicas = outdated
+	return status, nil
+}
+
+// Release applies the deletion retention policy of the set to all of its NfsPvcs.
+func Release(ctx context.Context, set danaiov1alpha1.NfsPvcSet, k8sClient client.Client) error {
+	owned, err := ListOwned(ctx, set, k8sClient)
+	if err != nil {
+		return err
+	}
+	for _, nfspvc := range owned {
+		if err := release(ctx, nfspvc, whenDeleted(set), k8sClient); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListOwned returns the NfsPvcs generated by the set.
+func ListOwned(ctx context.Context, set danaiov1alpha1.NfsPvcSet, k8sClient client.Client) ([]danaiov1alpha1.NfsPvc, error) {
+	nfspvcList := danaiov1alpha1.NfsPvcList{}
+	if err := k8sClient.List(ctx, &nfspvcList, client.InNamespace(set.Namespace), client.MatchingLabels{SetLabel: set.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list generated nfspvcs: %v", err)
+	}
+	var owned []danaiov1alpha1.NfsPvc
+	for _, nfspvc := range nfspvcList.Items {
+		if metav1.IsControlledBy(&nfspvc, &set) {
+			owned = append(owned, nfspvc)
+		}
+	}
+	return owned, nil
+}
+
+// createOrAdopt creates the generated NfsPvc, or adopts an orphaned NfsPvc of the same name.
+func createOrAdopt(ctx context.Context, set danaiov1alpha1.NfsPvcSet, nfspvc danaiov1alpha1.NfsPvc, k8sClient client.Client, scheme *runtime.Scheme) error {
+	if err := controllerutil.SetControllerReference(&set, &nfspvc, scheme); err != nil {
+		return err
+	}
+	err := k8sClient.Create(ctx, &nfspvc)
+	if err == nil || !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	existing := danaiov1alpha1.NfsPvc{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: nfspvc.Name, Namespace: nfspvc.Namespace}, &existing); err != nil {
+		return err
+	}
+	if owner := metav1.GetControllerOf(&existing); owner != nil {
+		if owner.UID == set.UID {
+			return nil
+		}
+		return fmt.Errorf("nfspvc %q is already controlled by %s %q", existing.Name, owner.Kind, owner.Name)
+	}
+	patch := client.MergeFrom(existing.DeepCopy())
+	if existing.Labels == nil {
+		existing.Labels = map[string]string{}
+	}
+	existing.Labels[SetLabel] = nfspvc.Labels[SetLabel]
+	existing.Labels[SetItemLabel] = nfspvc.Labels[SetItemLabel]
+	if err := controllerutil.SetControllerReference(&set, &existing, scheme); err != nil {
+		return err
+	}
+	return k8sClient.Patch(ctx, &existing, patch)
+}
+
+// patchMutableFields patches the labels and the annotations of the template, and the fields of the spec
+// that may change after the creation of an NfsPvc, when they drifted from the rendered NfsPvc. The labels
+// and the annotations not set by the template are kept.
+func patchMutableFields(ctx context.Context, existing, rendered danaiov1alpha1.NfsPvc, k8sClient client.Client) error {
+	desired := existing.DeepCopy()
+	for key, value := range rendered.Labels {
+		metav1.SetMetaDataLabel(&desired.ObjectMeta, key, value)
+	}
+	for key, value := range rendered.Annotations {
+		metav1.SetMetaDataAnnotation(&desired.ObjectMeta, key, value)
+	}
+	desired.Spec.NfsVersion = rendered.Spec.NfsVersion
+	desired.Spec.CreatePath = rendered.Spec.CreatePath.DeepCopy()
+	desired.Spec.MountInstructions = rendered.Spec.MountInstructions.DeepCopy()
+	if equality.Semantic.DeepEqual(existing.Labels, desired.Labels) &&
+		equality.Semantic.DeepEqual(existing.Annotations, desired.Annotations) &&
+		equality.Semantic.DeepEqual(existing.Spec, desired.Spec) {
+		return nil
+	}
+	return k8sClient.Patch(ctx, desired, client.MergeFrom(&existing))
+}
+
+// isOutdated returns true if an immutable field of the spec of the existing NfsPvc differs from the
+// rendered NfsPvc, which the template only applies at creation. The server and the path are left out,
+// since an NfsPvcMigration or an NfsServerFailover may change them.
+func isOutdated(existing, rendered danaiov1alpha1.NfsPvc) bool {
+	return !equality.Semantic.DeepEqual(existing.Spec.AccessModes, rendered.Spec.AccessModes) ||
+		!equality.Semantic.DeepEqual(existing.Spec.Capacity, rendered.Spec.Capacity) ||
+		!equality.Semantic.DeepEqual(existing.Spec.Security, rendered.Spec.Security) ||
+		!equality.Semantic.DeepEqual(existing.Spec.DataSource, rendered.Spec.DataSource)
+}
+
+// release deletes or orphans a generated NfsPvc according to the given policy.
+func release(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, policy danaiov1alpha1.NfsPvcSetRetentionPolicyType, k8sClient client.Client) error {
+	if policy == danaiov1alpha1.DeleteNfsPvcSetRetentionPolicyType {
+		if err := k8sClient.Delete(ctx, &nfspvc); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete nfspvc %q: %v", nfspvc.Name, err)
+		}
+		return nil
+	}
+
+	patch := client.MergeFrom(nfspvc.DeepCopy())
+	delete(nfspvc.Labels, SetLabel)
+	delete(nfspvc.Labels, SetItemLabel)
+	var ownerReferences []metav1.OwnerReference
+	for _, ref := range nfspvc.OwnerReferences {
+		if ref.Controller == nil || !*ref.Controller {
+			ownerReferences = append(ownerReferences, ref)
+		}
+	}
+	nfspvc.OwnerReferences = ownerReferences
+	if err := k8sClient.Patch(ctx, &nfspvc, patch); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to orphan nfspvc %q: %v", nfspvc.Name, err)
+	}
+	return nil
+}
+
+// computeStatus returns the status of the set given the desired items and the NfsPvcs it generated.
+func computeStatus(set danaiov1alpha1.NfsPvcSet, items []Item, owned []danaiov1alpha1.NfsPvc) danaiov1alpha1.NfsPvcSetStatus {
+	desired := make(map[string]bool, len(items))
+	for _, item := range items {
+		desired[item.NfsPvcName()] = true
+	}
+	status := danaiov1alpha1.NfsPvcSetStatus{
+		ObservedGeneration: set.Generation,
+		Replicas:           int32(len(items)),
+	}
+	for _, nfspvc := range owned {
+		if !desired[nfspvc.Name] {
+			continue
+		}
+		status.CurrentReplicas++
+		if nfspvc.Status.PvcPhase == string(corev1.ClaimBound) {
+			status.ReadyReplicas++
+		}
+	}
+	return status
+}
+
+// whenScaled returns the scale down retention policy of the set.
+func whenScaled(set danaiov1alpha1.NfsPvcSet) danaiov1alpha1.NfsPvcSetRetentionPolicyType {
+	if set.Spec.RetentionPolicy == nil || set.Spec.RetentionPolicy.WhenScaled == "" {
+		return danaiov1alpha1.RetainNfsPvcSetRetentionPolicyType
+	}
+	return set.Spec.RetentionPolicy.WhenScaled
+}
+
+// whenDeleted returns the deletion retention policy of the set.
+func whenDeleted(set danaiov1alpha1.NfsPvcSet) danaiov1alpha1.NfsPvcSetRetentionPolicyType {
+	if set.Spec.RetentionPolicy == nil || set.Spec.RetentionPolicy.WhenDeleted == "" {
+		return danaiov1alpha1.RetainNfsPvcSetRetentionPolicyType
+	}
+	return set.Spec.RetentionPolicy.WhenDeleted
+}
@@ -0,0 +1,146 @@
+package nfspvcset
+
+import (
+	"context"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ = Describe("Sync", func() {
+	var (
+		ctx       context.Context
+		scheme    *runtime.Scheme
+		k8sClient client.Client
+		set       danaiov1alpha1.NfsPvcSet
+	)
+
+	sync := func() danaiov1alpha1.NfsPvcSetStatus {
+		status, err := Sync(ctx, set, k8sClient, scheme)
+		Expect(err).NotTo(HaveOccurred())
+		return status
+	}
+
+	getNfsPvc := func(name string) (danaiov1alpha1.NfsPvc, error) {
+		nfspvc := danaiov1alpha1.NfsPvc{}
+		err := k8sClient.Get(ctx, client.ObjectKey{Name: name, Namespace: set.Namespace}, &nfspvc)
+		return nfspvc, err
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(danaiov1alpha1.AddToScheme(scheme)).To(Succeed())
+		set = newSet(danaiov1alpha1.NfsPvcSetSpec{Replicas: ptr.To[int32](2)})
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+	})
+
+	It("should create the nfspvcs of the set and report them", func() {
+		Expect(sync()).To(Equal(danaiov1alpha1.NfsPvcSetStatus{ObservedGeneration: 3, Replicas: 2}))
+
+		nfspvc, err := getNfsPvc("data-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(metav1.IsControlledBy(&nfspvc, &set)).To(BeTrue())
+		Expect(nfspvc.Spec.Path).To(Equal("/exports/team-a/data-1"))
+
+		nfspvc.Status.PvcPhase = string(corev1.ClaimBound)
+		Expect(k8sClient.Update(ctx, &nfspvc)).To(Succeed())
+		Expect(sync()).To(Equal(danaiov1alpha1.NfsPvcSetStatus{ObservedGeneration: 3, Replicas: 2, CurrentReplicas: 2, ReadyReplicas: 1}))
+	})
+
+	It("should apply the template changes to the nfspvcs it generated", func() {
+		sync()
+		set.Spec.Template.Labels["tier"] = "gold"
+		set.Spec.Template.Spec.NfsVersion = "4.2"
+		set.Spec.Template.Spec.MountInstructions = &danaiov1alpha1.NfsPvcMountInstructions{MountPoint: "/mnt/data"}
+		set.Spec.Template.Spec.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("2Gi")}
+		status := sync()
+		Expect(status.OutdatedReplicas).To(BeEquivalentTo(2))
+
+		for _, name := range []string{"data-0", "data-1"} {
+			nfspvc, err := getNfsPvc(name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nfspvc.Labels).To(HaveKeyWithValue("tier", "gold"))
+			Expect(nfspvc.Labels).To(HaveKeyWithValue(SetLabel, "data"))
+			Expect(nfspvc.Spec.NfsVersion).To(Equal("4.2"))
+			Expect(nfspvc.Spec.MountInstructions.MountPoint).To(Equal("/mnt/data"))
+			Expect(nfspvc.Spec.Capacity.Storage().String()).To(Equal("1Gi"))
+		}
+
+		By("creating the new nfspvcs with the current template")
+		set.Spec.Replicas = ptr.To[int32](3)
+		status = sync()
+		Expect(status.OutdatedReplicas).To(BeEquivalentTo(2))
+		nfspvc, err := getNfsPvc("data-2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nfspvc.Spec.Capacity.Storage().String()).To(Equal("2Gi"))
+	})
+
+	It("should orphan the nfspvcs it no longer generates by default", func() {
+		sync()
+		set.Spec.Replicas = ptr.To[int32](1)
+		Expect(sync().Replicas).To(BeEquivalentTo(1))
+
+		nfspvc, err := getNfsPvc("data-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(metav1.GetControllerOf(&nfspvc)).To(BeNil())
+		Expect(nfspvc.Labels).NotTo(HaveKey(SetLabel))
+		Expect(nfspvc.Labels).To(HaveKeyWithValue("app", "web"))
+	})
+
+	It("should delete the nfspvcs it no longer generates with the Delete policy", func() {
+		set.Spec.RetentionPolicy = &danaiov1alpha1.NfsPvcSetRetentionPolicy{WhenScaled: danaiov1alpha1.DeleteNfsPvcSetRetentionPolicyType}
+		sync()
+		set.Spec.Replicas = ptr.To[int32](1)
+		sync()
+		_, err := getNfsPvc("data-1")
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		_, err = getNfsPvc("data-0")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should adopt an orphaned nfspvc of the same name", func() {
+		orphan := danaiov1alpha1.NfsPvc{ObjectMeta: metav1.ObjectMeta{Name: "data-0", Namespace: set.Namespace}}
+		Expect(k8sClient.Create(ctx, &orphan)).To(Succeed())
+		sync()
+
+		nfspvc, err := getNfsPvc("data-0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(metav1.IsControlledBy(&nfspvc, &set)).To(BeTrue())
+		Expect(nfspvc.Labels).To(HaveKeyWithValue(SetItemLabel, "0"))
+	})
+
+	It("should not take over an nfspvc controlled by another owner", func() {
+		other := newSet(danaiov1alpha1.NfsPvcSetSpec{Replicas: ptr.To[int32](1)})
+		other.Name, other.UID = "other", "other-uid"
+		nfspvc := danaiov1alpha1.NfsPvc{ObjectMeta: metav1.ObjectMeta{Name: "data-0", Namespace: set.Namespace}}
+		Expect(controllerutil.SetControllerReference(&other, &nfspvc, scheme)).To(Succeed())
+		Expect(k8sClient.Create(ctx, &nfspvc)).To(Succeed())
+
+		_, err := Sync(ctx, set, k8sClient, scheme)
+		Expect(err).To(MatchError(ContainSubstring(`already controlled by NfsPvcSet "other"`)))
+	})
+
+	It("should apply the deletion retention policy when the set is released", func() {
+		set.Spec.RetentionPolicy = &danaiov1alpha1.NfsPvcSetRetentionPolicy{WhenDeleted: danaiov1alpha1.DeleteNfsPvcSetRetentionPolicyType}
+		sync()
+		Expect(Release(ctx, set, k8sClient)).To(Succeed())
+		owned, err := ListOwned(ctx, set, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(owned).To(BeEmpty())
+		_, err = getNfsPvc("data-0")
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
@@ -0,0 +1,138 @@
+package nfspvcset
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"text/template"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SetLabel     = "nfspvc.dana.io/nfspvcset"
+	SetItemLabel = "nfspvc.dana.io/nfspvcset-item"
+)
+
+// Item is a single entry generated by an NfsPvcSet, and the data its templates are rendered with.
+type Item struct {
+	// Name is the entry in names, the pod name for a selected workload, or the NfsPvc name for replicas.
+	Name string
+	// Ordinal is the index of the item in the set, or the replica ordinal for a selected workload.
+	Ordinal int
+	// Workload is the name of the selected StatefulSet, empty for other generators.
+	Workload string
+	// SetName is the name of the NfsPvcSet.
+	SetName string
+	// Namespace is the namespace of the NfsPvcSet.
+	Namespace string
+
+	suffix string
+}
+
+// NfsPvcName returns the name of the NfsPvc generated for the item.
+func (i Item) NfsPvcName() string {
+	return i.SetName + "-" + i.suffix
+}
+
+// DesiredItems returns the items an NfsPvcSet should currently generate.
+func DesiredItems(ctx context.Context, set danaiov1alpha1.NfsPvcSet, k8sClient client.Client) ([]Item, error) {
+	var items []Item
+	switch {
+	case set.Spec.Replicas != nil:
+		for ordinal := 0; ordinal < int(*set.Spec.Replicas); ordinal++ {
+			suffix := strconv.Itoa(ordinal)
+			items = append(items, newItem(set, set.Name+"-"+suffix, ordinal, "", suffix))
+		}
+	case len(set.Spec.Names) > 0:
+		for ordinal, name := range set.Spec.Names {
+			items = append(items, newItem(set, name, ordinal, "", name))
+		}
+	case set.Spec.WorkloadSelector != nil:
+		selector, err := metav1.LabelSelectorAsSelector(set.Spec.WorkloadSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid workloadSelector: %v", err)
+		}
+		statefulSets := appsv1.StatefulSetList{}
+		if err := k8sClient.List(ctx, &statefulSets, client.InNamespace(set.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, fmt.Errorf("failed to list statefulsets: %v", err)
+		}
+		for _, sts := range statefulSets.Items {
+			replicas := int32(1)
+			if sts.Spec.Replicas != nil {
+				replicas = *sts.Spec.Replicas
+			}
+			for ordinal := 0; ordinal < int(replicas); ordinal++ {
+				podName := sts.Name + "-" + strconv.Itoa(ordinal)
+				items = append(items, newItem(set, podName, ordinal, sts.Name, podName))
+			}
+		}
+	}
+	return items, nil
+}
+
+// newItem returns an Item of the given NfsPvcSet.
+func newItem(set danaiov1alpha1.NfsPvcSet, name string, ordinal int, workload, suffix string) Item {
+	return Item{
+		Name:      name,
+		Ordinal:   ordinal,
+		Workload:  workload,
+		SetName:   set.Name,
+		Namespace: set.Namespace,
+		suffix:    suffix,
+	}
+}
+
+// RenderNfsPvc returns the NfsPvc generated by the template of the set for the given item.
+func RenderNfsPvc(set danaiov1alpha1.NfsPvcSet, item Item) (danaiov1alpha1.NfsPvc, error) {
+	spec := set.Spec.Template.Spec.NfsPvcSpec()
+	server, err := render("server", spec.Server, item)
+	if err != nil {
+		return danaiov1alpha1.NfsPvc{}, err
+	}
+	path, err := render("path", spec.Path, item)
+	if err != nil {
+		return danaiov1alpha1.NfsPvc{}, err
+	}
+	spec.Server = server
+	spec.Path = path
+
+	labels := map[string]string{}
+	for key, value := range set.Spec.Template.Labels {
+		labels[key] = value
+	}
+	labels[SetLabel] = set.Name
+	labels[SetItemLabel] = item.suffix
+
+	annotations := map[string]string{}
+	for key, value := range set.Spec.Template.Annotations {
+		annotations[key] = value
+	}
+
+	return danaiov1alpha1.NfsPvc{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        item.NfsPvcName(),
+			Namespace:   set.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: spec,
+	}, nil
+}
+
+// render executes a single template field with the data of the item.
+func render(field, text string, item Item) (string, error) {
+	tmpl, err := template.New(field).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %v", field, err)
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, item); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %v", field, err)
+	}
+	return out.String(), nil
+}
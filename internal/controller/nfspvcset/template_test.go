@@ -0,0 +1,120 @@
+package nfspvcset
+
+import (
+	"context"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newSet returns an NfsPvcSet of the team-a namespace generating the given NfsPvcs.
+func newSet(spec danaiov1alpha1.NfsPvcSetSpec) danaiov1alpha1.NfsPvcSet {
+	spec.Template = danaiov1alpha1.NfsPvcTemplateSpec{
+		Labels:      map[string]string{"app": "web"},
+		Annotations: map[string]string{"owner": "team-a"},
+		Spec: danaiov1alpha1.NfsPvcSpecTemplate{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+			Capacity:    corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			Server:      "nas",
+			Path:        "/exports/{{.Namespace}}/{{.Name}}",
+			NfsVersion:  "4.1",
+		},
+	}
+	return danaiov1alpha1.NfsPvcSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "team-a", UID: "set-uid", Generation: 3},
+		Spec:       spec,
+	}
+}
+
+var _ = Describe("DesiredItems", func() {
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+	)
+
+	itemNames := func(items []Item) []string {
+		var names []string
+		for _, item := range items {
+			names = append(names, item.NfsPvcName())
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		statefulSet := func(name string, replicas *int32, labels map[string]string) *appsv1.StatefulSet {
+			return &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a", Labels: labels},
+				Spec:       appsv1.StatefulSetSpec{Replicas: replicas},
+			}
+		}
+		k8sClient = fake.NewClientBuilder().WithObjects(
+			statefulSet("db", ptr.To[int32](2), map[string]string{"storage": "nfs"}),
+			statefulSet("cache", nil, map[string]string{"storage": "nfs"}),
+			statefulSet("web", ptr.To[int32](3), nil),
+		).Build()
+	})
+
+	It("should generate one item per replica", func() {
+		items, err := DesiredItems(ctx, newSet(danaiov1alpha1.NfsPvcSetSpec{Replicas: ptr.To[int32](2)}), k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(itemNames(items)).To(Equal([]string{"data-0", "data-1"}))
+		Expect(items[1].Name).To(Equal("data-1"))
+		Expect(items[1].Ordinal).To(Equal(1))
+	})
+
+	It("should generate one item per name", func() {
+		items, err := DesiredItems(ctx, newSet(danaiov1alpha1.NfsPvcSetSpec{Names: []string{"alpha", "beta"}}), k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(itemNames(items)).To(Equal([]string{"data-alpha", "data-beta"}))
+		Expect(items[1].Ordinal).To(Equal(1))
+	})
+
+	It("should generate one item per pod of the selected statefulsets", func() {
+		items, err := DesiredItems(ctx, newSet(danaiov1alpha1.NfsPvcSetSpec{
+			WorkloadSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"storage": "nfs"}},
+		}), k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(itemNames(items)).To(ConsistOf("data-db-0", "data-db-1", "data-cache-0"))
+		for _, item := range items {
+			Expect(item.Workload).To(BeElementOf("db", "cache"))
+		}
+	})
+})
+
+var _ = Describe("RenderNfsPvc", func() {
+	It("should render the server and the path of the template for the item", func() {
+		set := newSet(danaiov1alpha1.NfsPvcSetSpec{Names: []string{"alpha"}})
+		item := newItem(set, "alpha", 0, "", "alpha")
+		nfspvc, err := RenderNfsPvc(set, item)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nfspvc.Name).To(Equal("data-alpha"))
+		Expect(nfspvc.Namespace).To(Equal("team-a"))
+		Expect(nfspvc.Spec.Server).To(Equal("nas"))
+		Expect(nfspvc.Spec.Path).To(Equal("/exports/team-a/alpha"))
+		Expect(nfspvc.Spec.NfsVersion).To(Equal("4.1"))
+		Expect(nfspvc.Labels).To(Equal(map[string]string{"app": "web", SetLabel: "data", SetItemLabel: "alpha"}))
+		Expect(nfspvc.Annotations).To(HaveKeyWithValue("owner", "team-a"))
+		Expect(set.Spec.Template.Spec.Path).To(Equal("/exports/{{.Namespace}}/{{.Name}}"))
+	})
+
+	It("should fail on an invalid or unknown template field", func() {
+		set := newSet(danaiov1alpha1.NfsPvcSetSpec{Names: []string{"alpha"}})
+		item := newItem(set, "alpha", 0, "", "alpha")
+		set.Spec.Template.Spec.Path = "/exports/{{.Unknown}}"
+		_, err := RenderNfsPvc(set, item)
+		Expect(err).To(MatchError(ContainSubstring("failed to render path template")))
+
+		set.Spec.Template.Spec.Server = "{{.Name"
+		_, err = RenderNfsPvc(set, item)
+		Expect(err).To(MatchError(ContainSubstring("failed to parse server template")))
+	})
+})
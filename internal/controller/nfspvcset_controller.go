@@ -0,0 +1,153 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/finalizer"
+	"github.com/dana-team/nfspvc-operator/internal/controller/nfspvcset"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	"github.com/dana-team/nfspvc-operator/internal/sharding"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NfsPvcSetReconciler reconciles a NfsPvcSet object
+type NfsPvcSetReconciler struct {
+	client.Client
+	Scheme  *runtime.Scheme
+	Log     logr.Logger
+	Options controller.Options
+	Scope   sharding.Scope
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NfsPvcSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&danaiov1alpha1.NfsPvcSet{}).
+		WithOptions(r.Options).
+		Owns(&danaiov1alpha1.NfsPvc{}).
+		Watches(&appsv1.StatefulSet{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueRequestsFromStatefulSet),
+		)
+	return r.Scope.WatchNamespaces(b, r.enqueueRequestsFromNamespace).Complete(r)
+}
+
+// +kubebuilder:rbac:groups=nfspvc.dana.io,resources=nfspvcsets,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=nfspvc.dana.io,resources=nfspvcsets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=nfspvc.dana.io,resources=nfspvcsets/finalizers,verbs=update
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch
+
+func (r *NfsPvcSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("NfsPvcSet", req.Name, "NfsPvcSetNamespace", req.Namespace)
+	logger.Info("Starting Reconcile")
+	if managed, err := r.Scope.ManagesNamespace(ctx, r.Client, req.Namespace); err != nil || !managed {
+		return ctrl.Result{}, err
+	}
+	set := danaiov1alpha1.NfsPvcSet{}
+	if err := r.Get(ctx, req.NamespacedName, &set); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("Didn't find NfsPvcSet")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get NfsPvcSet: %s", err.Error())
+	}
+
+	if set.DeletionTimestamp != nil {
+		if !controllerutil.ContainsFinalizer(&set, utils.NfsPvcSetDeletionFinalizer) {
+			return ctrl.Result{}, nil
+		}
+		if err := nfspvcset.Release(ctx, set, r.Client); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to release generated NfsPvcs: %s", err.Error())
+		}
+		if err := finalizer.RemoveFrom(ctx, &set, utils.NfsPvcSetDeletionFinalizer, r.Client); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to remove finalizer from NfsPvcSet: %s", err.Error())
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if err := finalizer.AddTo(ctx, &set, utils.NfsPvcSetDeletionFinalizer, r.Client); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to ensure finalizer in NfsPvcSet: %s", err.Error())
+	}
+
+	newStatus, err := nfspvcset.Sync(ctx, set, r.Client, r.Scheme)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to sync NfsPvcSet: %s", err.Error())
+	}
+	if reflect.DeepEqual(newStatus, set.Status) {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{}, utils.RetryOnConflictUpdate(ctx, r.Client, &set, set.Name, set.Namespace, func(obj *danaiov1alpha1.NfsPvcSet) error {
+		obj.Status = newStatus
+		return r.Status().Update(ctx, obj)
+	})
+}
+
+// enqueueRequestsFromStatefulSet reconciles the nfspvcsets whose workloadSelector matches a changed statefulset.
+func (r *NfsPvcSetReconciler) enqueueRequestsFromStatefulSet(ctx context.Context, sts client.Object) []reconcile.Request {
+	setList := &danaiov1alpha1.NfsPvcSetList{}
+	if err := r.List(ctx, setList, client.InNamespace(sts.GetNamespace())); err != nil {
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for _, set := range setList.Items {
+		if set.Spec.WorkloadSelector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(set.Spec.WorkloadSelector)
+		if err != nil || !selector.Matches(labels.Set(sts.GetLabels())) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: set.Name, Namespace: set.Namespace},
+		})
+	}
+	return requests
+}
+
+// enqueueRequestsFromNamespace reconciles the nfspvcsets of a namespace when its labels change.
+func (r *NfsPvcSetReconciler) enqueueRequestsFromNamespace(ctx context.Context, namespace client.Object) []reconcile.Request {
+	setList := &danaiov1alpha1.NfsPvcSetList{}
+	if err := r.List(ctx, setList, client.InNamespace(namespace.GetName())); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(setList.Items))
+	for _, item := range setList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: item.Name, Namespace: item.Namespace},
+		})
+	}
+	return requests
+}
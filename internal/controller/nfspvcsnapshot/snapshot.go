@@ -0,0 +1,227 @@
+package nfspvcsnapshot
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/jobs"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	SnapshotLabel = "nfspvc.dana.io/nfspvcsnapshot"
+
+	copyJobKind    = "snapshot"
+	cleanupJobKind = "snapshot-cleanup"
+	namePrefix     = "nfspvcsnapshot-"
+	sourceMount    = "/source"
+	destMount      = "/destination"
+)
+
+// JobName returns the name of the Job copying the data of the snapshot.
+func JobName(snapshot danaiov1alpha1.NfsPvcSnapshot) string {
+	return namePrefix + snapshot.Name
+}
+
+// cleanupJobName returns the name of the Job removing the data of the snapshot.
+func cleanupJobName(snapshot danaiov1alpha1.NfsPvcSnapshot) string {
+	return namePrefix + snapshot.Name + "-cleanup"
+}
+
+// Directory returns the directory of the snapshot data, relative to the root of the destination.
+func Directory(snapshot danaiov1alpha1.NfsPvcSnapshot) string {
+	return path.Join(snapshot.Namespace, snapshot.Name)
+}
+
+// DestinationClaim returns the name of the PVC of the destination of the snapshot.
+func DestinationClaim(snapshot danaiov1alpha1.NfsPvcSnapshot) string {
+	if snapshot.Spec.Destination.NfsPvcName != "" {
+		return snapshot.Spec.Destination.NfsPvcName
+	}
+	return namePrefix + snapshot.Name
+}
+
+// DestinationNfsPvc returns the NfsPvc describing a backup server destination. It is never created; it only
+// feeds the PV and PVC helpers, so the destination is mounted like any other NfsPvc, with the version of the source.
+func DestinationNfsPvc(snapshot danaiov1alpha1.NfsPvcSnapshot, source danaiov1alpha1.NfsPvc) danaiov1alpha1.NfsPvc {
+	return danaiov1alpha1.NfsPvc{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DestinationClaim(snapshot),
+			Namespace: snapshot.Namespace,
+		},
+		Spec: danaiov1alpha1.NfsPvcSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+			Capacity:    source.Spec.Capacity,
+			Server:      snapshot.Spec.Destination.Server,
+			Path:        snapshot.Spec.Destination.Path,
+			NfsVersion:  resources.NfsVersion(source),
+			Security:    source.Spec.Security,
+		},
+	}
+}
+
+// Sync copies the data of the source NfsPvc into the destination with a Job and returns the status of the snapshot.
+func Sync(ctx context.Context, snapshot danaiov1alpha1.NfsPvcSnapshot, k8sClient client.Client, scheme *runtime.Scheme) (danaiov1alpha1.NfsPvcSnapshotStatus, error) {
+	status := *snapshot.Status.DeepCopy()
+
+	source := danaiov1alpha1.NfsPvc{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: snapshot.Spec.Source, Namespace: snapshot.Namespace}, &source); err != nil {
+		if apierrors.IsNotFound(err) {
+			status.Phase = danaiov1alpha1.PendingNfsPvcSnapshotPhase
+			status.Message = fmt.Sprintf("source nfspvc %q not found", snapshot.Spec.Source)
+			return status, nil
+		}
+		return status, fmt.Errorf("failed to fetch source nfspvc %q: %v", snapshot.Spec.Source, err)
+	}
+
+	location, err := ensureDestination(ctx, snapshot, source, k8sClient, scheme)
+	if err != nil {
+		return status, err
+	}
+	if location == "" {
+		status.Phase = danaiov1alpha1.PendingNfsPvcSnapshotPhase
+		status.Message = fmt.Sprintf("destination nfspvc %q not found", snapshot.Spec.Destination.NfsPvcName)
+		return status, nil
+	}
+	status.Location = location
+
+	job := jobs.PrepareJob(JobName(snapshot), snapshot.Namespace, copyJobKind, utils.DataMoverImage,
+		jobs.CopyScript(string(snapshot.Spec.Method), sourceMount, path.Join(destMount, Directory(snapshot)), false),
+		jobs.ClaimVolume("source", sourceMount, source.Name, true),
+		jobs.ClaimVolume("destination", destMount, DestinationClaim(snapshot), false),
+	)
+	job.Labels[SnapshotLabel] = snapshot.Name
+	if err := controllerutil.SetControllerReference(&snapshot, &job, scheme); err != nil {
+		return status, err
+	}
+	job, err = jobs.Ensure(ctx, k8sClient, job)
+	if err != nil {
+		return status, err
+	}
+	state, err := jobs.Observe(ctx, k8sClient, job)
+	if err != nil {
+		return status, err
+	}
+
+	status.JobName = job.Name
+	status.StartTime = state.StartTime
+	status.CompletionTime = state.CompletionTime
+	status.Message = state.Message
+	switch state.Phase {
+	case jobs.Running:
+		status.Phase = danaiov1alpha1.RunningNfsPvcSnapshotPhase
+	case jobs.Failed:
+		status.Phase = danaiov1alpha1.FailedNfsPvcSnapshotPhase
+	case jobs.Succeeded:
+		status.Phase = danaiov1alpha1.SucceededNfsPvcSnapshotPhase
+		if size, err := strconv.ParseInt(state.Results[jobs.SizeResult], 10, 64); err == nil {
+			status.Size = resource.NewQuantity(size, resource.BinarySI)
+		}
+		if files, err := strconv.ParseInt(state.Results[jobs.FilesResult], 10, 64); err == nil {
+			status.Files = files
+		}
+	}
+	return status, nil
+}
+
+// ensureDestination ensures the PVC of the destination exists and returns the NFS location of the snapshot data.
+// It returns an empty location when the destination NfsPvc does not exist.
+func ensureDestination(ctx context.Context, snapshot danaiov1alpha1.NfsPvcSnapshot, source danaiov1alpha1.NfsPvc, k8sClient client.Client, scheme *runtime.Scheme) (string, error) {
+	if name := snapshot.Spec.Destination.NfsPvcName; name != "" {
+		destination := danaiov1alpha1.NfsPvc{}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: snapshot.Namespace}, &destination); err != nil {
+			return "", client.IgnoreNotFound(err)
+		}
+		return location(destination.Spec.Server, resources.ExportPath(destination), Directory(snapshot)), nil
+	}
+
+	destination := DestinationNfsPvc(snapshot, source)
+	pv := resources.PreparePV(destination, utils.StorageClass, string(corev1.PersistentVolumeReclaimRetain))
+	pv.Labels[SnapshotLabel] = snapshot.Name
+	if err := k8sClient.Create(ctx, &pv); err != nil && !apierrors.IsAlreadyExists(err) {
+		return "", fmt.Errorf("failed to create pv %q: %v", pv.Name, err)
+	}
+	pvc := resources.PreparePVC(destination, utils.StorageClass)
+	pvc.Labels[SnapshotLabel] = snapshot.Name
+	if err := controllerutil.SetControllerReference(&snapshot, &pvc, scheme); err != nil {
+		return "", err
+	}
+	if err := k8sClient.Create(ctx, &pvc); err != nil && !apierrors.IsAlreadyExists(err) {
+		return "", fmt.Errorf("failed to create pvc %q: %v", pvc.Name, err)
+	}
+	return location(destination.Spec.Server, resources.ExportPath(destination), Directory(snapshot)), nil
+}
+
+// Release removes the data of the snapshot when its deletion policy is Delete, then deletes its Jobs and the
+// PV and PVC of a backup server destination. It returns false while the data is being removed.
+func Release(ctx context.Context, snapshot danaiov1alpha1.NfsPvcSnapshot, k8sClient client.Client, scheme *runtime.Scheme) (bool, error) {
+	if snapshot.Spec.DeletionPolicy == danaiov1alpha1.DeleteNfsPvcSnapshotDeletionPolicy && snapshot.Status.JobName != "" {
+		done, err := removeData(ctx, snapshot, k8sClient, scheme)
+		if err != nil || !done {
+			return false, err
+		}
+	}
+
+	if err := jobs.Delete(ctx, k8sClient, JobName(snapshot), snapshot.Namespace); err != nil {
+		return false, fmt.Errorf("failed to delete job %q: %v", JobName(snapshot), err)
+	}
+	if err := jobs.Delete(ctx, k8sClient, cleanupJobName(snapshot), snapshot.Namespace); err != nil {
+		return false, fmt.Errorf("failed to delete job %q: %v", cleanupJobName(snapshot), err)
+	}
+	if snapshot.Spec.Destination.NfsPvcName != "" {
+		return true, nil
+	}
+
+	destination := danaiov1alpha1.NfsPvc{ObjectMeta: metav1.ObjectMeta{Name: DestinationClaim(snapshot), Namespace: snapshot.Namespace}}
+	pvc := corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: destination.Name, Namespace: destination.Namespace}}
+	if err := k8sClient.Delete(ctx, &pvc); client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to delete pvc %q: %v", pvc.Name, err)
+	}
+	pv := corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: resources.PVName(destination)}}
+	if err := k8sClient.Delete(ctx, &pv); client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to delete pv %q: %v", pv.Name, err)
+	}
+	return true, nil
+}
+
+// removeData runs a Job removing the data of the snapshot from the destination and returns true once it completed.
+func removeData(ctx context.Context, snapshot danaiov1alpha1.NfsPvcSnapshot, k8sClient client.Client, scheme *runtime.Scheme) (bool, error) {
+	job := jobs.PrepareJob(cleanupJobName(snapshot), snapshot.Namespace, cleanupJobKind, utils.DataMoverImage,
+		jobs.RemoveScript(path.Join(destMount, Directory(snapshot))),
+		jobs.ClaimVolume("destination", destMount, DestinationClaim(snapshot), false),
+	)
+	job.Labels[SnapshotLabel] = snapshot.Name
+	if err := controllerutil.SetControllerReference(&snapshot, &job, scheme); err != nil {
+		return false, err
+	}
+	job, err := jobs.Ensure(ctx, k8sClient, job)
+	if err != nil {
+		return false, err
+	}
+	state, err := jobs.Observe(ctx, k8sClient, job)
+	if err != nil {
+		return false, err
+	}
+	if state.Phase == jobs.Failed {
+		log.FromContext(ctx).Info(fmt.Sprintf("failed to remove the data of the snapshot from %s: %s", snapshot.Status.Location, state.Message))
+	}
+	return state.Phase != jobs.Running, nil
+}
+
+// location returns the NFS location of the directory in the export.
+func location(server, exportPath, directory string) string {
+	return server + ":" + path.Join(exportPath, directory)
+}
@@ -0,0 +1,177 @@
+package nfspvcsnapshot
+
+import (
+	"context"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/jobs"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Sync and Release", func() {
+	const namespace = "team-a"
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		scheme    *runtime.Scheme
+		snapshot  danaiov1alpha1.NfsPvcSnapshot
+	)
+
+	sync := func() danaiov1alpha1.NfsPvcSnapshotStatus {
+		status, err := Sync(ctx, snapshot, k8sClient, scheme)
+		Expect(err).NotTo(HaveOccurred())
+		snapshot.Status = status
+		return status
+	}
+
+	getJob := func(name string) (batchv1.Job, error) {
+		job := batchv1.Job{}
+		err := k8sClient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &job)
+		return job, err
+	}
+
+	// complete marks the Job as complete, with a succeeded pod reporting the results in its termination message.
+	complete := func(name, message string) {
+		job, err := getJob(name)
+		Expect(err).NotTo(HaveOccurred())
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		Expect(k8sClient.Status().Update(ctx, &job)).To(Succeed())
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-pod", Namespace: namespace, Labels: map[string]string{batchv1.JobNameLabel: name}},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodSucceeded,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "data-mover",
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: message}},
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, &pod)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(danaiov1alpha1.AddToScheme(scheme)).To(Succeed())
+		source := &danaiov1alpha1.NfsPvc{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: namespace},
+			Spec:       danaiov1alpha1.NfsPvcSpec{Server: "nas", Path: "/exports/data", NfsVersion: "4.1"},
+		}
+		snapshot = danaiov1alpha1.NfsPvcSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: namespace, UID: "nightly-uid"},
+			Spec: danaiov1alpha1.NfsPvcSnapshotSpec{
+				Source:      "data",
+				Destination: danaiov1alpha1.NfsPvcSnapshotDestination{Server: "backup", Path: "/backups"},
+				Method:      danaiov1alpha1.RsyncNfsPvcSnapshotMethod,
+			},
+		}
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(source).Build()
+	})
+
+	It("should wait for the source", func() {
+		snapshot.Spec.Source = "missing"
+		Expect(sync().Phase).To(Equal(danaiov1alpha1.PendingNfsPvcSnapshotPhase))
+		_, err := getJob(JobName(snapshot))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should copy the source to a backup server and report the results", func() {
+		status := sync()
+		Expect(status.Phase).To(Equal(danaiov1alpha1.RunningNfsPvcSnapshotPhase))
+		Expect(status.Location).To(Equal("backup:/backups/team-a/nightly"))
+		Expect(status.JobName).To(Equal("nfspvcsnapshot-nightly"))
+
+		destination := DestinationNfsPvc(snapshot, danaiov1alpha1.NfsPvc{})
+		pv := corev1.PersistentVolume{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: resources.PVName(destination)}, &pv)).To(Succeed())
+		Expect(pv.Spec.NFS.Server).To(Equal("backup"))
+		Expect(pv.Labels).To(HaveKeyWithValue(SnapshotLabel, "nightly"))
+		pvc := corev1.PersistentVolumeClaim{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: DestinationClaim(snapshot), Namespace: namespace}, &pvc)).To(Succeed())
+		Expect(metav1.IsControlledBy(&pvc, &snapshot)).To(BeTrue())
+
+		job, err := getJob(JobName(snapshot))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(metav1.IsControlledBy(&job, &snapshot)).To(BeTrue())
+		Expect(job.Labels).To(HaveKeyWithValue(jobs.JobLabel, copyJobKind))
+		Expect(job.Labels).To(HaveKeyWithValue(SnapshotLabel, "nightly"))
+		Expect(job.Spec.Template.Spec.Containers[0].Image).To(Equal(utils.DataMoverImage))
+
+		complete(JobName(snapshot), "size=2048\nfiles=3\n")
+		status = sync()
+		Expect(status.Phase).To(Equal(danaiov1alpha1.SucceededNfsPvcSnapshotPhase))
+		Expect(status.Size.Value()).To(Equal(int64(2048)))
+		Expect(status.Files).To(Equal(int64(3)))
+	})
+
+	It("should copy the source into an NfsPvc of the namespace", func() {
+		snapshot.Spec.Destination = danaiov1alpha1.NfsPvcSnapshotDestination{NfsPvcName: "archive"}
+		Expect(sync().Phase).To(Equal(danaiov1alpha1.PendingNfsPvcSnapshotPhase))
+
+		archive := danaiov1alpha1.NfsPvc{
+			ObjectMeta: metav1.ObjectMeta{Name: "archive", Namespace: namespace},
+			Spec:       danaiov1alpha1.NfsPvcSpec{Server: "nas-b", Path: "/exports/archive", NfsVersion: "4.1"},
+		}
+		Expect(k8sClient.Create(ctx, &archive)).To(Succeed())
+		status := sync()
+		Expect(status.Phase).To(Equal(danaiov1alpha1.RunningNfsPvcSnapshotPhase))
+		Expect(status.Location).To(Equal("nas-b:/exports/archive/team-a/nightly"))
+		job, err := getJob(JobName(snapshot))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(job.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("VolumeSource.PersistentVolumeClaim.ClaimName", "archive")))
+	})
+
+	It("should report a failed copy", func() {
+		sync()
+		job, err := getJob(JobName(snapshot))
+		Expect(err).NotTo(HaveOccurred())
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}}
+		Expect(k8sClient.Status().Update(ctx, &job)).To(Succeed())
+		status := sync()
+		Expect(status.Phase).To(Equal(danaiov1alpha1.FailedNfsPvcSnapshotPhase))
+		Expect(status.Message).To(Equal("BackoffLimitExceeded"))
+	})
+
+	It("should remove the data before releasing a snapshot with the Delete deletion policy", func() {
+		snapshot.Spec.DeletionPolicy = danaiov1alpha1.DeleteNfsPvcSnapshotDeletionPolicy
+		sync()
+
+		released, err := Release(ctx, snapshot, k8sClient, scheme)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(released).To(BeFalse())
+		cleanup, err := getJob(cleanupJobName(snapshot))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cleanup.Labels).To(HaveKeyWithValue(jobs.JobLabel, cleanupJobKind))
+
+		complete(cleanupJobName(snapshot), "")
+		released, err = Release(ctx, snapshot, k8sClient, scheme)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(released).To(BeTrue())
+		for _, name := range []string{JobName(snapshot), cleanupJobName(snapshot)} {
+			_, err := getJob(name)
+			Expect(err).To(HaveOccurred())
+		}
+		pvc := corev1.PersistentVolumeClaim{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: DestinationClaim(snapshot), Namespace: namespace}, &pvc)).NotTo(Succeed())
+	})
+
+	It("should keep the data of a snapshot with the Retain deletion policy", func() {
+		sync()
+		released, err := Release(ctx, snapshot, k8sClient, scheme)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(released).To(BeTrue())
+		_, err = getJob(cleanupJobName(snapshot))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,14 @@
+package nfspvcsnapshot
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestNfsPvcSnapshot(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "NfsPvcSnapshot Suite")
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/nfspvcsnapshot"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	"github.com/dana-team/nfspvc-operator/internal/sharding"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// NfsPvcSnapshotReconciler reconciles a NfsPvcSnapshot object
+type NfsPvcSnapshotReconciler struct {
+	client.Client
+	Scheme  *runtime.Scheme
+	Log     logr.Logger
+	Options controller.Options
+	Scope   sharding.Scope
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NfsPvcSnapshotReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&danaiov1alpha1.NfsPvcSnapshot{}).
+		WithOptions(r.Options).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=nfspvc.dana.io,resources=nfspvcsnapshots,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=nfspvc.dana.io,resources=nfspvcsnapshots/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=nfspvc.dana.io,resources=nfspvcsnapshots/finalizers,verbs=update
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+
+func (r *NfsPvcSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("NfsPvcSnapshot", req.Name, "NfsPvcSnapshotNamespace", req.Namespace)
+	logger.Info("Starting Reconcile")
+	if managed, err := r.Scope.ManagesNamespace(ctx, r.Client, req.Namespace); err != nil || !managed {
+		return ctrl.Result{}, err
+	}
+	snapshot := danaiov1alpha1.NfsPvcSnapshot{}
+	if err := r.Get(ctx, req.NamespacedName, &snapshot); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("Didn't find NfsPvcSnapshot")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get NfsPvcSnapshot: %s", err.Error())
+	}
+
+	if snapshot.DeletionTimestamp != nil {
+		if !controllerutil.ContainsFinalizer(&snapshot, utils.NfsPvcSnapshotDeletionFinalizer) {
+			return ctrl.Result{}, nil
+		}
+		released, err := nfspvcsnapshot.Release(ctx, snapshot, r.Client, r.Scheme)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to release NfsPvcSnapshot: %s", err.Error())
+		}
+		if !released {
+			logger.Info("the data of the NfsPvcSnapshot is being removed, so trying again in a few seconds")
+			return ctrl.Result{RequeueAfter: time.Second * RequeueIntervalSeconds}, nil
+		}
+		patch := client.MergeFromWithOptions(snapshot.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		controllerutil.RemoveFinalizer(&snapshot, utils.NfsPvcSnapshotDeletionFinalizer)
+		return ctrl.Result{}, client.IgnoreNotFound(r.Patch(ctx, &snapshot, patch))
+	}
+
+	if !controllerutil.ContainsFinalizer(&snapshot, utils.NfsPvcSnapshotDeletionFinalizer) {
+		patch := client.MergeFromWithOptions(snapshot.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		controllerutil.AddFinalizer(&snapshot, utils.NfsPvcSnapshotDeletionFinalizer)
+		if err := r.Patch(ctx, &snapshot, patch); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to ensure finalizer in NfsPvcSnapshot: %s", err.Error())
+		}
+	}
+
+	if isSnapshotComplete(snapshot.Status) {
+		return r.expire(ctx, snapshot)
+	}
+
+	newStatus, err := nfspvcsnapshot.Sync(ctx, snapshot, r.Client, r.Scheme)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to sync NfsPvcSnapshot: %s", err.Error())
+	}
+	if !reflect.DeepEqual(newStatus, snapshot.Status) {
+		patch := client.MergeFrom(snapshot.DeepCopy())
+		snapshot.Status = newStatus
+		if err := r.Status().Patch(ctx, &snapshot, patch); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update NfsPvcSnapshot status: %s", err.Error())
+		}
+	}
+
+	switch {
+	case isSnapshotComplete(snapshot.Status):
+		return r.expire(ctx, snapshot)
+	case snapshot.Status.Phase == danaiov1alpha1.PendingNfsPvcSnapshotPhase:
+		return ctrl.Result{RequeueAfter: time.Second * RequeueIntervalSeconds}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// expire deletes a completed snapshot once its retention is over, and requeues it until then.
+func (r *NfsPvcSnapshotReconciler) expire(ctx context.Context, snapshot danaiov1alpha1.NfsPvcSnapshot) (ctrl.Result, error) {
+	if snapshot.Spec.ExpireAfter == nil || snapshot.Status.CompletionTime == nil {
+		return ctrl.Result{}, nil
+	}
+	remaining := time.Until(snapshot.Status.CompletionTime.Add(snapshot.Spec.ExpireAfter.Duration))
+	if remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+	log.FromContext(ctx).Info("NfsPvcSnapshot expired, deleting it")
+	return ctrl.Result{}, client.IgnoreNotFound(r.Delete(ctx, &snapshot))
+}
+
+// isSnapshotComplete returns true if the copy of the snapshot succeeded or failed.
+func isSnapshotComplete(status danaiov1alpha1.NfsPvcSnapshotStatus) bool {
+	return status.Phase == danaiov1alpha1.SucceededNfsPvcSnapshotPhase || status.Phase == danaiov1alpha1.FailedNfsPvcSnapshotPhase
+}
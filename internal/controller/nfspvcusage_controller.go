@@ -0,0 +1,110 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/status"
+	"github.com/dana-team/nfspvc-operator/internal/controller/usage"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	"github.com/dana-team/nfspvc-operator/internal/sharding"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// NfsPvcUsageReconciler reports the usage of the NfsPvcs, as measured by the usage agent, as metrics
+// and as the NearlyFull condition. It runs apart from the NfsPvcReconciler, so that the frequent
+// usage updates do not trigger a full reconcile of the NfsPvcs.
+type NfsPvcUsageReconciler struct {
+	client.Client
+	Scheme  *runtime.Scheme
+	Log     logr.Logger
+	Options controller.Options
+	Scope   sharding.Scope
+	// Recorder records the NfsPvcs getting nearly full as events.
+	Recorder record.EventRecorder
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// Only the updates of the usage of an NfsPvc are reconciled.
+func (r *NfsPvcUsageReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("nfspvcusage").
+		For(&danaiov1alpha1.NfsPvc{}, builder.WithPredicates(usageChangedPredicate)).
+		WithOptions(r.Options).
+		Complete(r)
+}
+
+func (r *NfsPvcUsageReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("NfsPvc", req.Name)
+	logger.Info("Starting Reconcile")
+	if managed, err := r.Scope.ManagesNamespace(ctx, r.Client, req.Namespace); err != nil || !managed {
+		return ctrl.Result{}, err
+	}
+	nfspvc := danaiov1alpha1.NfsPvc{}
+	if err := r.Get(ctx, req.NamespacedName, &nfspvc); err != nil {
+		if apierrors.IsNotFound(err) {
+			usage.ForgetMetrics(req.Namespace, req.Name)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get NfsPvc: %s", err.Error())
+	}
+	if nfspvc.DeletionTimestamp != nil || nfspvc.Status.Usage == nil {
+		return ctrl.Result{}, nil
+	}
+
+	usage.RecordMetrics(nfspvc)
+	condition := usage.Condition(nfspvc, utils.UsageWarningThreshold)
+	condition.ObservedGeneration = nfspvc.Generation
+	wasNearlyFull := meta.IsStatusConditionTrue(nfspvc.Status.Conditions, danaiov1alpha1.NearlyFullNfsPvcCondition)
+	if err := status.SetCondition(ctx, &nfspvc, condition, r.Client); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to report the usage of NfsPvc: %s", err.Error())
+	}
+	if condition.Status == metav1.ConditionTrue && !wasNearlyFull && r.Recorder != nil {
+		r.Recorder.Event(&nfspvc, corev1.EventTypeWarning, danaiov1alpha1.NearlyFullNfsPvcCondition, condition.Message)
+	}
+	return ctrl.Result{}, nil
+}
+
+// usageChangedPredicate passes the creation of the nfspvcs and the updates of the space they use,
+// as reported by the usage agent.
+var usageChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldNfsPvc, okOld := e.ObjectOld.(*danaiov1alpha1.NfsPvc)
+		newNfsPvc, okNew := e.ObjectNew.(*danaiov1alpha1.NfsPvc)
+		if !okOld || !okNew || newNfsPvc.Status.Usage == nil {
+			return false
+		}
+		oldUsage, newUsage := oldNfsPvc.Status.Usage, newNfsPvc.Status.Usage
+		return oldUsage == nil || oldUsage.UsedBytes != newUsage.UsedBytes || oldUsage.AvailableBytes != newUsage.AvailableBytes
+	},
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/failover"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	"github.com/dana-team/nfspvc-operator/internal/sharding"
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// NfsServerFailoverReconciler reconciles a NfsServerFailover object
+type NfsServerFailoverReconciler struct {
+	client.Client
+	Scheme  *runtime.Scheme
+	Log     logr.Logger
+	Options controller.Options
+	Scope   sharding.Scope
+	// Reader lists the NfsPvcs and the namespaces of the whole cluster. It defaults to the client,
+	// whose cache only holds the namespaces of the scope.
+	Reader client.Reader
+	// Recorder records the removal of the pv-protection finalizers of the moved PVs as events.
+	Recorder record.EventRecorder
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// The PVs of the moved NfsPvcs are polled until the failover completes.
+func (r *NfsServerFailoverReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&danaiov1alpha1.NfsServerFailover{}).
+		WithOptions(r.Options).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=nfspvc.dana.io,resources=nfsserverfailovers,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=nfspvc.dana.io,resources=nfsserverfailovers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *NfsServerFailoverReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("NfsServerFailover", req.Name)
+	logger.Info("Starting Reconcile")
+	nfsServerFailover := danaiov1alpha1.NfsServerFailover{}
+	if err := r.Get(ctx, req.NamespacedName, &nfsServerFailover); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("Didn't find NfsServerFailover")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get NfsServerFailover: %s", err.Error())
+	}
+	if nfsServerFailover.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	reader := r.Reader
+	if reader == nil {
+		reader = r.Client
+	}
+	newStatus, err := failover.Sync(ctx, nfsServerFailover, r.Client, reader, r.Scope, r.Recorder, time.Now())
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to sync NfsServerFailover: %s", err.Error())
+	}
+	if !reflect.DeepEqual(newStatus, nfsServerFailover.Status) {
+		if err := utils.RetryOnConflictUpdate(ctx, r.Client, &nfsServerFailover, nfsServerFailover.Name, "", func(obj *danaiov1alpha1.NfsServerFailover) error {
+			obj.Status = newStatus
+			return r.Status().Update(ctx, obj)
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if newStatus.Phase != danaiov1alpha1.CompletedNfsServerFailoverPhase {
+		return ctrl.Result{RequeueAfter: time.Second * RequeueIntervalSeconds}, nil
+	}
+	return ctrl.Result{}, nil
+}
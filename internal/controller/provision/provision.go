@@ -0,0 +1,69 @@
+package provision
+
+import (
+	"context"
+	"fmt"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	"github.com/dana-team/nfspvc-operator/internal/storage"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Reasons of the Provisioned condition.
+	ProvisionedReason        = "Provisioned"
+	NoStorageBackendReason   = "NoStorageBackend"
+	ProvisioningFailedReason = "ProvisioningFailed"
+
+	// maxExportNameLength is the maximum length of the name of an export, e.g. of an ONTAP qtree.
+	maxExportNameLength = 64
+)
+
+// Provision creates the export of an nfspvc without path on the storage backend, with a quota of
+// its capacity, and returns its path and the Provisioned condition of the nfspvc. The path is
+// empty when the export could not be provisioned.
+func Provision(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, backend storage.StorageBackend) (string, metav1.Condition) {
+	if backend == nil {
+		return "", provisionedCondition(metav1.ConditionFalse, NoStorageBackendReason, "the nfspvc has no path and the operator has no storage backend to provision one")
+	}
+	capacity := nfspvc.Spec.Capacity[corev1.ResourceStorage]
+	path, err := backend.CreateExport(ctx, ExportName(nfspvc), capacity.Value())
+	if err != nil {
+		return "", provisionedCondition(metav1.ConditionFalse, ProvisioningFailedReason, fmt.Sprintf("failed to provision export %q: %v", ExportName(nfspvc), err))
+	}
+	return path, provisionedCondition(metav1.ConditionTrue, ProvisionedReason, fmt.Sprintf("provisioned %s:%s with a quota of %s", nfspvc.Spec.Server, path, capacity.String()))
+}
+
+// Deprovision deletes the export provisioned for the nfspvc when the reclaim policy is Delete.
+// Other reclaim policies keep it on the storage array.
+func Deprovision(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, backend storage.StorageBackend) error {
+	if !IsProvisioned(nfspvc) || backend == nil || corev1.PersistentVolumeReclaimPolicy(utils.ReclaimPolicy) != corev1.PersistentVolumeReclaimDelete {
+		return nil
+	}
+	if err := backend.DeleteExport(ctx, ExportName(nfspvc)); err != nil {
+		return fmt.Errorf("failed to delete export %q: %v", ExportName(nfspvc), err)
+	}
+	return nil
+}
+
+// IsProvisioned returns true if the export of the nfspvc was provisioned by the storage backend.
+func IsProvisioned(nfspvc danaiov1alpha1.NfsPvc) bool {
+	return nfspvc.Spec.Path == "" && nfspvc.Status.Path != ""
+}
+
+// ExportName returns the name of the export provisioned for the nfspvc, unique in the storage array.
+func ExportName(nfspvc danaiov1alpha1.NfsPvc) string {
+	return utils.ShortenName(nfspvc.Namespace+"_"+nfspvc.Name, maxExportNameLength)
+}
+
+// provisionedCondition returns the Provisioned condition of an nfspvc.
+func provisionedCondition(status metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    danaiov1alpha1.ProvisionedNfsPvcCondition,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+}
@@ -0,0 +1,102 @@
+package provision
+
+import (
+	"context"
+	"errors"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fakeBackend records the exports it is asked to create and delete.
+type fakeBackend struct {
+	exports map[string]int64
+	err     error
+}
+
+func (f *fakeBackend) CreateExport(_ context.Context, name string, size int64) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	f.exports[name] = size
+	return "/exports/" + name, nil
+}
+
+func (f *fakeBackend) DeleteExport(_ context.Context, name string) error {
+	delete(f.exports, name)
+	return f.err
+}
+
+var _ = Describe("Provision", func() {
+	var (
+		ctx     context.Context
+		backend *fakeBackend
+		nfspvc  danaiov1alpha1.NfsPvc
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		backend = &fakeBackend{exports: map[string]int64{}}
+		nfspvc = danaiov1alpha1.NfsPvc{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"},
+			Spec: danaiov1alpha1.NfsPvcSpec{
+				Server:   "nas",
+				Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		utils.ReclaimPolicy = ""
+	})
+
+	It("should provision an export with a quota of the capacity", func() {
+		path, condition := Provision(ctx, nfspvc, backend)
+		Expect(path).To(Equal("/exports/default_data"))
+		Expect(condition.Type).To(Equal(danaiov1alpha1.ProvisionedNfsPvcCondition))
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(ProvisionedReason))
+		Expect(backend.exports).To(HaveKeyWithValue("default_data", int64(10<<30)))
+	})
+
+	It("should report a missing or failing backend", func() {
+		path, condition := Provision(ctx, nfspvc, nil)
+		Expect(path).To(BeEmpty())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(NoStorageBackendReason))
+
+		backend.err = errors.New("volume is full")
+		path, condition = Provision(ctx, nfspvc, backend)
+		Expect(path).To(BeEmpty())
+		Expect(condition.Reason).To(Equal(ProvisioningFailedReason))
+		Expect(condition.Message).To(ContainSubstring("volume is full"))
+	})
+
+	It("should only delete provisioned exports with the Delete reclaim policy", func() {
+		backend.exports["default_data"] = 1
+		utils.ReclaimPolicy = string(corev1.PersistentVolumeReclaimRetain)
+		nfspvc.Status.Path = "/exports/default_data"
+		Expect(Deprovision(ctx, nfspvc, backend)).To(Succeed())
+		Expect(backend.exports).To(HaveKey("default_data"))
+
+		utils.ReclaimPolicy = string(corev1.PersistentVolumeReclaimDelete)
+		nfspvc.Spec.Path = "/exports/default_data"
+		Expect(Deprovision(ctx, nfspvc, backend)).To(Succeed())
+		Expect(backend.exports).To(HaveKey("default_data"))
+
+		nfspvc.Spec.Path = ""
+		Expect(Deprovision(ctx, nfspvc, backend)).To(Succeed())
+		Expect(backend.exports).To(BeEmpty())
+	})
+
+	It("should keep the names of the exports short", func() {
+		nfspvc.Namespace = "a-very-long-namespace-name-of-a-team"
+		nfspvc.Name = "a-very-long-name-of-an-nfspvc-of-the-team"
+		Expect(len(ExportName(nfspvc))).To(BeNumerically("<=", maxExportNameLength))
+	})
+})
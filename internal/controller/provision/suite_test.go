@@ -0,0 +1,14 @@
+package provision
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestProvision(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "Provision Suite")
+}
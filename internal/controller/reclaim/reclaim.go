@@ -0,0 +1,179 @@
+package reclaim
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/clusternfspvc"
+	"github.com/dana-team/nfspvc-operator/internal/controller/jobs"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// ReclaimedAnnotation marks a deleted nfspvc whose export was reclaimed, so that it is reclaimed once.
+	ReclaimedAnnotation = "nfspvc.dana.io/reclaimed"
+
+	// Reasons of the events recorded on the nfspvc.
+	ScrubbedReason       = "Scrubbed"
+	ArchivedReason       = "Archived"
+	ReclaimFailedReason  = "ReclaimFailed"
+	ReclaimSkippedReason = "ReclaimSkipped"
+
+	namePrefix   = "nfspvc-reclaim-"
+	dataMount    = "/data"
+	archiveMount = "/archive"
+)
+
+// JobName returns the name of the Job reclaiming the export of the nfspvc.
+func JobName(nfspvc danaiov1alpha1.NfsPvc) string {
+	return utils.ShortenName(namePrefix+nfspvc.Name, utils.MaxLabelValueLength)
+}
+
+// ArchiveFile returns the path of the archive of the nfspvc, relative to the root of the archive export.
+// It includes the UID of the nfspvc, so that an nfspvc recreated with the same name never overwrites it.
+func ArchiveFile(nfspvc danaiov1alpha1.NfsPvc) string {
+	return path.Join(nfspvc.Namespace, nfspvc.Name+"-"+string(nfspvc.UID)+".tar.gz")
+}
+
+// Run carries out the Scrub or the Archive reclaim policy on the export of a deleted nfspvc that opted
+// into it, through a Job mounting its PVC, and records the result as an event. It returns true once the
+// export is reclaimed, or when there is nothing to do; a failed Job is recreated until it succeeds.
+// The export of an adopted nfspvc, of a ClusterNfsPvc copy, or shared with another nfspvc or PV is kept.
+func Run(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc, observed resources.Observed, k8sClient client.Client, scheme *runtime.Scheme, recorder record.EventRecorder) (bool, error) {
+	if !utils.IsOperatorReclaimPolicy() || nfspvc.Annotations[ReclaimedAnnotation] == "true" ||
+		nfspvc.Annotations[danaiov1alpha1.ReclaimExportAnnotation] != "true" {
+		return true, nil
+	}
+	if _, adopted := nfspvc.Annotations[danaiov1alpha1.AdoptedPVAnnotation]; adopted {
+		return skip(ctx, nfspvc, k8sClient, recorder, "the nfspvc adopted an existing pv")
+	}
+	if _, materialized := nfspvc.Labels[clusternfspvc.ClusterNfsPvcLabel]; materialized {
+		return skip(ctx, nfspvc, k8sClient, recorder, "the nfspvc is materialized by a clusternfspvc")
+	}
+	shared, err := sharedWith(ctx, *nfspvc, k8sClient)
+	if err != nil {
+		return false, err
+	}
+	if shared != "" {
+		return skip(ctx, nfspvc, k8sClient, recorder, "the export is shared with "+shared)
+	}
+	if observed.PVC == nil || observed.PVC.Status.Phase != corev1.ClaimBound || observed.PVC.DeletionTimestamp != nil {
+		return skip(ctx, nfspvc, k8sClient, recorder, fmt.Sprintf("pvc %q is not bound", nfspvc.Name))
+	}
+
+	job := prepareJob(*nfspvc)
+	if err := controllerutil.SetControllerReference(nfspvc, &job, scheme); err != nil {
+		return false, err
+	}
+	job, err = jobs.Ensure(ctx, k8sClient, job)
+	if err != nil {
+		return false, err
+	}
+	state, err := jobs.Observe(ctx, k8sClient, job)
+	if err != nil {
+		return false, err
+	}
+
+	switch state.Phase {
+	case jobs.Failed:
+		recorder.Eventf(nfspvc, corev1.EventTypeWarning, ReclaimFailedReason, "job %q failed, retrying: %s", job.Name, state.Message)
+		if err := jobs.Delete(ctx, k8sClient, job.Name, job.Namespace); err != nil {
+			return false, fmt.Errorf("failed to delete job %q: %v", job.Name, err)
+		}
+		return false, nil
+	case jobs.Succeeded:
+		if corev1.PersistentVolumeReclaimPolicy(utils.ReclaimPolicy) == utils.ArchiveReclaimPolicy {
+			recorder.Eventf(nfspvc, corev1.EventTypeNormal, ArchivedReason, "archived %s files of %s:%s into %s:%s (%s bytes)",
+				state.Results[jobs.FilesResult], nfspvc.Spec.Server, resources.ExportPath(*nfspvc), utils.ArchiveServer, path.Join(utils.ArchivePath, ArchiveFile(*nfspvc)), state.Results[jobs.SizeResult])
+		} else {
+			recorder.Eventf(nfspvc, corev1.EventTypeNormal, ScrubbedReason, "removed %s files (%s bytes) from %s:%s",
+				state.Results[jobs.FilesResult], state.Results[jobs.SizeResult], nfspvc.Spec.Server, resources.ExportPath(*nfspvc))
+		}
+		if err := jobs.Delete(ctx, k8sClient, job.Name, job.Namespace); err != nil {
+			return false, fmt.Errorf("failed to delete job %q: %v", job.Name, err)
+		}
+		return true, markReclaimed(ctx, nfspvc, k8sClient)
+	}
+	return false, nil
+}
+
+// skip records why the reclaim policy was not applied to the export of the nfspvc, and marks it reclaimed.
+func skip(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc, k8sClient client.Client, recorder record.EventRecorder, reason string) (bool, error) {
+	recorder.Eventf(nfspvc, corev1.EventTypeWarning, ReclaimSkippedReason,
+		"the %s reclaim policy was not applied to %s:%s because %s", utils.ReclaimPolicy, nfspvc.Spec.Server, resources.ExportPath(*nfspvc), reason)
+	return true, markReclaimed(ctx, nfspvc, k8sClient)
+}
+
+// sharedWith returns another nfspvc or PV whose path on the server of the nfspvc contains, or is
+// contained in, the export of the nfspvc, or an empty string when the export is not shared.
+func sharedWith(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, k8sClient client.Client) (string, error) {
+	exportPath := resources.ExportPath(nfspvc)
+	nfspvcList := danaiov1alpha1.NfsPvcList{}
+	if err := k8sClient.List(ctx, &nfspvcList); err != nil {
+		return "", fmt.Errorf("failed to list nfspvcs: %v", err)
+	}
+	for _, other := range nfspvcList.Items {
+		if other.UID == nfspvc.UID || (other.Namespace == nfspvc.Namespace && other.Name == nfspvc.Name) {
+			continue
+		}
+		if other.Spec.Server == nfspvc.Spec.Server && overlaps(resources.ExportPath(other), exportPath) {
+			return fmt.Sprintf("nfspvc %q in namespace %q", other.Name, other.Namespace), nil
+		}
+	}
+	pvList := corev1.PersistentVolumeList{}
+	if err := k8sClient.List(ctx, &pvList); err != nil {
+		return "", fmt.Errorf("failed to list pvs: %v", err)
+	}
+	for _, pv := range pvList.Items {
+		if pv.Name == resources.PVName(nfspvc) || pv.Spec.NFS == nil {
+			continue
+		}
+		if pv.Spec.NFS.Server == nfspvc.Spec.Server && overlaps(pv.Spec.NFS.Path, exportPath) {
+			return fmt.Sprintf("pv %q", pv.Name), nil
+		}
+	}
+	return "", nil
+}
+
+// overlaps returns true if one of the paths is, or contains, the other.
+func overlaps(first, second string) bool {
+	first, second = path.Clean("/"+first), path.Clean("/"+second)
+	return first == second || strings.HasPrefix(first, strings.TrimSuffix(second, "/")+"/") ||
+		strings.HasPrefix(second, strings.TrimSuffix(first, "/")+"/")
+}
+
+// markReclaimed sets the reclaimed annotation of the nfspvc.
+func markReclaimed(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc, k8sClient client.Client) error {
+	patch := client.MergeFrom(nfspvc.DeepCopy())
+	if nfspvc.Annotations == nil {
+		nfspvc.Annotations = map[string]string{}
+	}
+	nfspvc.Annotations[ReclaimedAnnotation] = "true"
+	if err := k8sClient.Patch(ctx, nfspvc, patch); err != nil {
+		return fmt.Errorf("failed to set the %s annotation of nfspvc %q: %v", ReclaimedAnnotation, nfspvc.Name, err)
+	}
+	return nil
+}
+
+// prepareJob returns the Job scrubbing or archiving the export of the nfspvc.
+func prepareJob(nfspvc danaiov1alpha1.NfsPvc) batchv1.Job {
+	data := jobs.ClaimVolume("data", dataMount, nfspvc.Name, false)
+	if corev1.PersistentVolumeReclaimPolicy(utils.ReclaimPolicy) == utils.ArchiveReclaimPolicy {
+		return jobs.PrepareJob(JobName(nfspvc), nfspvc.Namespace, "archive", utils.DataMoverImage,
+			jobs.ArchiveScript(dataMount, path.Join(archiveMount, ArchiveFile(nfspvc))),
+			data,
+			jobs.NFSVolume("archive", archiveMount, utils.ArchiveServer, utils.ArchivePath, false),
+		)
+	}
+	return jobs.PrepareJob(JobName(nfspvc), nfspvc.Namespace, "scrub", utils.DataMoverImage, jobs.ScrubScript(dataMount), data)
+}
@@ -0,0 +1,217 @@
+package reclaim
+
+import (
+	"context"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/clusternfspvc"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Run", func() {
+	const namespace = "default"
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		scheme    *runtime.Scheme
+		recorder  *record.FakeRecorder
+		nfspvc    *danaiov1alpha1.NfsPvc
+		pvc       corev1.PersistentVolumeClaim
+	)
+
+	run := func() bool {
+		observed, err := resources.Observe(ctx, *nfspvc, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		reclaimed, err := Run(ctx, nfspvc, observed, k8sClient, scheme, recorder)
+		Expect(err).NotTo(HaveOccurred())
+		return reclaimed
+	}
+
+	// finishJob marks the reclaim Job as complete or failed.
+	finishJob := func(conditionType batchv1.JobConditionType) {
+		job := batchv1.Job{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: JobName(*nfspvc), Namespace: namespace}, &job)).To(Succeed())
+		job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+		Expect(k8sClient.Status().Update(ctx, &job)).To(Succeed())
+	}
+
+	jobExists := func() bool {
+		err := k8sClient.Get(ctx, client.ObjectKey{Name: JobName(*nfspvc), Namespace: namespace}, &batchv1.Job{})
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).NotTo(HaveOccurred())
+		return true
+	}
+
+	build := func(objects ...client.Object) {
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, nfspvc, &pvc)...).
+			WithStatusSubresource(&batchv1.Job{}).Build()
+	}
+
+	// expectSkipped checks that the export was left untouched and the nfspvc marked reclaimed.
+	expectSkipped := func(reason string) {
+		Expect(run()).To(BeTrue())
+		Expect(jobExists()).To(BeFalse())
+		Expect(recorder.Events).To(Receive(And(ContainSubstring(ReclaimSkippedReason), ContainSubstring(reason))))
+		Expect(nfspvc.Annotations).To(HaveKeyWithValue(ReclaimedAnnotation, "true"))
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(danaiov1alpha1.AddToScheme(scheme)).To(Succeed())
+		recorder = record.NewFakeRecorder(10)
+		utils.ReclaimPolicy = string(utils.ScrubReclaimPolicy)
+		utils.ArchiveServer, utils.ArchivePath = "archive-nas", "/archive"
+		nfspvc = &danaiov1alpha1.NfsPvc{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: namespace, UID: "data-uid",
+				Annotations: map[string]string{danaiov1alpha1.ReclaimExportAnnotation: "true"}},
+			Spec: danaiov1alpha1.NfsPvcSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+				Server:      "nas",
+				Path:        "/data",
+				NfsVersion:  "4.1",
+			},
+		}
+		pvc = resources.PreparePVC(*nfspvc, "")
+		pvc.Status.Phase = corev1.ClaimBound
+		build()
+	})
+
+	AfterEach(func() {
+		utils.ReclaimPolicy = ""
+		utils.ArchiveServer, utils.ArchivePath = "", ""
+	})
+
+	It("should do nothing when the reclaim policy is carried out by Kubernetes", func() {
+		utils.ReclaimPolicy = string(corev1.PersistentVolumeReclaimDelete)
+		Expect(run()).To(BeTrue())
+		Expect(jobExists()).To(BeFalse())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("should do nothing when the nfspvc did not opt into the reclaim", func() {
+		nfspvc.Annotations = nil
+		build()
+		Expect(run()).To(BeTrue())
+		Expect(jobExists()).To(BeFalse())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("should scrub the export with a Job and mark the nfspvc reclaimed", func() {
+		Expect(run()).To(BeFalse())
+		job := batchv1.Job{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: JobName(*nfspvc), Namespace: namespace}, &job)).To(Succeed())
+		Expect(job.Spec.Template.Spec.Volumes).To(HaveLen(1))
+		Expect(job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("data"))
+
+		finishJob(batchv1.JobComplete)
+		Expect(run()).To(BeTrue())
+		Expect(jobExists()).To(BeFalse())
+		Expect(nfspvc.Annotations).To(HaveKeyWithValue(ReclaimedAnnotation, "true"))
+		Expect(recorder.Events).To(Receive(ContainSubstring(ScrubbedReason)))
+
+		Expect(run()).To(BeTrue())
+		Expect(jobExists()).To(BeFalse())
+	})
+
+	It("should archive the export into the archive export", func() {
+		utils.ReclaimPolicy = string(utils.ArchiveReclaimPolicy)
+		Expect(run()).To(BeFalse())
+		job := batchv1.Job{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: JobName(*nfspvc), Namespace: namespace}, &job)).To(Succeed())
+		Expect(job.Spec.Template.Spec.Volumes).To(HaveLen(2))
+		Expect(job.Spec.Template.Spec.Volumes[1].NFS.Server).To(Equal("archive-nas"))
+		Expect(ArchiveFile(*nfspvc)).To(Equal("default/data-data-uid.tar.gz"))
+
+		finishJob(batchv1.JobComplete)
+		Expect(run()).To(BeTrue())
+		Expect(recorder.Events).To(Receive(And(ContainSubstring(ArchivedReason), ContainSubstring("/archive/default/data-data-uid.tar.gz"))))
+	})
+
+	It("should recreate a failed Job", func() {
+		Expect(run()).To(BeFalse())
+		finishJob(batchv1.JobFailed)
+		Expect(run()).To(BeFalse())
+		Expect(jobExists()).To(BeFalse())
+		Expect(recorder.Events).To(Receive(ContainSubstring(ReclaimFailedReason)))
+		Expect(nfspvc.Annotations).NotTo(HaveKey(ReclaimedAnnotation))
+
+		Expect(run()).To(BeFalse())
+		Expect(jobExists()).To(BeTrue())
+	})
+
+	It("should skip the reclaim when the pvc is not bound", func() {
+		pvc.Status.Phase = corev1.ClaimPending
+		build()
+		Expect(run()).To(BeTrue())
+		Expect(jobExists()).To(BeFalse())
+		Expect(recorder.Events).To(Receive(ContainSubstring(ReclaimSkippedReason)))
+		Expect(nfspvc.Annotations).To(HaveKeyWithValue(ReclaimedAnnotation, "true"))
+	})
+
+	It("should skip the reclaim of an adopted nfspvc", func() {
+		nfspvc.Annotations[danaiov1alpha1.AdoptedPVAnnotation] = "legacy-pv"
+		build()
+		expectSkipped("adopted")
+	})
+
+	It("should skip the reclaim of a clusternfspvc copy", func() {
+		nfspvc.Labels = map[string]string{clusternfspvc.ClusterNfsPvcLabel: "shared"}
+		build()
+		expectSkipped("clusternfspvc")
+	})
+
+	It("should skip the reclaim of an export shared with another nfspvc", func() {
+		other := danaiov1alpha1.NfsPvc{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "other", UID: "other-uid"},
+			Spec:       danaiov1alpha1.NfsPvcSpec{Server: "nas", Path: "/data/"},
+		}
+		build(&other)
+		expectSkipped(`nfspvc "data" in namespace "other"`)
+	})
+
+	It("should skip the reclaim of an export containing the path of another pv", func() {
+		pv := corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "legacy"},
+			Spec: corev1.PersistentVolumeSpec{PersistentVolumeSource: corev1.PersistentVolumeSource{
+				NFS: &corev1.NFSVolumeSource{Server: "nas", Path: "/data/reports"},
+			}},
+		}
+		build(&pv)
+		expectSkipped(`pv "legacy"`)
+	})
+
+	It("should reclaim an export next to other exports of the server", func() {
+		other := danaiov1alpha1.NfsPvc{
+			ObjectMeta: metav1.ObjectMeta{Name: "database", Namespace: namespace, UID: "database-uid"},
+			Spec:       danaiov1alpha1.NfsPvcSpec{Server: "nas", Path: "/database"},
+		}
+		pv := resources.PreparePV(*nfspvc, "nfs", string(corev1.PersistentVolumeReclaimRetain))
+		build(&other, &pv)
+		Expect(run()).To(BeFalse())
+		Expect(jobExists()).To(BeTrue())
+	})
+})
+
+var _ = Describe("NormalizeReclaimPolicy", func() {
+	It("should carry out the deprecated Recycle policy with Scrub", func() {
+		Expect(utils.IsReclaimPolicyValid(string(corev1.PersistentVolumeReclaimRecycle))).To(BeTrue())
+		Expect(utils.NormalizeReclaimPolicy(string(corev1.PersistentVolumeReclaimRecycle))).To(Equal(string(utils.ScrubReclaimPolicy)))
+		Expect(utils.NormalizeReclaimPolicy(string(utils.ArchiveReclaimPolicy))).To(Equal(string(utils.ArchiveReclaimPolicy)))
+	})
+})
@@ -0,0 +1,14 @@
+package reclaim
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestReclaim(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "Reclaim Suite")
+}
@@ -0,0 +1,41 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EnsureClaim creates the PV and the PVC of an nfspvc that is never created itself, such as the
+// temporary claims through which the data mover Jobs mount an export. The PV is retained, so that
+// deleting the claim never touches the data.
+func EnsureClaim(ctx context.Context, claim danaiov1alpha1.NfsPvc, k8sClient client.Client) error {
+	pv := PreparePV(claim, "", string(corev1.PersistentVolumeReclaimRetain))
+	if err := k8sClient.Create(ctx, &pv); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create pv %q: %v", pv.Name, err)
+	}
+	pvc := PreparePVC(claim, "")
+	if err := k8sClient.Create(ctx, &pvc); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create pvc %q: %v", pvc.Name, err)
+	}
+	return nil
+}
+
+// DeleteClaim deletes the PVC and the PV created by EnsureClaim.
+func DeleteClaim(ctx context.Context, name, namespace string, k8sClient client.Client) error {
+	pvc := corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	if err := k8sClient.Delete(ctx, &pvc); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete pvc %q: %v", pvc.Name, err)
+	}
+	claim := danaiov1alpha1.NfsPvc{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	pv := corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: PVName(claim)}}
+	if err := k8sClient.Delete(ctx, &pv); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete pv %q: %v", pv.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/pkg/sdk"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Observed holds the PV and the PVC of an nfspvc as read once from the cache at the start of a reconcile.
+// A nil PV or PVC does not exist.
+type Observed struct {
+	PV  *corev1.PersistentVolume
+	PVC *corev1.PersistentVolumeClaim
+}
+
+// Observe reads the PV and the PVC of the nfspvc.
+func Observe(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, k8sClient client.Reader) (Observed, error) {
+	observed := Observed{}
+
+	pv := &corev1.PersistentVolume{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: PVName(nfspvc)}, pv); err != nil {
+		if !errors.IsNotFound(err) {
+			return observed, fmt.Errorf("failed to fetch pv %q: %v", PVName(nfspvc), err)
+		}
+	} else {
+		observed.PV = pv
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: nfspvc.Namespace, Name: nfspvc.Name}, pvc); err != nil {
+		if !errors.IsNotFound(err) {
+			return observed, fmt.Errorf("failed to fetch pvc %q: %v", nfspvc.Name, err)
+		}
+	} else {
+		observed.PVC = pvc
+	}
+
+	return observed, nil
+}
+
+// PVName returns the name of the PV of the nfspvc, the existing PV it adopted if any.
+func PVName(nfspvc danaiov1alpha1.NfsPvc) string {
+	return sdk.PVName(nfspvc)
+}
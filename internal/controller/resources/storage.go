@@ -3,18 +3,19 @@ package resources
 import (
 	"context"
 	"fmt"
-
-	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	"slices"
+	"strings"
 
 	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/nfsprobe"
+	"github.com/dana-team/nfspvc-operator/pkg/sdk"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
-	nfsPvcDanaLabel = "nfspvc.dana.io/nfspvc-owner"
+	NfsPvcOwnerLabel = sdk.OwnerLabel
 )
 
 // PreparePVC returns a PVC with the given storageclass.
@@ -26,12 +27,12 @@ func PreparePVC(nfspvc danaiov1alpha1.NfsPvc, StorageClass string) corev1.Persis
 			Name:      nfspvc.Name,
 			Namespace: nfspvc.Namespace,
 			Labels: map[string]string{
-				nfsPvcDanaLabel: nfspvc.Name,
+				NfsPvcOwnerLabel: nfspvc.Name,
 			},
 		},
 		Spec: corev1.PersistentVolumeClaimSpec{
 			StorageClassName: &storageClass,
-			VolumeName:       nfspvc.Name + "-" + nfspvc.Namespace + "-pv",
+			VolumeName:       PVName(nfspvc),
 			AccessModes:      nfspvc.Spec.AccessModes,
 			Resources: corev1.VolumeResourceRequirements{
 				Requests: nfspvc.Spec.Capacity,
@@ -42,15 +43,15 @@ func PreparePVC(nfspvc danaiov1alpha1.NfsPvc, StorageClass string) corev1.Persis
 
 // PreparePV returns a PV with the given storageclass and reclaimpolicy.
 func PreparePV(nfspvc danaiov1alpha1.NfsPvc, StorageClass string, ReclaimPolicy string) corev1.PersistentVolume {
-	var pvName = nfspvc.Name + "-" + nfspvc.Namespace + "-pv"
-	var mountOptions = []string{fmt.Sprintf("nfsvers=%s", nfspvc.Spec.NfsVersion)}
+	var pvName = PVName(nfspvc)
+	var mountOptions = MountOptions(nfspvc)
 
 	return corev1.PersistentVolume{
 		TypeMeta: metav1.TypeMeta{},
 		ObjectMeta: metav1.ObjectMeta{
 			Name: pvName,
 			Labels: map[string]string{
-				nfsPvcDanaLabel: nfspvc.Name,
+				NfsPvcOwnerLabel: nfspvc.Name,
 			},
 		},
 		Spec: corev1.PersistentVolumeSpec{
@@ -66,7 +67,7 @@ func PreparePV(nfspvc danaiov1alpha1.NfsPvc, StorageClass string, ReclaimPolicy
 			PersistentVolumeSource: corev1.PersistentVolumeSource{
 				NFS: &corev1.NFSVolumeSource{
 					Server: nfspvc.Spec.Server,
-					Path:   nfspvc.Spec.Path,
+					Path:   ExportPath(nfspvc),
 				},
 			},
 			MountOptions: mountOptions,
@@ -74,6 +75,74 @@ func PreparePV(nfspvc danaiov1alpha1.NfsPvc, StorageClass string, ReclaimPolicy
 	}
 }
 
+var (
+	nfsSecurityFlavors     = []danaiov1alpha1.NfsSecurityFlavor{danaiov1alpha1.SysNfsSecurityFlavor, danaiov1alpha1.Krb5NfsSecurityFlavor, danaiov1alpha1.Krb5iNfsSecurityFlavor, danaiov1alpha1.Krb5pNfsSecurityFlavor}
+	nfsTransportSecurities = []danaiov1alpha1.NfsTransportSecurity{danaiov1alpha1.NoneNfsTransportSecurity, danaiov1alpha1.TLSNfsTransportSecurity, danaiov1alpha1.MTLSNfsTransportSecurity}
+)
+
+// MountOptions returns the mount options of the PV of the nfspvc.
+func MountOptions(nfspvc danaiov1alpha1.NfsPvc) []string {
+	mountOptions := []string{fmt.Sprintf("nfsvers=%s", NfsVersion(nfspvc))}
+	if security := nfspvc.Spec.Security; security != nil {
+		if security.Sec != "" {
+			mountOptions = append(mountOptions, fmt.Sprintf("sec=%s", security.Sec))
+		}
+		if security.TransportSecurity != "" && security.TransportSecurity != danaiov1alpha1.NoneNfsTransportSecurity {
+			mountOptions = append(mountOptions, fmt.Sprintf("xprtsec=%s", security.TransportSecurity))
+		}
+	}
+	return mountOptions
+}
+
+// ApplyMountOptions sets the NFS version and the security of the spec from NFS mount options, as
+// MountOptions renders them, and returns the options the spec cannot express. An option may hold
+// several comma separated options, as in fstab.
+func ApplyMountOptions(spec *danaiov1alpha1.NfsPvcSpec, options []string) []string {
+	var unsupported []string
+	version, minorVersion := "", ""
+	for _, option := range strings.Split(strings.Join(options, ","), ",") {
+		option = strings.TrimSpace(option)
+		key, value, _ := strings.Cut(option, "=")
+		switch {
+		case option == "":
+		case key == "nfsvers" || key == "vers":
+			version = strings.TrimSuffix(value, ".0")
+		case key == "minorversion":
+			minorVersion = value
+		case key == "sec" && slices.Contains(nfsSecurityFlavors, danaiov1alpha1.NfsSecurityFlavor(value)):
+			if spec.Security == nil {
+				spec.Security = &danaiov1alpha1.NfsSecurity{}
+			}
+			spec.Security.Sec = danaiov1alpha1.NfsSecurityFlavor(value)
+		case key == "xprtsec" && slices.Contains(nfsTransportSecurities, danaiov1alpha1.NfsTransportSecurity(value)):
+			if spec.Security == nil {
+				spec.Security = &danaiov1alpha1.NfsSecurity{}
+			}
+			spec.Security.TransportSecurity = danaiov1alpha1.NfsTransportSecurity(value)
+		default:
+			unsupported = append(unsupported, option)
+		}
+	}
+	if version == "4" && minorVersion != "" && minorVersion != "0" {
+		version += "." + minorVersion
+	}
+	switch {
+	case slices.Contains(nfsprobe.Versions, version):
+		spec.NfsVersion = version
+	case version != "":
+		unsupported = append(unsupported, "nfsvers="+version)
+	}
+	return unsupported
+}
+
+// NfsVersion returns the NFS version the PV of the nfspvc is mounted with.
+func NfsVersion(nfspvc danaiov1alpha1.NfsPvc) string {
+	if nfspvc.Spec.NfsVersion == danaiov1alpha1.NfsVersionAuto {
+		return nfspvc.Status.NegotiatedVersion
+	}
+	return nfspvc.Spec.NfsVersion
+}
+
 // UpdatePV updates the PV claim reference when the NFSPVC is updated.
 func UpdatePV(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc, k8sClient client.Client, pv *corev1.PersistentVolume) error {
 	claimRefForPv := &corev1.ObjectReference{
@@ -81,14 +150,8 @@ func UpdatePV(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc, k8sClient clie
 		Namespace: nfspvc.Namespace,
 		Kind:      corev1.ResourcePersistentVolumeClaims.String(),
 	}
-	pvName := nfspvc.Name + "-" + nfspvc.Namespace + "-pv"
-
-	if err := k8sClient.Get(ctx, types.NamespacedName{Name: pvName, Namespace: nfspvc.Namespace}, pv); err != nil {
-		return err
-	}
 
-	return utils.RetryOnConflictUpdate(ctx, k8sClient, pv, pvName, nfspvc.Namespace, func(obj *corev1.PersistentVolume) error {
-		obj.Spec.ClaimRef = claimRefForPv
-		return k8sClient.Update(ctx, obj)
-	})
+	patched := pv.DeepCopy()
+	patched.Spec.ClaimRef = claimRefForPv
+	return k8sClient.Patch(ctx, patched, client.MergeFrom(pv))
 }
@@ -9,22 +9,23 @@ import (
 	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
-	pvcBindStatusAnnotation = "pv.kubernetes.io/bind-completed"
+	PVCBindStatusAnnotation = "pv.kubernetes.io/bind-completed"
 	desiredBindStatus       = "yes"
 )
 
 // HandleStorageObjectState handles the underlying PV and PVC when an NFSPVC is updated.
-func HandleStorageObjectState(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, k8sClient client.Client) error {
-	if err := handlePVState(ctx, nfspvc, k8sClient); err != nil {
+func HandleStorageObjectState(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, observed Observed, k8sClient client.Client) error {
+	if err := handlePVState(ctx, nfspvc, observed, k8sClient); err != nil {
 		return err
 	}
 
-	if err := handlePVCState(ctx, nfspvc, k8sClient); err != nil {
+	if err := handlePVCState(ctx, nfspvc, observed, k8sClient); err != nil {
 		return err
 	}
 
@@ -33,62 +34,87 @@ func HandleStorageObjectState(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc,
 }
 
 // handlePVState ensures the pv connected to an nfspvc exists and has a ClaimRef.
-func handlePVState(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, k8sClient client.Client) error {
-	pv := corev1.PersistentVolume{}
-	if err := k8sClient.Get(ctx, types.NamespacedName{Name: nfspvc.Name + "-" + nfspvc.Namespace + "-pv"}, &pv); err != nil {
-		if !errors.IsNotFound(err) {
-			return err
+// The pv of an nfspvc with a dataSource is only created once its export is populated,
+// and a pv being deleted, such as during a migration, is left alone until it is gone.
+func handlePVState(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, observed Observed, k8sClient client.Client) error {
+	if IsPVDeleting(observed) {
+		return nil
+	}
+	if observed.PV == nil {
+		if ExportPath(nfspvc) == "" || !IsPathReady(nfspvc) || !IsPopulated(nfspvc) {
+			return nil
 		}
-		pvFromNfsPvc := PreparePV(nfspvc, utils.StorageClass, utils.ReclaimPolicy)
-		if err := k8sClient.Create(ctx, &pvFromNfsPvc); err != nil {
+		pvFromNfsPvc := PreparePV(nfspvc, utils.StorageClass, utils.PVReclaimPolicy())
+		// another reconcile may have created the pv since it was read from the cache
+		if err := k8sClient.Create(ctx, &pvFromNfsPvc); err != nil && !errors.IsAlreadyExists(err) {
 			return fmt.Errorf("failed to create pv %q: %v", pvFromNfsPvc.Name, err)
 		}
 		return nil
 	}
 
-	isClaimRefPVCDeleted, err := isConnectedPVCDeleted(ctx, k8sClient, pv, nfspvc)
-	if err != nil {
-		return fmt.Errorf("failed to fetch claimRef PVC: %s", err.Error())
-	}
-	if isClaimRefPVCDeleted {
-		return UpdatePV(ctx, &nfspvc, k8sClient, &pv)
+	if isConnectedPVCDeleted(*observed.PV, observed.PVC, nfspvc) {
+		return UpdatePV(ctx, &nfspvc, k8sClient, observed.PV)
 	}
 	return nil
 }
 
 // handlePVCState ensures the pvc connected to an nfspvc exists and checks if it is bound to a pv.
-func handlePVCState(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, k8sClient client.Client) error {
-	pvc := corev1.PersistentVolumeClaim{}
-	if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: nfspvc.Namespace, Name: nfspvc.Name}, &pvc); err != nil {
-		if errors.IsNotFound(err) {
-			pvcFromNfsPvc := PreparePVC(nfspvc, utils.StorageClass)
-			if err := k8sClient.Create(ctx, &pvcFromNfsPvc); err != nil {
-				return fmt.Errorf("failed to create pvc %q: %v", nfspvc.Name, err)
-			}
+// The pvc is not recreated while the pv is being deleted, so that it never binds to it again.
+func handlePVCState(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, observed Observed, k8sClient client.Client) error {
+	if observed.PVC == nil {
+		if IsPVDeleting(observed) {
 			return nil
-		} else {
-			return fmt.Errorf("failed to fetch pvc %q: %v", nfspvc.Name, err)
 		}
+		pvcFromNfsPvc := PreparePVC(nfspvc, utils.StorageClass)
+		if err := k8sClient.Create(ctx, &pvcFromNfsPvc); err != nil && !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create pvc %q: %v", nfspvc.Name, err)
+		}
+		return nil
 	}
 
-	if pvc.Status.Phase == corev1.ClaimLost { // if the pvc's phase is 'lost', so probably the associated pv was deleted
-		return deletePVCBindAnnotation(ctx, &nfspvc, k8sClient, &pvc)
+	if observed.PVC.Status.Phase == corev1.ClaimLost { // if the pvc's phase is 'lost', so probably the associated pv was deleted
+		return deletePVCBindAnnotation(ctx, k8sClient, observed.PVC)
 	}
 	return nil
 }
 
+// IsPopulated returns false while the export of an nfspvc with a dataSource has not been filled yet.
+func IsPopulated(nfspvc danaiov1alpha1.NfsPvc) bool {
+	return nfspvc.Spec.DataSource == nil ||
+		meta.IsStatusConditionFalse(nfspvc.Status.Conditions, danaiov1alpha1.PopulatingNfsPvcCondition)
+}
+
+// ExportPath returns the path of the export of the nfspvc, the one provisioned by the storage backend
+// when the nfspvc has no path. It is empty until the export is provisioned.
+func ExportPath(nfspvc danaiov1alpha1.NfsPvc) string {
+	if nfspvc.Spec.Path != "" {
+		return nfspvc.Spec.Path
+	}
+	return nfspvc.Status.Path
+}
+
+// IsPathReady returns false while the path of an nfspvc with createPath has not been created yet
+// for the current generation of the nfspvc.
+func IsPathReady(nfspvc danaiov1alpha1.NfsPvc) bool {
+	if nfspvc.Spec.CreatePath == nil {
+		return true
+	}
+	condition := meta.FindStatusCondition(nfspvc.Status.Conditions, danaiov1alpha1.PathReadyNfsPvcCondition)
+	return condition != nil && condition.Status == metav1.ConditionTrue && condition.ObservedGeneration == nfspvc.Generation
+}
+
+// IsPVDeleting returns true if the pv of the nfspvc is being deleted.
+func IsPVDeleting(observed Observed) bool {
+	return observed.PV != nil && observed.PV.DeletionTimestamp != nil
+}
+
 // deletePVCBindAnnotation deletes the "bind" annotation from a pvc.
-func deletePVCBindAnnotation(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc, k8sClient client.Client, pvc *corev1.PersistentVolumeClaim) error {
-	bindStatus, ok := pvc.Annotations[pvcBindStatusAnnotation]
+func deletePVCBindAnnotation(ctx context.Context, k8sClient client.Client, pvc *corev1.PersistentVolumeClaim) error {
+	bindStatus, ok := pvc.Annotations[PVCBindStatusAnnotation]
 	if ok && bindStatus == desiredBindStatus {
-		if err := k8sClient.Get(ctx, types.NamespacedName{Name: nfspvc.Name, Namespace: nfspvc.Namespace}, pvc); err != nil {
-			return err
-		}
-
-		return utils.RetryOnConflictUpdate(ctx, k8sClient, pvc, nfspvc.Name, nfspvc.Namespace, func(obj *corev1.PersistentVolumeClaim) error {
-			delete(obj.Annotations, pvcBindStatusAnnotation)
-			return k8sClient.Update(ctx, obj)
-		})
+		patched := pvc.DeepCopy()
+		delete(patched.Annotations, PVCBindStatusAnnotation)
+		return k8sClient.Patch(ctx, patched, client.MergeFrom(pvc))
 	}
 	return nil
 }
@@ -96,18 +122,14 @@ func deletePVCBindAnnotation(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc,
 // isConnectedPVCDeleted returns if the connected PVC is deleted,
 // connected PVC considered deleted when the PV phase is Released or Failed,
 // or the PV phase in the nfspvc is bound, the PVC phase in the nfspvc is pending and the UID in the PV claimRef is different from the PVC UID.
-func isConnectedPVCDeleted(ctx context.Context, k8sClient client.Client, PV corev1.PersistentVolume, nfspvc danaiov1alpha1.NfsPvc) (bool, error) {
+func isConnectedPVCDeleted(PV corev1.PersistentVolume, pvc *corev1.PersistentVolumeClaim, nfspvc danaiov1alpha1.NfsPvc) bool {
 	if isPVReleased(PV) {
-		return true, nil
+		return true
 	}
 	if isPVFailed(PV) {
-		return true, nil
-	}
-	if isPVCInRecreation, err := isPVCInRecreationState(ctx, k8sClient, nfspvc, PV); err != nil {
-		return false, err
-	} else {
-		return isPVCInRecreation, nil
+		return true
 	}
+	return isPVCInRecreationState(nfspvc, PV, pvc)
 }
 
 // isPVReleased returns true if the PV phase is Released.
@@ -124,25 +146,16 @@ func isPVFailed(PV corev1.PersistentVolume) bool {
 // the PV phase of the nfspvc is bound, and
 // the PVC phase in the nfspvc is pending, and
 // the UID in the PV claimRef is different from the PVC UID.
-func isPVCInRecreationState(ctx context.Context, k8sClient client.Client, nfspvc danaiov1alpha1.NfsPvc, PV corev1.PersistentVolume) (bool, error) {
-	isUIDEqual, err := isPVCUIDEqual(ctx, k8sClient, PV.Spec.ClaimRef.UID, nfspvc)
-	if err != nil {
-		return false, err
-	}
+func isPVCInRecreationState(nfspvc danaiov1alpha1.NfsPvc, PV corev1.PersistentVolume, pvc *corev1.PersistentVolumeClaim) bool {
 	return nfspvc.Status.PvPhase == string(corev1.VolumeBound) &&
 		nfspvc.Status.PvcPhase == string(corev1.ClaimPending) &&
-		!isUIDEqual, nil
+		!isPVCUIDEqual(PV.Spec.ClaimRef, pvc)
 }
 
 // isPVCUIDEqual returns true if the PVC uid and the claimRef uid of the PV are equal.
-func isPVCUIDEqual(ctx context.Context, k8sClient client.Client, uid types.UID, nfspvc danaiov1alpha1.NfsPvc) (bool, error) {
-	pvc := corev1.PersistentVolumeClaim{}
-	if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: nfspvc.Namespace, Name: nfspvc.Name}, &pvc); err != nil {
-		if errors.IsNotFound(err) {
-			return false, nil
-		} else {
-			return false, err
-		}
+func isPVCUIDEqual(claimRef *corev1.ObjectReference, pvc *corev1.PersistentVolumeClaim) bool {
+	if pvc == nil || claimRef == nil {
+		return false
 	}
-	return pvc.GetUID() == uid, nil
+	return pvc.GetUID() == claimRef.UID
 }
@@ -2,64 +2,113 @@ package status
 
 import (
 	"context"
+	"slices"
 
-	"github.com/dana-team/nfspvc-operator/internal/controller/utils"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
 
 	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
-	phaseUnknown  = "Unknown"
 	phaseNotFound = "NotFound"
 )
 
-// Update fetches the phase of the pv and the pvc that is created by the nfspvc and updates the nfspvc status.
-func Update(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, k8sClient client.Client) error {
-	pvcPhase := getPVCStatus(ctx, nfspvc, k8sClient)
-	pvPhase := getPVStatus(ctx, nfspvc, k8sClient)
-	if pvcPhase != nfspvc.Status.PvcPhase || pvPhase != nfspvc.Status.PvPhase {
-		return ensure(ctx, pvcPhase, pvPhase, &nfspvc, k8sClient)
+// Update computes the phase of the pv and the pvc that are created by the nfspvc and patches the nfspvc status when it changed.
+func Update(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc, observed resources.Observed, k8sClient client.Client) error {
+	pvcPhase := getPVCStatus(observed)
+	pvPhase := getPVStatus(observed)
+	if pvcPhase == nfspvc.Status.PvcPhase && pvPhase == nfspvc.Status.PvPhase {
+		return nil
 	}
-	return nil
+	return patch(ctx, nfspvc, k8sClient, func(status *danaiov1alpha1.NfsPvcStatus) {
+		status.PvcPhase = pvcPhase
+		status.PvPhase = pvPhase
+	})
+}
+
+// SetNegotiatedVersion records the NFS version negotiated with the server in the nfspvc status.
+func SetNegotiatedVersion(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc, version string, k8sClient client.Client) error {
+	return patch(ctx, nfspvc, k8sClient, func(status *danaiov1alpha1.NfsPvcStatus) {
+		status.NegotiatedVersion = version
+	})
+}
+
+// SetProvisioned records the path of the export provisioned by the storage backend and the Provisioned
+// condition in the nfspvc status.
+func SetProvisioned(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc, path string, condition metav1.Condition, k8sClient client.Client) error {
+	conditions := slices.Clone(nfspvc.Status.Conditions)
+	if !meta.SetStatusCondition(&conditions, condition) && path == nfspvc.Status.Path {
+		return nil
+	}
+	return patch(ctx, nfspvc, k8sClient, func(status *danaiov1alpha1.NfsPvcStatus) {
+		status.Path = path
+		status.Conditions = conditions
+	})
+}
+
+// SetCondition sets the condition in the nfspvc status, and patches the status when it changed.
+func SetCondition(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc, condition metav1.Condition, k8sClient client.Client) error {
+	conditions := slices.Clone(nfspvc.Status.Conditions)
+	if !meta.SetStatusCondition(&conditions, condition) {
+		return nil
+	}
+	return patch(ctx, nfspvc, k8sClient, func(status *danaiov1alpha1.NfsPvcStatus) {
+		status.Conditions = conditions
+	})
 }
 
-// ensure updates the status of the nfspvc to match the state of the underlying PVC.
-func ensure(ctx context.Context, pvcPhase, pvPhase string, nfspvc *danaiov1alpha1.NfsPvc, k8sClient client.Client) error {
-	if err := k8sClient.Get(ctx, types.NamespacedName{Name: nfspvc.Name, Namespace: nfspvc.Namespace}, nfspvc); err != nil {
-		return err
+// RemoveCondition removes the condition of the given type from the nfspvc status, when it is set.
+func RemoveCondition(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc, conditionType string, k8sClient client.Client) error {
+	conditions := slices.Clone(nfspvc.Status.Conditions)
+	if !meta.RemoveStatusCondition(&conditions, conditionType) {
+		return nil
 	}
+	return patch(ctx, nfspvc, k8sClient, func(status *danaiov1alpha1.NfsPvcStatus) {
+		status.Conditions = conditions
+	})
+}
+
+// SetUsage records the usage of the export measured by the usage agent in the nfspvc status.
+func SetUsage(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc, usage danaiov1alpha1.NfsPvcUsage, k8sClient client.Client) error {
+	return patch(ctx, nfspvc, k8sClient, func(status *danaiov1alpha1.NfsPvcStatus) {
+		status.Usage = &usage
+	})
+}
 
-	return utils.RetryOnConflictUpdate(ctx, k8sClient, nfspvc, nfspvc.Name, nfspvc.Namespace, func(obj *danaiov1alpha1.NfsPvc) error {
-		obj.Status.PvcPhase = pvcPhase
-		obj.Status.PvPhase = pvPhase
-		return k8sClient.Status().Update(ctx, obj)
+// SetDiagnostics records the findings of the failed diagnostic checks in the nfspvc status, and patches
+// the status when they changed.
+func SetDiagnostics(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc, diagnostics []danaiov1alpha1.NfsPvcDiagnostic, k8sClient client.Client) error {
+	if slices.Equal(diagnostics, nfspvc.Status.Diagnostics) {
+		return nil
+	}
+	return patch(ctx, nfspvc, k8sClient, func(status *danaiov1alpha1.NfsPvcStatus) {
+		status.Diagnostics = diagnostics
 	})
 }
 
+// patch applies mutate to the nfspvc status and sends the difference as a merge patch to the status subresource.
+// The patch only carries the mutated fields, so it does not conflict with concurrent writers.
+func patch(ctx context.Context, nfspvc *danaiov1alpha1.NfsPvc, k8sClient client.Client, mutate func(*danaiov1alpha1.NfsPvcStatus)) error {
+	original := nfspvc.DeepCopy()
+	mutate(&nfspvc.Status)
+	return k8sClient.Status().Patch(ctx, nfspvc, client.MergeFrom(original))
+}
+
 // getPVCStatus returns the phase of the pvc.
-func getPVCStatus(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, k8sClient client.Client) string {
-	pvc := corev1.PersistentVolumeClaim{}
-	if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: nfspvc.Namespace, Name: nfspvc.Name}, &pvc); err != nil {
-		if errors.IsNotFound(err) {
-			return phaseNotFound
-		}
-		return phaseUnknown
+func getPVCStatus(observed resources.Observed) string {
+	if observed.PVC == nil {
+		return phaseNotFound
 	}
-	return string(pvc.Status.Phase)
+	return string(observed.PVC.Status.Phase)
 }
 
 // getPVStatus returns the phase of the pv.
-func getPVStatus(ctx context.Context, nfspvc danaiov1alpha1.NfsPvc, k8sClient client.Client) string {
-	pv := corev1.PersistentVolume{}
-	if err := k8sClient.Get(ctx, types.NamespacedName{Name: nfspvc.Name + "-" + nfspvc.Namespace + "-pv"}, &pv); err != nil {
-		if errors.IsNotFound(err) {
-			return phaseNotFound
-		}
-		return phaseUnknown
+func getPVStatus(observed resources.Observed) string {
+	if observed.PV == nil {
+		return phaseNotFound
 	}
-	return string(pv.Status.Phase)
+	return string(observed.PV.Status.Phase)
 }
@@ -0,0 +1,47 @@
+package usage
+
+import (
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	metricLabels = []string{"namespace", "nfspvc"}
+
+	usedBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "nfspvc_used_bytes",
+		Help: "Space used on the export of an NfsPvc.",
+	}, metricLabels)
+	availableBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "nfspvc_available_bytes",
+		Help: "Space still available on the export of an NfsPvc.",
+	}, metricLabels)
+	sizeBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "nfspvc_size_bytes",
+		Help: "Size of the file system of the export of an NfsPvc.",
+	}, metricLabels)
+)
+
+func init() {
+	metrics.Registry.MustRegister(usedBytes, availableBytes, sizeBytes)
+}
+
+// RecordMetrics exports the usage of the nfspvc.
+func RecordMetrics(nfspvc danaiov1alpha1.NfsPvc) {
+	if nfspvc.Status.Usage == nil {
+		return
+	}
+	labels := prometheus.Labels{"namespace": nfspvc.Namespace, "nfspvc": nfspvc.Name}
+	usedBytes.With(labels).Set(float64(nfspvc.Status.Usage.UsedBytes))
+	availableBytes.With(labels).Set(float64(nfspvc.Status.Usage.AvailableBytes))
+	sizeBytes.With(labels).Set(float64(Size(nfspvc)))
+}
+
+// ForgetMetrics removes the metrics of the nfspvc.
+func ForgetMetrics(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "nfspvc": name}
+	usedBytes.Delete(labels)
+	availableBytes.Delete(labels)
+	sizeBytes.Delete(labels)
+}
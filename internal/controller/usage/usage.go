@@ -0,0 +1,48 @@
+package usage
+
+import (
+	"fmt"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Reasons of the NearlyFull condition.
+	AboveThresholdReason = "AboveThreshold"
+	BelowThresholdReason = "BelowThreshold"
+)
+
+// Size returns the size of the file system of the export of the nfspvc, the space used and available
+// on it. The used space is measured on the same file system, so the capacity of the nfspvc, which the
+// storage array does not enforce on a shared file system, is not compared with it.
+func Size(nfspvc danaiov1alpha1.NfsPvc) int64 {
+	return nfspvc.Status.Usage.UsedBytes + nfspvc.Status.Usage.AvailableBytes
+}
+
+// Percent returns the percentage of the size of the nfspvc that is used.
+func Percent(nfspvc danaiov1alpha1.NfsPvc) int64 {
+	size := Size(nfspvc)
+	if size <= 0 {
+		return 0
+	}
+	return nfspvc.Status.Usage.UsedBytes * 100 / size
+}
+
+// Condition returns the NearlyFull condition of an nfspvc with a usage, which is True once the
+// used percentage of its size reaches the threshold.
+func Condition(nfspvc danaiov1alpha1.NfsPvc, threshold int64) metav1.Condition {
+	percent := Percent(nfspvc)
+	condition := metav1.Condition{
+		Type:    danaiov1alpha1.NearlyFullNfsPvcCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  BelowThresholdReason,
+		Message: fmt.Sprintf("%d%% of %d bytes is used, below the %d%% threshold", percent, Size(nfspvc), threshold),
+	}
+	if percent >= threshold {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = AboveThresholdReason
+		condition.Message = fmt.Sprintf("%d%% of %d bytes is used, past the %d%% threshold", percent, Size(nfspvc), threshold)
+	}
+	return condition
+}
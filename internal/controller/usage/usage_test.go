@@ -0,0 +1,51 @@
+package usage
+
+import (
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Condition", func() {
+	const gi = int64(1024 * 1024 * 1024)
+
+	nfspvc := func(capacity string, used, available int64) danaiov1alpha1.NfsPvc {
+		return danaiov1alpha1.NfsPvc{
+			Spec: danaiov1alpha1.NfsPvcSpec{
+				Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(capacity)},
+			},
+			Status: danaiov1alpha1.NfsPvcStatus{
+				Usage: &danaiov1alpha1.NfsPvcUsage{UsedBytes: used, AvailableBytes: available},
+			},
+		}
+	}
+
+	It("should compare the usage with the file system of the export", func() {
+		full := nfspvc("200Gi", 185*gi, 15*gi)
+		Expect(Size(full)).To(Equal(200 * gi))
+		Expect(Percent(full)).To(Equal(int64(92)))
+		condition := Condition(full, 90)
+		Expect(condition.Type).To(Equal(danaiov1alpha1.NearlyFullNfsPvcCondition))
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(AboveThresholdReason))
+
+		condition = Condition(nfspvc("200Gi", 100*gi, 100*gi), 90)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(BelowThresholdReason))
+	})
+
+	It("should ignore the capacity of an nfspvc on a larger file system", func() {
+		shared := nfspvc("200Gi", 185*gi, 815*gi)
+		Expect(Size(shared)).To(Equal(1000 * gi))
+		Expect(Percent(shared)).To(Equal(int64(18)))
+		Expect(Condition(shared, 90).Status).To(Equal(metav1.ConditionFalse))
+	})
+
+	It("should not divide by an empty size", func() {
+		Expect(Percent(nfspvc("0", 0, 0))).To(BeZero())
+		Expect(Condition(nfspvc("0", 0, 0), 90).Status).To(Equal(metav1.ConditionFalse))
+	})
+})
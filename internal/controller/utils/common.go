@@ -2,34 +2,74 @@ package utils
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
+	"github.com/dana-team/nfspvc-operator/internal/controller/jobs"
+	"github.com/dana-team/nfspvc-operator/internal/nfsprobe"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"golang.org/x/exp/slices"
 )
 
 const (
-	StorageClassEnv  = "STORAGE_CLASS"
-	ReclaimPolicyEnv = "RECLAIM_POLICY"
+	StorageClassEnv          = "STORAGE_CLASS"
+	ReclaimPolicyEnv         = "RECLAIM_POLICY"
+	AllowedNfsVersionsEnv    = "ALLOWED_NFS_VERSIONS"
+	DataMoverImageEnv        = "DATA_MOVER_IMAGE"
+	ArchiveServerEnv         = "ARCHIVE_SERVER"
+	ArchivePathEnv           = "ARCHIVE_PATH"
+	StorageBackendEnv        = "STORAGE_BACKEND"
+	UsageWarningThresholdEnv = "USAGE_WARNING_THRESHOLD"
 
 	UndefinedEnvironmentVariableMsg = "failed to get configuration environment variable"
 	InvalidReclaimPolicyMsg         = "invalid default Persistent Volume Reclaim Policy"
+	InvalidAllowedNfsVersionsMsg    = "invalid list of NFS versions allowed for negotiation"
+	UndefinedArchiveExportMsg       = "the Archive Reclaim Policy requires the ARCHIVE_SERVER and ARCHIVE_PATH environment variables"
+	InvalidUsageWarningThresholdMsg = "invalid usage warning threshold, it must be a percentage between 1 and 100"
 
-	NfsPvcDeletionFinalizer = "nfspvc.dana.io/nfspvc-protection"
+	// ScrubReclaimPolicy wipes the export of a deleted NfsPvc with a Job.
+	ScrubReclaimPolicy corev1.PersistentVolumeReclaimPolicy = "Scrub"
+	// ArchiveReclaimPolicy tars the export of a deleted NfsPvc into the archive export, then wipes it, with a Job.
+	ArchiveReclaimPolicy corev1.PersistentVolumeReclaimPolicy = "Archive"
+
+	NfsPvcDeletionFinalizer    = "nfspvc.dana.io/nfspvc-protection"
+	NfsPvcSetDeletionFinalizer = "nfspvc.dana.io/nfspvcset-protection"
+
+	NfsPvcSnapshotDeletionFinalizer  = "nfspvc.dana.io/nfspvcsnapshot-protection"
+	NfsPvcMigrationDeletionFinalizer = "nfspvc.dana.io/nfspvcmigration-protection"
 )
 
+// AllowedReclaimPolicies are the reclaim policies of the NfsPvcs. Recycle is not implemented by Kubernetes
+// for NFS, and is still accepted as the deprecated name of Scrub.
 var AllowedReclaimPolicies = []corev1.PersistentVolumeReclaimPolicy{
-	corev1.PersistentVolumeReclaimRecycle,
 	corev1.PersistentVolumeReclaimDelete,
 	corev1.PersistentVolumeReclaimRetain,
+	ScrubReclaimPolicy,
+	ArchiveReclaimPolicy,
+	corev1.PersistentVolumeReclaimRecycle,
 }
 var ReclaimPolicy string
+
+// ArchiveServer and ArchivePath are the export the Archive Reclaim Policy writes to.
+var ArchiveServer, ArchivePath string
 var StorageClass string
 
+// DataMoverImage is the image of the data mover Jobs.
+var DataMoverImage = jobs.DefaultImage
+
+// UsageWarningThreshold is the used percentage of an NfsPvc from which its NearlyFull condition is True.
+var UsageWarningThreshold int64 = 90
+
+// AllowedNfsVersions are the NFS versions an "auto" nfspvc may be negotiated to, all of them by default.
+var AllowedNfsVersions = nfsprobe.Versions
+
 // VerifyEnvironmentVariables ensures the StorageClass and ReclaimPolicy env variables are set and valid.
 func VerifyEnvironmentVariables() (bool, string) {
 	storageClass, ok := os.LookupEnv(StorageClassEnv)
@@ -41,16 +81,89 @@ func VerifyEnvironmentVariables() (bool, string) {
 	if !ok {
 		return false, UndefinedEnvironmentVariableMsg
 	}
-	if !isReclaimPolicyValid(reclaimPolicy) {
+	if !IsReclaimPolicyValid(reclaimPolicy) {
 		return false, InvalidReclaimPolicyMsg
 	}
+	if corev1.PersistentVolumeReclaimPolicy(reclaimPolicy) == corev1.PersistentVolumeReclaimRecycle {
+		log.Log.WithName("configuration").Info(fmt.Sprintf("the %s reclaim policy is deprecated, %s is used in its place", reclaimPolicy, ScrubReclaimPolicy))
+	}
+	reclaimPolicy = NormalizeReclaimPolicy(reclaimPolicy)
 	ReclaimPolicy = reclaimPolicy
+	if corev1.PersistentVolumeReclaimPolicy(reclaimPolicy) == ArchiveReclaimPolicy {
+		ArchiveServer, ArchivePath = os.Getenv(ArchiveServerEnv), os.Getenv(ArchivePathEnv)
+		if ArchiveServer == "" || ArchivePath == "" {
+			return false, UndefinedArchiveExportMsg
+		}
+	}
+	if allowedNfsVersions, ok := os.LookupEnv(AllowedNfsVersionsEnv); ok {
+		versions, valid := ParseNfsVersions(allowedNfsVersions)
+		if !valid {
+			return false, InvalidAllowedNfsVersionsMsg
+		}
+		AllowedNfsVersions = versions
+	}
+	if threshold, ok := os.LookupEnv(UsageWarningThresholdEnv); ok && threshold != "" {
+		percent, err := strconv.ParseInt(threshold, 10, 64)
+		if err != nil || percent < 1 || percent > 100 {
+			return false, InvalidUsageWarningThresholdMsg
+		}
+		UsageWarningThreshold = percent
+	}
+	if image, ok := os.LookupEnv(DataMoverImageEnv); ok && image != "" {
+		DataMoverImage = image
+	}
 
 	return true, ""
 }
 
-// isReclaimPolicyValid checks if given reclaimPolicy is one of the AllowedReclaimPolicies.
-func isReclaimPolicyValid(reclaimPolicy string) bool {
+// ParseNfsVersions parses a comma separated list of NFS versions, all of which must be supported.
+func ParseNfsVersions(value string) ([]string, bool) {
+	var versions []string
+	for _, version := range strings.Split(value, ",") {
+		version = strings.TrimSpace(version)
+		if !slices.Contains(nfsprobe.Versions, version) {
+			return nil, false
+		}
+		versions = append(versions, version)
+	}
+	return versions, len(versions) > 0
+}
+
+// PVReclaimPolicy returns the reclaim policy of the PVs. The policies carried out by the operator
+// retain the PV, since Kubernetes does not know them.
+func PVReclaimPolicy() string {
+	return PVReclaimPolicyOf(ReclaimPolicy)
+}
+
+// PVReclaimPolicyOf returns the reclaim policy of the PVs under the given reclaim policy of the operator.
+func PVReclaimPolicyOf(reclaimPolicy string) string {
+	if isOperatorReclaimPolicy(reclaimPolicy) {
+		return string(corev1.PersistentVolumeReclaimRetain)
+	}
+	return reclaimPolicy
+}
+
+// IsOperatorReclaimPolicy returns true if the reclaim policy is carried out by the operator with a Job.
+func IsOperatorReclaimPolicy() bool {
+	return isOperatorReclaimPolicy(ReclaimPolicy)
+}
+
+// isOperatorReclaimPolicy returns true if the given reclaim policy is carried out by the operator.
+func isOperatorReclaimPolicy(reclaimPolicy string) bool {
+	policy := corev1.PersistentVolumeReclaimPolicy(reclaimPolicy)
+	return policy == ScrubReclaimPolicy || policy == ArchiveReclaimPolicy
+}
+
+// NormalizeReclaimPolicy returns the reclaim policy carrying out the given one, Scrub for the deprecated Recycle.
+func NormalizeReclaimPolicy(reclaimPolicy string) string {
+	if corev1.PersistentVolumeReclaimPolicy(reclaimPolicy) == corev1.PersistentVolumeReclaimRecycle {
+		return string(ScrubReclaimPolicy)
+	}
+	return reclaimPolicy
+}
+
+// IsReclaimPolicyValid checks if given reclaimPolicy is one of the AllowedReclaimPolicies.
+func IsReclaimPolicyValid(reclaimPolicy string) bool {
 	policy := corev1.PersistentVolumeReclaimPolicy(reclaimPolicy)
 	return slices.Contains(AllowedReclaimPolicies, policy)
 }
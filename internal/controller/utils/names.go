@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+// MaxLabelValueLength is the maximum length of a label value, and so of the name of a Job.
+const MaxLabelValueLength = 63
+
+// ShortenName keeps a name within maxLength characters, replacing its end with a hash of the full name.
+func ShortenName(name string, maxLength int) string {
+	if len(name) <= maxLength {
+		return name
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(name))
+	return strings.TrimRight(name[:maxLength-9], "-.") + "-" + fmt.Sprintf("%08x", hash.Sum32())
+}
@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	MaxConcurrentReconcilesEnv = "MAX_CONCURRENT_RECONCILES"
+	RateLimiterBaseDelayEnv    = "RATE_LIMITER_BASE_DELAY"
+	RateLimiterMaxDelayEnv     = "RATE_LIMITER_MAX_DELAY"
+	RateLimiterQPSEnv          = "RATE_LIMITER_QPS"
+	RateLimiterBurstEnv        = "RATE_LIMITER_BURST"
+	CacheSyncTimeoutEnv        = "CACHE_SYNC_TIMEOUT"
+)
+
+// ControllerOptions tunes the concurrency and the rate limiting of the controllers.
+type ControllerOptions struct {
+	MaxConcurrentReconciles int
+	RateLimiterBaseDelay    time.Duration
+	RateLimiterMaxDelay     time.Duration
+	RateLimiterQPS          int
+	RateLimiterBurst        int
+	CacheSyncTimeout        time.Duration
+}
+
+// DefaultControllerOptions returns the controller-runtime defaults.
+func DefaultControllerOptions() ControllerOptions {
+	return ControllerOptions{
+		MaxConcurrentReconciles: 1,
+		RateLimiterBaseDelay:    5 * time.Millisecond,
+		RateLimiterMaxDelay:     1000 * time.Second,
+		RateLimiterQPS:          10,
+		RateLimiterBurst:        100,
+		CacheSyncTimeout:        2 * time.Minute,
+	}
+}
+
+// BindFlags registers the controller options flags. The defaults are taken from the
+// configuration environment variables when they are set, so a flag overrides the ConfigMap.
+func (o *ControllerOptions) BindFlags(fs *flag.FlagSet) error {
+	defaults := DefaultControllerOptions()
+	if err := intFromEnv(MaxConcurrentReconcilesEnv, &defaults.MaxConcurrentReconciles); err != nil {
+		return err
+	}
+	if err := durationFromEnv(RateLimiterBaseDelayEnv, &defaults.RateLimiterBaseDelay); err != nil {
+		return err
+	}
+	if err := durationFromEnv(RateLimiterMaxDelayEnv, &defaults.RateLimiterMaxDelay); err != nil {
+		return err
+	}
+	if err := intFromEnv(RateLimiterQPSEnv, &defaults.RateLimiterQPS); err != nil {
+		return err
+	}
+	if err := intFromEnv(RateLimiterBurstEnv, &defaults.RateLimiterBurst); err != nil {
+		return err
+	}
+	if err := durationFromEnv(CacheSyncTimeoutEnv, &defaults.CacheSyncTimeout); err != nil {
+		return err
+	}
+
+	fs.IntVar(&o.MaxConcurrentReconciles, "max-concurrent-reconciles", defaults.MaxConcurrentReconciles,
+		"The maximum number of concurrent reconciles of each controller.")
+	fs.DurationVar(&o.RateLimiterBaseDelay, "rate-limiter-base-delay", defaults.RateLimiterBaseDelay,
+		"The base delay of the per-item exponential backoff of failed reconciles.")
+	fs.DurationVar(&o.RateLimiterMaxDelay, "rate-limiter-max-delay", defaults.RateLimiterMaxDelay,
+		"The maximum delay of the per-item exponential backoff of failed reconciles.")
+	fs.IntVar(&o.RateLimiterQPS, "rate-limiter-qps", defaults.RateLimiterQPS,
+		"The overall number of reconciles per second allowed by the bucket rate limiter.")
+	fs.IntVar(&o.RateLimiterBurst, "rate-limiter-burst", defaults.RateLimiterBurst,
+		"The burst size of the bucket rate limiter.")
+	fs.DurationVar(&o.CacheSyncTimeout, "cache-sync-timeout", defaults.CacheSyncTimeout,
+		"The time limit for waiting for the caches of a controller to sync.")
+	return nil
+}
+
+// Validate returns an error if one of the options is out of range.
+func (o ControllerOptions) Validate() error {
+	if o.MaxConcurrentReconciles < 1 {
+		return fmt.Errorf("max-concurrent-reconciles must be at least 1, got %d", o.MaxConcurrentReconciles)
+	}
+	if o.RateLimiterBaseDelay <= 0 || o.RateLimiterMaxDelay < o.RateLimiterBaseDelay {
+		return fmt.Errorf("rate limiter delays must satisfy 0 < base (%s) <= max (%s)", o.RateLimiterBaseDelay, o.RateLimiterMaxDelay)
+	}
+	if o.RateLimiterQPS < 1 || o.RateLimiterBurst < 1 {
+		return fmt.Errorf("rate limiter qps (%d) and burst (%d) must be at least 1", o.RateLimiterQPS, o.RateLimiterBurst)
+	}
+	if o.CacheSyncTimeout <= 0 {
+		return fmt.Errorf("cache-sync-timeout must be positive, got %s", o.CacheSyncTimeout)
+	}
+	return nil
+}
+
+// Options returns the controller options. Every call builds a new rate limiter,
+// so that controllers do not share a bucket.
+func (o ControllerOptions) Options() controller.Options {
+	return controller.Options{
+		MaxConcurrentReconciles: o.MaxConcurrentReconciles,
+		CacheSyncTimeout:        o.CacheSyncTimeout,
+		RateLimiter: workqueue.NewTypedMaxOfRateLimiter(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](o.RateLimiterBaseDelay, o.RateLimiterMaxDelay),
+			&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(o.RateLimiterQPS), o.RateLimiterBurst)},
+		),
+	}
+}
+
+// intFromEnv sets value from the environment variable, if it is set.
+func intFromEnv(name string, value *int) error {
+	raw, ok := os.LookupEnv(name)
+	if !ok || raw == "" {
+		return nil
+	}
+	parsed, err := strconv.Atoi(raw)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %v", name, err)
+	}
+	*value = parsed
+	return nil
+}
+
+// durationFromEnv sets value from the environment variable, if it is set.
+func durationFromEnv(name string, value *time.Duration) error {
+	raw, ok := os.LookupEnv(name)
+	if !ok || raw == "" {
+		return nil
+	}
+	parsed, err := time.ParseDuration(raw)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %v", name, err)
+	}
+	*value = parsed
+	return nil
+}
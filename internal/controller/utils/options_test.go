@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"flag"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ControllerOptions", func() {
+	// setenv sets the environment variable for the current spec only.
+	setenv := func(name, value string) {
+		Expect(os.Setenv(name, value)).To(Succeed())
+		DeferCleanup(os.Unsetenv, name)
+	}
+
+	bind := func(args ...string) (ControllerOptions, error) {
+		options := ControllerOptions{}
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		if err := options.BindFlags(fs); err != nil {
+			return options, err
+		}
+		return options, fs.Parse(args)
+	}
+
+	It("should default to the controller-runtime defaults", func() {
+		options, err := bind()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(options).To(Equal(DefaultControllerOptions()))
+		Expect(options.Validate()).To(Succeed())
+	})
+
+	It("should take the defaults from the environment", func() {
+		setenv(MaxConcurrentReconcilesEnv, "4")
+		setenv(RateLimiterBaseDelayEnv, "10ms")
+		setenv(RateLimiterMaxDelayEnv, "5m")
+		setenv(RateLimiterQPSEnv, "50")
+		setenv(RateLimiterBurstEnv, "")
+		setenv(CacheSyncTimeoutEnv, "30s")
+		options, err := bind()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(options).To(Equal(ControllerOptions{
+			MaxConcurrentReconciles: 4,
+			RateLimiterBaseDelay:    10 * time.Millisecond,
+			RateLimiterMaxDelay:     5 * time.Minute,
+			RateLimiterQPS:          50,
+			RateLimiterBurst:        100,
+			CacheSyncTimeout:        30 * time.Second,
+		}))
+	})
+
+	It("should let a flag override the environment", func() {
+		setenv(MaxConcurrentReconcilesEnv, "4")
+		options, err := bind("--max-concurrent-reconciles=8")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(options.MaxConcurrentReconciles).To(Equal(8))
+	})
+
+	It("should reject an invalid environment variable", func() {
+		setenv(RateLimiterQPSEnv, "fast")
+		_, err := bind()
+		Expect(err).To(MatchError(ContainSubstring(RateLimiterQPSEnv)))
+		setenv(RateLimiterQPSEnv, "")
+		setenv(CacheSyncTimeoutEnv, "30")
+		_, err = bind()
+		Expect(err).To(MatchError(ContainSubstring(CacheSyncTimeoutEnv)))
+	})
+
+	DescribeTable("should reject the options out of range",
+		func(mutate func(*ControllerOptions)) {
+			options := DefaultControllerOptions()
+			mutate(&options)
+			Expect(options.Validate()).NotTo(Succeed())
+		},
+		Entry("no concurrent reconcile", func(o *ControllerOptions) { o.MaxConcurrentReconciles = 0 }),
+		Entry("a zero base delay", func(o *ControllerOptions) { o.RateLimiterBaseDelay = 0 }),
+		Entry("a max delay below the base delay", func(o *ControllerOptions) { o.RateLimiterMaxDelay = time.Millisecond }),
+		Entry("a zero qps", func(o *ControllerOptions) { o.RateLimiterQPS = 0 }),
+		Entry("a zero burst", func(o *ControllerOptions) { o.RateLimiterBurst = 0 }),
+		Entry("a negative cache sync timeout", func(o *ControllerOptions) { o.CacheSyncTimeout = -time.Second }),
+	)
+})
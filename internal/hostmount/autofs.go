@@ -0,0 +1,189 @@
+package hostmount
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// DirectMountPoint is the mount point of the master map entries of direct maps, whose keys are the
+// mount points.
+const DirectMountPoint = "/-"
+
+// MasterEntry is an entry of an autofs master map: the map of the mounts under a mount point.
+type MasterEntry struct {
+	MountPoint string
+	// Map is the file of the map, as written in the master map without its "file:" type.
+	Map     string
+	Options []string
+	Source  string
+}
+
+// ParseMaster returns the entries of an autofs master map, auto.master. The entries of maps that are
+// not files, such as -hosts, or LDAP and NIS maps, are reported as issues.
+func ParseMaster(r io.Reader, name string) ([]MasterEntry, []Issue, error) {
+	lines, err := readMapLines(r, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	var entries []MasterEntry
+	var issues []Issue
+	for _, l := range lines {
+		fields := strings.Fields(l.text)
+		if strings.HasPrefix(fields[0], "+") {
+			issues = append(issues, Issue{Source: l.source, Message: fmt.Sprintf("the included map %q is not supported", strings.TrimPrefix(fields[0], "+"))})
+			continue
+		}
+		if len(fields) < 2 {
+			issues = append(issues, Issue{Source: l.source, Message: "expected a mount point and a map"})
+			continue
+		}
+		mapName := fields[1]
+		if mapType, file, ok := strings.Cut(mapName, ":"); ok && !strings.HasPrefix(mapName, "/") {
+			if mapType, _, _ = strings.Cut(mapType, ","); mapType != "file" {
+				issues = append(issues, Issue{Source: l.source, Message: fmt.Sprintf("the %s map %q is not supported, only file maps are", mapType, file)})
+				continue
+			}
+			mapName = file
+		}
+		if strings.HasPrefix(mapName, "-") {
+			issues = append(issues, Issue{Source: l.source, Message: fmt.Sprintf("the built-in map %q is not supported", mapName)})
+			continue
+		}
+		entries = append(entries, MasterEntry{
+			MountPoint: fields[0],
+			Map:        mapName,
+			Options:    mapOptions(fields[2:]),
+			Source:     l.source,
+		})
+	}
+	return entries, issues, nil
+}
+
+// ParseMap returns the NFS mounts of the autofs map of a master map entry. The options of the entry
+// apply to all its mounts, before their own options. Wildcard keys, multi-mounts and replicated
+// servers cannot be converted and are reported as issues.
+func ParseMap(r io.Reader, name string, entry MasterEntry) ([]Mount, []Issue, error) {
+	lines, err := readMapLines(r, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	var mounts []Mount
+	var issues []Issue
+	for _, l := range lines {
+		fields := strings.Fields(l.text)
+		key := fields[0]
+		if strings.HasPrefix(key, "+") {
+			issues = append(issues, Issue{Source: l.source, Message: fmt.Sprintf("the included map %q is not supported", strings.TrimPrefix(key, "+"))})
+			continue
+		}
+		options := entry.Options
+		locations := fields[1:]
+		if len(locations) > 0 && strings.HasPrefix(locations[0], "-") {
+			options = append(append([]string{}, options...), mapOptions(locations[:1])...)
+			locations = locations[1:]
+		}
+		switch {
+		case key == "*":
+			issues = append(issues, Issue{Source: l.source, Message: "wildcard keys cannot be converted, add a key per directory"})
+			continue
+		case len(locations) == 0:
+			issues = append(issues, Issue{Source: l.source, Message: "expected a key and a location"})
+			continue
+		case len(locations) > 1 || strings.HasPrefix(locations[0], "/"):
+			issues = append(issues, Issue{Source: l.source, Message: "multi-mount and replicated entries are not supported"})
+			continue
+		}
+
+		fsType := "nfs"
+		var nfsOptions []string
+		for _, option := range options {
+			if value, ok := strings.CutPrefix(option, "fstype="); ok {
+				fsType = value
+				continue
+			}
+			nfsOptions = append(nfsOptions, option)
+		}
+		if fsType != "nfs" && fsType != "nfs4" {
+			continue
+		}
+		if fsType == "nfs4" {
+			nfsOptions = append([]string{"nfsvers=4"}, nfsOptions...)
+		}
+		server, exportPath, ok := splitExport(locations[0])
+		if !ok {
+			issues = append(issues, Issue{Source: l.source, Message: fmt.Sprintf("%q is not an NFS export of the form server:/path", locations[0])})
+			continue
+		}
+		mountPoint := key
+		if entry.MountPoint != DirectMountPoint {
+			mountPoint = path.Join(entry.MountPoint, key)
+		}
+		mounts = append(mounts, Mount{
+			Server:     server,
+			Path:       exportPath,
+			MountPoint: mountPoint,
+			Options:    nfsOptions,
+			Source:     l.source,
+		})
+	}
+	return mounts, issues, nil
+}
+
+// mapLine is a logical line of an autofs map, with its continuation lines.
+type mapLine struct {
+	text   string
+	source string
+}
+
+// readMapLines returns the non-empty lines of an autofs map without their comments, joining the lines
+// ending with a backslash with the next ones.
+func readMapLines(r io.Reader, name string) ([]mapLine, error) {
+	var lines []mapLine
+	current := mapLine{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if current.source == "" {
+			if strings.HasPrefix(strings.TrimSpace(text), "#") {
+				continue
+			}
+			current.source = source(name, line)
+		}
+		continued := strings.HasSuffix(text, `\`)
+		current.text += " " + strings.TrimSuffix(text, `\`)
+		if continued {
+			continue
+		}
+		if strings.TrimSpace(current.text) != "" {
+			lines = append(lines, current)
+		}
+		current = mapLine{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", name, err)
+	}
+	if strings.TrimSpace(current.text) != "" {
+		lines = append(lines, current)
+	}
+	return lines, nil
+}
+
+// mapOptions returns the mount options of the fields of an autofs map, written as -option,option.
+// The options of the automounter, such as --timeout, are skipped.
+func mapOptions(fields []string) []string {
+	var options []string
+	for _, field := range fields {
+		if !strings.HasPrefix(field, "-") || strings.HasPrefix(field, "--") {
+			continue
+		}
+		for _, option := range strings.Split(strings.TrimPrefix(field, "-"), ",") {
+			if option != "" {
+				options = append(options, option)
+			}
+		}
+	}
+	return options
+}
@@ -0,0 +1,110 @@
+package hostmount
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// hostOptions only configure how the host mounts the export, and have no meaning for a PV.
+var hostOptions = []string{"defaults", "auto", "noauto", "_netdev", "nofail", "user", "nouser", "users", "owner", "group", "bg", "fg"}
+
+// invalidNameCharacters are replaced in the names of the nfspvcs.
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// maxNameLength leaves room for the namespace in the name of the PV of an nfspvc.
+const maxNameLength = 63
+
+// Convert returns the nfspvcs of the namespace mounting the exports of the mounts with the capacity,
+// one per export, in the order of the mounts. The nfspvc of an export mounted several times is
+// read-write when one of its mounts is. The options an nfspvc cannot express, and the mounts of an
+// export with different NFS versions or security, are reported as issues.
+func Convert(mounts []Mount, namespace string, capacity resource.Quantity) ([]danaiov1alpha1.NfsPvc, []Issue) {
+	var nfspvcs []danaiov1alpha1.NfsPvc
+	var issues []Issue
+	exports := map[string]int{}
+	sources := map[string]string{}
+	for _, mount := range mounts {
+		spec, unsupported := mountSpec(mount, capacity)
+		if len(unsupported) > 0 {
+			issues = append(issues, Issue{Source: mount.Source, Message: fmt.Sprintf("the nfspvc cannot express the mount options %s", strings.Join(unsupported, ","))})
+		}
+
+		export := spec.Server + ":" + spec.Path
+		if i, ok := exports[export]; ok {
+			existing := &nfspvcs[i].Spec
+			if existing.NfsVersion != spec.NfsVersion || !equality.Semantic.DeepEqual(existing.Security, spec.Security) {
+				issues = append(issues, Issue{Source: mount.Source, Message: fmt.Sprintf("%s is also mounted by %s with another NFS version or security, which is kept", export, sources[export])})
+			}
+			if slices.Contains(spec.AccessModes, corev1.ReadWriteMany) {
+				existing.AccessModes = spec.AccessModes
+			}
+			continue
+		}
+		exports[export] = len(nfspvcs)
+		sources[export] = mount.Source
+		nfspvcs = append(nfspvcs, danaiov1alpha1.NfsPvc{
+			TypeMeta:   metav1.TypeMeta{APIVersion: danaiov1alpha1.GroupVersion.String(), Kind: "NfsPvc"},
+			ObjectMeta: metav1.ObjectMeta{Name: uniqueName(nfspvcs, mountName(mount)), Namespace: namespace},
+			Spec:       spec,
+		})
+	}
+	return nfspvcs, issues
+}
+
+// mountSpec returns the spec of the nfspvc of a mount, and the options it cannot express.
+func mountSpec(mount Mount, capacity resource.Quantity) (danaiov1alpha1.NfsPvcSpec, []string) {
+	spec := danaiov1alpha1.NfsPvcSpec{
+		Server:      mount.Server,
+		Path:        mount.Path,
+		Capacity:    corev1.ResourceList{corev1.ResourceStorage: capacity},
+		AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+	}
+	var options []string
+	for _, option := range strings.Split(strings.Join(mount.Options, ","), ",") {
+		option = strings.TrimSpace(option)
+		switch {
+		case option == "ro":
+			spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadOnlyMany}
+		case option == "rw":
+			spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+		case slices.Contains(hostOptions, option) || strings.HasPrefix(option, "x-systemd.") || strings.HasPrefix(option, "comment="):
+		default:
+			options = append(options, option)
+		}
+	}
+	return spec, resources.ApplyMountOptions(&spec, options)
+}
+
+// mountName returns the name of the nfspvc of a mount, after its mount point, or its export.
+func mountName(mount Mount) string {
+	for _, candidate := range []string{mount.MountPoint, mount.Path} {
+		name := invalidNameCharacters.ReplaceAllString(strings.ToLower(path.Base(candidate)), "-")
+		if name = strings.Trim(name, "-"); name != "" {
+			return strings.Trim(name[:min(len(name), maxNameLength)], "-")
+		}
+	}
+	return "nfspvc"
+}
+
+// uniqueName returns the name with a numbered suffix when an nfspvc already has it.
+func uniqueName(nfspvcs []danaiov1alpha1.NfsPvc, name string) string {
+	taken := func(candidate string) bool {
+		return slices.ContainsFunc(nfspvcs, func(nfspvc danaiov1alpha1.NfsPvc) bool { return nfspvc.Name == candidate })
+	}
+	candidate := name
+	for i := 2; taken(candidate); i++ {
+		suffix := fmt.Sprintf("-%d", i)
+		candidate = strings.Trim(name[:min(len(name), maxNameLength-len(suffix))], "-") + suffix
+	}
+	return candidate
+}
@@ -0,0 +1,56 @@
+package hostmount
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// fstabUnescaper decodes the octal escapes of the fields of an fstab.
+var fstabUnescaper = strings.NewReplacer(`\040`, " ", `\011`, "\t", `\012`, "\n", `\134`, `\`)
+
+// ParseFstab returns the NFS mounts of an fstab. The other file systems are skipped.
+func ParseFstab(r io.Reader, name string) ([]Mount, []Issue, error) {
+	var mounts []Mount
+	var issues []Issue
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 3 {
+			issues = append(issues, Issue{Source: source(name, line), Message: "expected a device, a mount point and a type"})
+			continue
+		}
+		fsType := fields[2]
+		if fsType != "nfs" && fsType != "nfs4" {
+			continue
+		}
+		server, path, ok := splitExport(fstabUnescaper.Replace(fields[0]))
+		if !ok {
+			issues = append(issues, Issue{Source: source(name, line), Message: fmt.Sprintf("%q is not an NFS export of the form server:/path", fields[0])})
+			continue
+		}
+		var options []string
+		if fsType == "nfs4" {
+			options = append(options, "nfsvers=4")
+		}
+		if len(fields) > 3 {
+			options = append(options, strings.Split(fields[3], ",")...)
+		}
+		mounts = append(mounts, Mount{
+			Server:     server,
+			Path:       path,
+			MountPoint: fstabUnescaper.Replace(fields[1]),
+			Options:    options,
+			Source:     source(name, line),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %v", name, err)
+	}
+	return mounts, issues, nil
+}
@@ -0,0 +1,53 @@
+package hostmount
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Mount is an NFS mount of a host, read from an fstab or an autofs map.
+type Mount struct {
+	Server     string
+	Path       string
+	MountPoint string
+	Options    []string
+	// Source is the file and the line of the mount, e.g. /etc/fstab:3.
+	Source string
+}
+
+// Issue is a line, or a mount, that cannot be converted exactly.
+type Issue struct {
+	Source  string
+	Message string
+}
+
+func (i Issue) String() string {
+	return i.Source + ": " + i.Message
+}
+
+// splitExport splits an NFS export of the form server:/path, where the server may be a bracketed
+// IPv6 address.
+func splitExport(export string) (string, string, bool) {
+	server, path := "", ""
+	if strings.HasPrefix(export, "[") {
+		end := strings.Index(export, "]:")
+		if end < 0 {
+			return "", "", false
+		}
+		server, path = export[1:end], export[end+2:]
+	} else {
+		var ok bool
+		if server, path, ok = strings.Cut(export, ":"); !ok {
+			return "", "", false
+		}
+	}
+	if server == "" || !strings.HasPrefix(path, "/") || strings.ContainsAny(server, ",()") {
+		return "", "", false
+	}
+	return server, path, true
+}
+
+// source returns the source of a line of a file.
+func source(name string, line int) string {
+	return fmt.Sprintf("%s:%d", name, line)
+}
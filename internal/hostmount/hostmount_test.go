@@ -0,0 +1,121 @@
+package hostmount
+
+import (
+	"strings"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const fstab = `# /etc/fstab
+UUID=1234  /      ext4  defaults  0 1
+nas:/vol/data      /mnt/data      nfs4  rw,minorversion=1,_netdev,hard  0 0
+nas:/vol/home      /home          nfs   vers=3,ro,x-systemd.automount  0 0
+[fd00::1]:/exports /mnt/My\040Share nfs sec=krb5p
+nas-only           /mnt/broken    nfs   defaults
+`
+
+const master = `# auto.master
+/net    -hosts
+/apps   /etc/auto.apps  -rw,fstype=nfs4 --timeout 60
+/-      file:auto.direct
++auto.master
+/ldap   ldap:ou=auto.ldap,dc=example
+`
+
+const appsMap = `# auto.apps
+billing   nas:/vol/billing
+reports   -ro,vers=4.2 \
+          nas:/vol/reports
+*         nas:/vol/&
+multi     / nas:/vol/a /sub nas:/vol/b
+backup    nas1,nas2:/vol/backup
+smb       -fstype=cifs ://fileserver/share
+`
+
+var _ = Describe("ParseFstab", func() {
+	It("should return the NFS mounts of an fstab", func() {
+		mounts, issues, err := ParseFstab(strings.NewReader(fstab), "fstab")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mounts).To(Equal([]Mount{
+			{Server: "nas", Path: "/vol/data", MountPoint: "/mnt/data", Options: []string{"nfsvers=4", "rw", "minorversion=1", "_netdev", "hard"}, Source: "fstab:3"},
+			{Server: "nas", Path: "/vol/home", MountPoint: "/home", Options: []string{"vers=3", "ro", "x-systemd.automount"}, Source: "fstab:4"},
+			{Server: "fd00::1", Path: "/exports", MountPoint: "/mnt/My Share", Options: []string{"sec=krb5p"}, Source: "fstab:5"},
+		}))
+		Expect(issues).To(ConsistOf(Issue{Source: "fstab:6", Message: `"nas-only" is not an NFS export of the form server:/path`}))
+	})
+})
+
+var _ = Describe("ParseMaster and ParseMap", func() {
+	It("should return the file maps of a master map", func() {
+		entries, issues, err := ParseMaster(strings.NewReader(master), "auto.master")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(Equal([]MasterEntry{
+			{MountPoint: "/apps", Map: "/etc/auto.apps", Options: []string{"rw", "fstype=nfs4"}, Source: "auto.master:3"},
+			{MountPoint: DirectMountPoint, Map: "auto.direct", Source: "auto.master:4"},
+		}))
+		Expect(issues).To(HaveLen(3))
+		Expect(issues[0].String()).To(Equal(`auto.master:2: the built-in map "-hosts" is not supported`))
+		Expect(issues[2].Message).To(ContainSubstring("the ldap map"))
+	})
+
+	It("should return the NFS mounts of a map with the options of its entry", func() {
+		entry := MasterEntry{MountPoint: "/apps", Options: []string{"rw", "fstype=nfs4"}}
+		mounts, issues, err := ParseMap(strings.NewReader(appsMap), "auto.apps", entry)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mounts).To(Equal([]Mount{
+			{Server: "nas", Path: "/vol/billing", MountPoint: "/apps/billing", Options: []string{"nfsvers=4", "rw"}, Source: "auto.apps:2"},
+			{Server: "nas", Path: "/vol/reports", MountPoint: "/apps/reports", Options: []string{"nfsvers=4", "rw", "ro", "vers=4.2"}, Source: "auto.apps:3"},
+		}))
+		Expect(issues).To(HaveLen(3))
+		Expect(issues[0].Message).To(ContainSubstring("wildcard"))
+		Expect(issues[1].Message).To(ContainSubstring("multi-mount"))
+		Expect(issues[2].Source).To(Equal("auto.apps:7"))
+	})
+
+	It("should use the keys of a direct map as mount points", func() {
+		mounts, _, err := ParseMap(strings.NewReader("/srv/data  nas:/vol/data\n"), "auto.direct", MasterEntry{MountPoint: DirectMountPoint})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mounts).To(ConsistOf(HaveField("MountPoint", "/srv/data")))
+	})
+})
+
+var _ = Describe("Convert", func() {
+	It("should return an nfspvc per export with the options mapped onto its spec", func() {
+		mounts, _, err := ParseFstab(strings.NewReader(fstab), "fstab")
+		Expect(err).NotTo(HaveOccurred())
+		mounts = append(mounts, Mount{Server: "nas", Path: "/vol/home", MountPoint: "/srv/home", Options: []string{"vers=3", "rw"}, Source: "other:1"})
+
+		nfspvcs, issues := Convert(mounts, "team-a", resource.MustParse("5Gi"))
+		Expect(nfspvcs).To(HaveLen(3))
+		data, home, share := nfspvcs[0], nfspvcs[1], nfspvcs[2]
+		Expect(data.Name).To(Equal("data"))
+		Expect(data.Namespace).To(Equal("team-a"))
+		Expect(data.Kind).To(Equal("NfsPvc"))
+		Expect(data.Spec.NfsVersion).To(Equal("4.1"))
+		Expect(data.Spec.Capacity.Storage().String()).To(Equal("5Gi"))
+		Expect(home.Spec.NfsVersion).To(Equal("3"))
+		Expect(home.Spec.AccessModes).To(Equal([]corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}))
+		Expect(share.Name).To(Equal("my-share"))
+		Expect(share.Spec.Server).To(Equal("fd00::1"))
+		Expect(share.Spec.Security.Sec).To(Equal(danaiov1alpha1.Krb5pNfsSecurityFlavor))
+		Expect(issues).To(ConsistOf(Issue{Source: "fstab:3", Message: "the nfspvc cannot express the mount options hard"}))
+	})
+
+	It("should report an export mounted with different versions", func() {
+		mounts := []Mount{
+			{Server: "nas", Path: "/vol/data", MountPoint: "/data", Options: []string{"vers=3", "ro"}, Source: "a:1"},
+			{Server: "nas", Path: "/vol/data", MountPoint: "/data", Options: []string{"vers=4.2", "ro"}, Source: "b:1"},
+			{Server: "nas", Path: "/vol/other", MountPoint: "/data", Source: "c:1"},
+		}
+		nfspvcs, issues := Convert(mounts, "team-a", resource.MustParse("1Gi"))
+		Expect(nfspvcs).To(HaveLen(2))
+		Expect(nfspvcs[0].Spec.NfsVersion).To(Equal("3"))
+		Expect(nfspvcs[0].Spec.AccessModes).To(Equal([]corev1.PersistentVolumeAccessMode{corev1.ReadOnlyMany}))
+		Expect(nfspvcs[1].Name).To(Equal("data-2"))
+		Expect(issues).To(ConsistOf(Issue{Source: "b:1", Message: "nas:/vol/data is also mounted by a:1 with another NFS version or security, which is kept"}))
+	})
+})
@@ -0,0 +1,14 @@
+package hostmount
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestHostmount(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "Hostmount Suite")
+}
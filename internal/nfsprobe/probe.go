@@ -0,0 +1,155 @@
+package nfsprobe
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultPort    = 2049
+	DefaultTimeout = 5 * time.Second
+
+	nfsProgram      = 100003
+	procNull        = 0
+	procCompound    = 1
+	nfs4OK          = 0
+	nfs4ErrMinorVer = 10021
+)
+
+// Versions are the NFS versions the prober knows about, from lowest to highest.
+var Versions = []string{"3", "4", "4.1", "4.2"}
+
+// minorVersions maps the NFSv4 minor versions to their nfsVersion value.
+var minorVersions = map[uint32]string{0: "4", 1: "4.1", 2: "4.2"}
+
+// Prober finds the NFS versions supported by a server by talking ONC RPC to its NFS service.
+// NFSv3 and NFSv4 support is detected with a NULL call, and the NFSv4 minor versions
+// with a COMPOUND call of no operations, which fails with NFS4ERR_MINOR_VERS_MISMATCH
+// when the minor version is not supported.
+type Prober struct {
+	// Port is the port of the NFS service, DefaultPort when zero.
+	Port int
+	// Timeout bounds every RPC call, DefaultTimeout when zero.
+	Timeout time.Duration
+
+	xid atomic.Uint32
+}
+
+// Supported returns the versions supported by the server, from lowest to highest.
+func (p *Prober) Supported(ctx context.Context, server string) ([]string, error) {
+	var supported []string
+	v3, err := p.null(ctx, server, 3)
+	if err != nil {
+		return nil, err
+	}
+	if v3 {
+		supported = append(supported, "3")
+	}
+
+	v4, err := p.null(ctx, server, 4)
+	if err != nil {
+		return nil, err
+	}
+	if v4 {
+		for minor := uint32(0); minor < uint32(len(minorVersions)); minor++ {
+			ok, err := p.compound(ctx, server, minor)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				supported = append(supported, minorVersions[minor])
+			}
+		}
+	}
+	return supported, nil
+}
+
+// null reports whether the server accepts a NULL call for the given NFS version.
+func (p *Prober) null(ctx context.Context, server string, version uint32) (bool, error) {
+	res, err := p.call(ctx, server, call{prog: nfsProgram, vers: version, proc: procNull})
+	if err != nil {
+		return false, err
+	}
+	return res.stat == acceptSuccess, nil
+}
+
+// compound reports whether the server accepts NFSv4 COMPOUND calls of the given minor version.
+func (p *Prober) compound(ctx context.Context, server string, minor uint32) (bool, error) {
+	args := &bytes.Buffer{}
+	putOpaque(args, nil)
+	putUint32(args, minor, 0)
+	res, err := p.call(ctx, server, call{prog: nfsProgram, vers: 4, proc: procCompound, payload: args.Bytes()})
+	if err != nil {
+		return false, err
+	}
+	if res.stat != acceptSuccess || len(res.payload) < 4 {
+		return false, nil
+	}
+	status := binary.BigEndian.Uint32(res.payload[:4])
+	return status != nfs4ErrMinorVer, nil
+}
+
+// call sends a single RPC call to the NFS service of the server over a new TCP connection.
+func (p *Prober) call(ctx context.Context, server string, c call) (reply, error) {
+	timeout := p.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	port := p.Port
+	if port == 0 {
+		port = DefaultPort
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(server, strconv.Itoa(port)))
+	if err != nil {
+		return reply{}, fmt.Errorf("failed to connect to nfs server %q: %v", server, err)
+	}
+	defer func() { _ = conn.Close() }()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	c.xid = p.xid.Add(1)
+	if _, err := conn.Write(encodeCall(c)); err != nil {
+		return reply{}, fmt.Errorf("failed to send rpc call to %q: %v", server, err)
+	}
+	message, err := readRecord(conn)
+	if err != nil {
+		return reply{}, fmt.Errorf("failed to read rpc reply from %q: %v", server, err)
+	}
+	res, err := decodeReply(message)
+	if err != nil {
+		return reply{}, err
+	}
+	if res.xid != c.xid {
+		return reply{}, fmt.Errorf("rpc reply xid %d does not match call xid %d", res.xid, c.xid)
+	}
+	return res, nil
+}
+
+// Negotiate returns the highest supported version that is also allowed.
+func Negotiate(supported, allowed []string) (string, error) {
+	isSupported := make(map[string]bool, len(supported))
+	for _, version := range supported {
+		isSupported[version] = true
+	}
+	isAllowed := make(map[string]bool, len(allowed))
+	for _, version := range allowed {
+		isAllowed[version] = true
+	}
+	for i := len(Versions) - 1; i >= 0; i-- {
+		if isSupported[Versions[i]] && isAllowed[Versions[i]] {
+			return Versions[i], nil
+		}
+	}
+	return "", fmt.Errorf("none of the supported nfs versions %v is allowed %v", supported, allowed)
+}
@@ -0,0 +1,151 @@
+package nfsprobe
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// standIn is a local RPC server that answers NULL and COMPOUND calls for the NFS program
+// like a server supporting the given versions.
+type standIn struct {
+	listener net.Listener
+	versions map[uint32]bool
+	minors   map[uint32]bool
+}
+
+func newStandIn(versions, minors []uint32) *standIn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	s := &standIn{listener: listener, versions: map[uint32]bool{}, minors: map[uint32]bool{}}
+	for _, version := range versions {
+		s.versions[version] = true
+	}
+	for _, minor := range minors {
+		s.minors[minor] = true
+	}
+	go s.serve()
+	return s
+}
+
+func (s *standIn) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *standIn) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer func() { _ = conn.Close() }()
+			for {
+				message, err := readRecord(conn)
+				if err != nil {
+					return
+				}
+				if _, err := conn.Write(s.handle(message)); err != nil {
+					return
+				}
+			}
+		}()
+	}
+}
+
+// handle decodes a call and encodes the reply of the stand-in.
+func (s *standIn) handle(message []byte) []byte {
+	r := bytes.NewReader(message)
+	var xid, msgType, rpcVers, prog, vers, proc, credFlavor, verfFlavor uint32
+	Expect(readUint32(r, &xid, &msgType, &rpcVers, &prog, &vers, &proc, &credFlavor)).To(Succeed())
+	_, err := readOpaque(r)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(readUint32(r, &verfFlavor)).To(Succeed())
+	_, err = readOpaque(r)
+	Expect(err).NotTo(HaveOccurred())
+
+	body := &bytes.Buffer{}
+	putUint32(body, xid, msgTypeReply, replyAccepted, authNone, 0)
+	switch {
+	case prog != nfsProgram:
+		putUint32(body, uint32(acceptProgUnavail))
+	case !s.versions[vers]:
+		putUint32(body, uint32(acceptProgMismatch), 3, 4)
+	case proc == procNull:
+		putUint32(body, uint32(acceptSuccess))
+	case proc == procCompound:
+		_, err := readOpaque(r)
+		Expect(err).NotTo(HaveOccurred())
+		var minor uint32
+		Expect(readUint32(r, &minor)).To(Succeed())
+		status := uint32(nfs4OK)
+		if !s.minors[minor] {
+			status = nfs4ErrMinorVer
+		}
+		putUint32(body, uint32(acceptSuccess), status)
+		putOpaque(body, nil)
+		putUint32(body, 0)
+	}
+
+	record := &bytes.Buffer{}
+	_ = binary.Write(record, binary.BigEndian, lastFragment|uint32(body.Len()))
+	record.Write(body.Bytes())
+	return record.Bytes()
+}
+
+var _ = Describe("Prober", func() {
+	It("should detect NFSv3 and the supported NFSv4 minor versions", func() {
+		server := newStandIn([]uint32{3, 4}, []uint32{0, 1})
+		defer func() { _ = server.listener.Close() }()
+
+		prober := &Prober{Port: server.port(), Timeout: time.Second}
+		supported, err := prober.Supported(context.Background(), "127.0.0.1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(supported).To(Equal([]string{"3", "4", "4.1"}))
+	})
+
+	It("should detect a server that only speaks NFSv4.2", func() {
+		server := newStandIn([]uint32{4}, []uint32{2})
+		defer func() { _ = server.listener.Close() }()
+
+		prober := &Prober{Port: server.port(), Timeout: time.Second}
+		supported, err := prober.Supported(context.Background(), "127.0.0.1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(supported).To(Equal([]string{"4.2"}))
+	})
+
+	It("should fail when the server is unreachable", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		port := listener.Addr().(*net.TCPAddr).Port
+		Expect(listener.Close()).To(Succeed())
+
+		prober := &Prober{Port: port, Timeout: time.Second}
+		_, err = prober.Supported(context.Background(), "127.0.0.1")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject a denied call", func() {
+		_, err := decodeReply([]byte{0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 1})
+		Expect(errors.Is(err, errRPCDenied)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Negotiate", func() {
+	It("should pick the highest supported version allowed by policy", func() {
+		version, err := Negotiate([]string{"3", "4", "4.1", "4.2"}, []string{"3", "4.1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(version).To(Equal("4.1"))
+	})
+
+	It("should fail when no supported version is allowed", func() {
+		_, err := Negotiate([]string{"3"}, []string{"4.1", "4.2"})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,162 @@
+package nfsprobe
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	rpcVersion = 2
+
+	msgTypeCall  = 0
+	msgTypeReply = 1
+
+	replyAccepted = 0
+
+	authNone = 0
+	authSys  = 1
+
+	lastFragment = 0x80000000
+
+	maxOpaqueAuthLength = 400
+	maxRecordLength     = 1 << 20
+)
+
+// acceptStat is the status of an RPC call accepted by the server, as defined in RFC 5531.
+type acceptStat uint32
+
+const (
+	acceptSuccess      acceptStat = 0
+	acceptProgUnavail  acceptStat = 1
+	acceptProgMismatch acceptStat = 2
+)
+
+var errRPCDenied = errors.New("rpc call was denied")
+
+// call is a single ONC RPC call message.
+type call struct {
+	xid     uint32
+	prog    uint32
+	vers    uint32
+	proc    uint32
+	payload []byte
+}
+
+// reply is the accepted reply to an ONC RPC call.
+type reply struct {
+	xid     uint32
+	stat    acceptStat
+	payload []byte
+}
+
+// encodeCall returns the call as a single record marked fragment, using AUTH_SYS credentials of root.
+func encodeCall(c call) []byte {
+	body := &bytes.Buffer{}
+	putUint32(body, c.xid, msgTypeCall, rpcVersion, c.prog, c.vers, c.proc)
+
+	cred := &bytes.Buffer{}
+	putUint32(cred, 0)
+	putOpaque(cred, []byte("nfspvc-operator"))
+	putUint32(cred, 0, 0, 0)
+	putUint32(body, authSys)
+	putOpaque(body, cred.Bytes())
+
+	putUint32(body, authNone, 0)
+	body.Write(c.payload)
+
+	record := &bytes.Buffer{}
+	putUint32(record, lastFragment|uint32(body.Len()))
+	record.Write(body.Bytes())
+	return record.Bytes()
+}
+
+// readRecord reads a complete record marked message from r.
+func readRecord(r io.Reader) ([]byte, error) {
+	var message []byte
+	for {
+		var header uint32
+		if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+			return nil, err
+		}
+		if length := header &^ lastFragment; int(length)+len(message) > maxRecordLength {
+			return nil, fmt.Errorf("rpc record of %d bytes is too long", length)
+		}
+		fragment := make([]byte, header&^lastFragment)
+		if _, err := io.ReadFull(r, fragment); err != nil {
+			return nil, err
+		}
+		message = append(message, fragment...)
+		if header&lastFragment != 0 {
+			return message, nil
+		}
+	}
+}
+
+// decodeReply parses an RPC reply message.
+func decodeReply(message []byte) (reply, error) {
+	r := bytes.NewReader(message)
+	var xid, msgType, replyStat uint32
+	if err := readUint32(r, &xid, &msgType, &replyStat); err != nil {
+		return reply{}, fmt.Errorf("malformed rpc reply: %v", err)
+	}
+	if msgType != msgTypeReply {
+		return reply{}, fmt.Errorf("malformed rpc reply: unexpected message type %d", msgType)
+	}
+	if replyStat != replyAccepted {
+		return reply{}, errRPCDenied
+	}
+	var verfFlavor uint32
+	if err := readUint32(r, &verfFlavor); err != nil {
+		return reply{}, fmt.Errorf("malformed rpc reply: %v", err)
+	}
+	if _, err := readOpaque(r); err != nil {
+		return reply{}, fmt.Errorf("malformed rpc reply: %v", err)
+	}
+	var stat uint32
+	if err := readUint32(r, &stat); err != nil {
+		return reply{}, fmt.Errorf("malformed rpc reply: %v", err)
+	}
+	payload, _ := io.ReadAll(r)
+	return reply{xid: xid, stat: acceptStat(stat), payload: payload}, nil
+}
+
+func putUint32(w *bytes.Buffer, values ...uint32) {
+	for _, value := range values {
+		_ = binary.Write(w, binary.BigEndian, value)
+	}
+}
+
+func putOpaque(w *bytes.Buffer, data []byte) {
+	putUint32(w, uint32(len(data)))
+	w.Write(data)
+	if pad := (4 - len(data)%4) % 4; pad > 0 {
+		w.Write(make([]byte, pad))
+	}
+}
+
+func readUint32(r io.Reader, values ...*uint32) error {
+	for _, value := range values {
+		if err := binary.Read(r, binary.BigEndian, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readOpaque(r io.Reader) ([]byte, error) {
+	var length uint32
+	if err := readUint32(r, &length); err != nil {
+		return nil, err
+	}
+	if length > maxOpaqueAuthLength {
+		return nil, fmt.Errorf("opaque of %d bytes is too long", length)
+	}
+	data := make([]byte, length+(4-length%4)%4)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data[:length], nil
+}
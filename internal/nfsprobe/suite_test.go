@@ -0,0 +1,14 @@
+package nfsprobe
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestNfsProbe(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "NFS Probe Suite")
+}
@@ -0,0 +1,206 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	RegistryLabel = "nfspvc.dana.io/shard-registry"
+
+	PodNamespaceEnv = "POD_NAMESPACE"
+	// DeploymentNameEnv names the Deployment of the shard, which owns its registry so that the
+	// registry is garbage collected with the shard.
+	DeploymentNameEnv = "DEPLOYMENT_NAME"
+
+	registryPrefix       = "nfspvc-shard-"
+	defaultShard         = "default"
+	namespacesKey        = "namespaces"
+	namespaceSelectorKey = "namespaceSelector"
+
+	serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+// OperatorNamespace returns the namespace the operator runs in, or an empty string when it runs outside the cluster.
+func OperatorNamespace() string {
+	if namespace, ok := os.LookupEnv(PodNamespaceEnv); ok && namespace != "" {
+		return namespace
+	}
+	if namespace, err := os.ReadFile(serviceAccountNamespaceFile); err == nil {
+		return strings.TrimSpace(string(namespace))
+	}
+	return ""
+}
+
+// Register records the scope of the shard in a ConfigMap of the operator namespace,
+// where the webhooks of all the shards find it. Replicas of a shard register concurrently, so conflicts are retried.
+// The registry is owned by the Deployment of the shard, when its name is given, so that uninstalling
+// the shard removes it.
+func Register(ctx context.Context, k8sClient client.Client, operatorNamespace, deployment string, scope Scope) error {
+	var owners []metav1.OwnerReference
+	if deployment != "" {
+		owner := appsv1.Deployment{}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: deployment, Namespace: operatorNamespace}, &owner); err != nil {
+			return fmt.Errorf("failed to fetch the deployment %q of the shard: %v", deployment, err)
+		}
+		owners = []metav1.OwnerReference{{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+			Name:       owner.Name,
+			UID:        owner.UID,
+		}}
+	}
+	return retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+	}, func() error {
+		return register(ctx, k8sClient, operatorNamespace, owners, scope)
+	})
+}
+
+// register creates or updates the registry ConfigMap of the shard.
+func register(ctx context.Context, k8sClient client.Client, operatorNamespace string, owners []metav1.OwnerReference, scope Scope) error {
+	shard := scope.Shard
+	if shard == "" {
+		shard = defaultShard
+	}
+	data := map[string]string{namespacesKey: strings.Join(scope.Namespaces, ",")}
+	if scope.Selector != nil {
+		data[namespaceSelectorKey] = scope.Selector.String()
+	}
+
+	configMap := corev1.ConfigMap{}
+	err := k8sClient.Get(ctx, types.NamespacedName{Name: registryPrefix + shard, Namespace: operatorNamespace}, &configMap)
+	if apierrors.IsNotFound(err) {
+		configMap = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            registryPrefix + shard,
+				Namespace:       operatorNamespace,
+				Labels:          map[string]string{RegistryLabel: shard},
+				OwnerReferences: owners,
+			},
+			Data: data,
+		}
+		return k8sClient.Create(ctx, &configMap)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch the registry of shard %q: %v", shard, err)
+	}
+	configMap.Data = data
+	configMap.OwnerReferences = owners
+	return k8sClient.Update(ctx, &configMap)
+}
+
+// Registered returns the scopes of all the shards registered in the operator namespace.
+func Registered(ctx context.Context, reader client.Reader, operatorNamespace string) ([]Scope, error) {
+	configMaps := corev1.ConfigMapList{}
+	if err := reader.List(ctx, &configMaps, client.InNamespace(operatorNamespace), client.HasLabels{RegistryLabel}); err != nil {
+		return nil, fmt.Errorf("failed to list the shard registry: %v", err)
+	}
+	scopes := make([]Scope, 0, len(configMaps.Items))
+	for _, configMap := range configMaps.Items {
+		shard := configMap.Labels[RegistryLabel]
+		if shard == defaultShard {
+			shard = ""
+		}
+		scope, err := NewScope(shard, configMap.Data[namespacesKey], configMap.Data[namespaceSelectorKey])
+		if err != nil {
+			return nil, fmt.Errorf("invalid registry %q: %v", configMap.Name, err)
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes, nil
+}
+
+// IsManaged returns true if a registered shard manages the namespace of the given name.
+// Every namespace is managed while no shard is registered.
+func IsManaged(ctx context.Context, reader client.Reader, operatorNamespace, name string) (bool, error) {
+	if operatorNamespace == "" {
+		return true, nil
+	}
+	scopes, err := Registered(ctx, reader, operatorNamespace)
+	if err != nil {
+		return false, err
+	}
+	return isManagedBy(ctx, scopes, reader, name)
+}
+
+// Registry memoizes the registered shards for a short time, so that checking the namespace of every
+// admitted NfsPvc does not list the registry from the API server.
+type Registry struct {
+	reader            client.Reader
+	operatorNamespace string
+	ttl               time.Duration
+	now               func() time.Time
+
+	mu      sync.Mutex
+	scopes  []Scope
+	expires time.Time
+}
+
+// NewRegistry returns a Registry listing the shards registered in the operator namespace through the
+// reader at most once per ttl.
+func NewRegistry(reader client.Reader, operatorNamespace string, ttl time.Duration) *Registry {
+	return &Registry{reader: reader, operatorNamespace: operatorNamespace, ttl: ttl, now: time.Now}
+}
+
+// Registered returns the registered shards, listed again once the previous list is older than the ttl.
+func (r *Registry) Registered(ctx context.Context) ([]Scope, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if now := r.now(); r.scopes == nil || !now.Before(r.expires) {
+		scopes, err := Registered(ctx, r.reader, r.operatorNamespace)
+		if err != nil {
+			return nil, err
+		}
+		r.scopes, r.expires = scopes, now.Add(r.ttl)
+	}
+	return r.scopes, nil
+}
+
+// IsManaged returns true if a registered shard manages the namespace of the given name, which is
+// fetched through the namespaces reader. Every namespace is managed while no shard is registered.
+func (r *Registry) IsManaged(ctx context.Context, namespaces client.Reader, name string) (bool, error) {
+	if r.operatorNamespace == "" {
+		return true, nil
+	}
+	scopes, err := r.Registered(ctx)
+	if err != nil {
+		return false, err
+	}
+	return isManagedBy(ctx, scopes, namespaces, name)
+}
+
+// isManagedBy returns true if one of the scopes manages the namespace of the given name, or if there is no scope.
+// The namespace is only fetched when its labels matter.
+func isManagedBy(ctx context.Context, scopes []Scope, reader client.Reader, name string) (bool, error) {
+	if len(scopes) == 0 {
+		return true, nil
+	}
+	namespace := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	for _, scope := range scopes {
+		if scope.Selector != nil {
+			if err := reader.Get(ctx, types.NamespacedName{Name: name}, &namespace); err != nil {
+				return false, fmt.Errorf("failed to fetch namespace %q: %v", name, err)
+			}
+			break
+		}
+	}
+	for _, scope := range scopes {
+		if scope.Manages(namespace) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,131 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	leaderElectionID = "201dc81e.dana.io"
+)
+
+// Scope is the set of namespaces an operator instance manages.
+// The zero Scope manages every namespace.
+type Scope struct {
+	// Shard names the instance; it is empty for an unsharded instance.
+	Shard string
+	// Namespaces restricts the scope to a list of namespaces, when not empty.
+	Namespaces []string
+	// Selector restricts the scope to the namespaces matching it, when not nil.
+	Selector labels.Selector
+}
+
+// NewScope returns the scope of a shard from its comma separated namespaces and its namespace label selector.
+func NewScope(shard, namespaces, selector string) (Scope, error) {
+	scope := Scope{Shard: shard}
+	for _, namespace := range strings.Split(namespaces, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			scope.Namespaces = append(scope.Namespaces, namespace)
+		}
+	}
+	if selector != "" {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return Scope{}, fmt.Errorf("invalid namespace selector %q: %v", selector, err)
+		}
+		scope.Selector = parsed
+	}
+	if shard != "" {
+		if errs := validation.IsDNS1123Label(shard); len(errs) > 0 {
+			return Scope{}, fmt.Errorf("invalid shard name %q: %s", shard, strings.Join(errs, ", "))
+		}
+	} else if !scope.IsClusterWide() {
+		return Scope{}, fmt.Errorf("a shard name is required to restrict the managed namespaces")
+	}
+	return scope, nil
+}
+
+// IsClusterWide returns true if the scope manages every namespace.
+func (s Scope) IsClusterWide() bool {
+	return len(s.Namespaces) == 0 && s.Selector == nil
+}
+
+// LeaderElectionID returns the lease name of the shard, so that every shard elects its own leader.
+func (s Scope) LeaderElectionID() string {
+	if s.Shard == "" {
+		return leaderElectionID
+	}
+	return s.Shard + "." + leaderElectionID
+}
+
+// CacheOptions restricts the cache of namespaced objects to the namespaces of the scope.
+// A label selector can not restrict the cache, so its namespaces are filtered by Manages instead.
+// The cache of the owned kinds, which the cluster holds many of, is further restricted to the objects
+// carrying the label given for their kind.
+func (s Scope) CacheOptions(owned map[client.Object]string) cache.Options {
+	options := cache.Options{}
+	if len(owned) > 0 {
+		options.ByObject = make(map[client.Object]cache.ByObject, len(owned))
+		for object, label := range owned {
+			selector := labels.NewSelector()
+			if requirement, err := labels.NewRequirement(label, selection.Exists, nil); err == nil {
+				selector = selector.Add(*requirement)
+			}
+			options.ByObject[object] = cache.ByObject{Label: selector}
+		}
+	}
+	if len(s.Namespaces) > 0 {
+		options.DefaultNamespaces = make(map[string]cache.Config, len(s.Namespaces))
+		for _, namespace := range s.Namespaces {
+			options.DefaultNamespaces[namespace] = cache.Config{}
+		}
+	}
+	return options
+}
+
+// Manages returns true if the namespace is in the scope.
+func (s Scope) Manages(namespace corev1.Namespace) bool {
+	if len(s.Namespaces) > 0 && !slices.Contains(s.Namespaces, namespace.Name) {
+		return false
+	}
+	return s.Selector == nil || s.Selector.Matches(labels.Set(namespace.Labels))
+}
+
+// ManagesNamespace returns true if the namespace of the given name is in the scope.
+// The namespace is only fetched when the scope has a label selector.
+func (s Scope) ManagesNamespace(ctx context.Context, reader client.Reader, name string) (bool, error) {
+	if s.Selector == nil {
+		return s.Manages(corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}), nil
+	}
+	namespace := corev1.Namespace{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: name}, &namespace); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return s.Manages(namespace), nil
+}
+
+// WatchNamespaces reconciles the objects returned by mapFunc when the labels of a namespace change,
+// which may move the namespace in or out of a scope with a label selector.
+func (s Scope) WatchNamespaces(b *builder.Builder, mapFunc handler.MapFunc) *builder.Builder {
+	if s.Selector == nil {
+		return b
+	}
+	return b.Watches(&corev1.Namespace{},
+		handler.EnqueueRequestsFromMapFunc(mapFunc),
+		builder.WithPredicates(predicate.LabelChangedPredicate{}),
+	)
+}
@@ -0,0 +1,169 @@
+package sharding
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const operatorNamespace = "nfspvc-operator"
+
+func namespace(name string, labels map[string]string) corev1.Namespace {
+	return corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+var _ = Describe("Scope", func() {
+	It("should manage every namespace when it is not restricted", func() {
+		scope, err := NewScope("", "", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(scope.IsClusterWide()).To(BeTrue())
+		Expect(scope.LeaderElectionID()).To(Equal("201dc81e.dana.io"))
+		Expect(scope.CacheOptions(nil).DefaultNamespaces).To(BeNil())
+		Expect(scope.Manages(namespace("any", nil))).To(BeTrue())
+	})
+
+	It("should require a shard name to restrict the namespaces", func() {
+		_, err := NewScope("", "team-a", "")
+		Expect(err).To(HaveOccurred())
+		_, err = NewScope("Team_A", "team-a", "")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should manage the listed namespaces only", func() {
+		scope, err := NewScope("team-a", "team-a-dev, team-a-prod", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(scope.LeaderElectionID()).To(Equal("team-a.201dc81e.dana.io"))
+		Expect(scope.CacheOptions(nil).DefaultNamespaces).To(HaveLen(2))
+		Expect(scope.Manages(namespace("team-a-prod", nil))).To(BeTrue())
+		Expect(scope.Manages(namespace("team-b", nil))).To(BeFalse())
+	})
+
+	It("should manage the namespaces matching the selector only", func() {
+		scope, err := NewScope("team-b", "", "team=b")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(scope.CacheOptions(nil).DefaultNamespaces).To(BeNil())
+		Expect(scope.Manages(namespace("b", map[string]string{"team": "b"}))).To(BeTrue())
+		Expect(scope.Manages(namespace("a", map[string]string{"team": "a"}))).To(BeFalse())
+
+		reader := fake.NewClientBuilder().WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "b", Labels: map[string]string{"team": "b"}}}).Build()
+		managed, err := scope.ManagesNamespace(context.Background(), reader, "b")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(managed).To(BeTrue())
+		managed, err = scope.ManagesNamespace(context.Background(), reader, "missing")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(managed).To(BeFalse())
+	})
+
+	It("should cache the owned objects carrying the label of their kind only", func() {
+		scope, err := NewScope("", "", "")
+		Expect(err).NotTo(HaveOccurred())
+		configMap, job := &corev1.ConfigMap{}, &batchv1.Job{}
+		options := scope.CacheOptions(map[client.Object]string{configMap: "owner", job: "job"})
+		Expect(options.ByObject).To(HaveLen(2))
+		Expect(options.ByObject[configMap].Label.Matches(labels.Set{"owner": "data"})).To(BeTrue())
+		Expect(options.ByObject[configMap].Label.Matches(labels.Set{"job": "copy"})).To(BeFalse())
+		Expect(options.ByObject[job].Label.Matches(labels.Set{"job": "copy"})).To(BeTrue())
+		Expect(options.ByObject[job].Label.Matches(labels.Set{"app": "web"})).To(BeFalse())
+	})
+
+	It("should reject an invalid selector", func() {
+		_, err := NewScope("team-b", "", "team in (b")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Registry", func() {
+	var k8sClient client.Client
+
+	BeforeEach(func() {
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a-dev"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"team": "b"}}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		).Build()
+	})
+
+	It("should manage every namespace while no shard is registered", func() {
+		managed, err := IsManaged(context.Background(), k8sClient, operatorNamespace, "other")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(managed).To(BeTrue())
+	})
+
+	It("should only manage the namespaces of the registered shards", func() {
+		teamA, err := NewScope("team-a", "team-a-dev", "")
+		Expect(err).NotTo(HaveOccurred())
+		teamB, err := NewScope("team-b", "", "team=b")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(Register(context.Background(), k8sClient, operatorNamespace, "", teamA)).To(Succeed())
+		Expect(Register(context.Background(), k8sClient, operatorNamespace, "", teamB)).To(Succeed())
+		Expect(Register(context.Background(), k8sClient, operatorNamespace, "", teamB)).To(Succeed())
+
+		scopes, err := Registered(context.Background(), k8sClient, operatorNamespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(scopes).To(HaveLen(2))
+
+		for name, expected := range map[string]bool{"team-a-dev": true, "team-b": true, "other": false} {
+			managed, err := IsManaged(context.Background(), k8sClient, operatorNamespace, name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(managed).To(Equal(expected), name)
+		}
+	})
+
+	It("should let the deployment of the shard own its registry", func() {
+		deployment := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "team-a-manager", Namespace: operatorNamespace, UID: "deployment-uid"}}
+		Expect(k8sClient.Create(context.Background(), &deployment)).To(Succeed())
+		teamA, err := NewScope("team-a", "team-a-dev", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(Register(context.Background(), k8sClient, operatorNamespace, "missing", teamA)).NotTo(Succeed())
+		Expect(Register(context.Background(), k8sClient, operatorNamespace, deployment.Name, teamA)).To(Succeed())
+
+		configMap := corev1.ConfigMap{}
+		Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: "nfspvc-shard-team-a", Namespace: operatorNamespace}, &configMap)).To(Succeed())
+		Expect(configMap.OwnerReferences).To(HaveLen(1))
+		Expect(configMap.OwnerReferences[0].Kind).To(Equal("Deployment"))
+		Expect(configMap.OwnerReferences[0].UID).To(BeEquivalentTo("deployment-uid"))
+	})
+
+	It("should manage every namespace when an unsharded instance is registered", func() {
+		Expect(Register(context.Background(), k8sClient, operatorNamespace, "", Scope{})).To(Succeed())
+		managed, err := IsManaged(context.Background(), k8sClient, operatorNamespace, "other")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(managed).To(BeTrue())
+	})
+
+	It("should list the registry again only once the ttl expires", func() {
+		now := time.Now()
+		registry := NewRegistry(k8sClient, operatorNamespace, time.Minute)
+		registry.now = func() time.Time { return now }
+
+		managed, err := registry.IsManaged(context.Background(), k8sClient, "other")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(managed).To(BeTrue())
+
+		teamA, err := NewScope("team-a", "team-a-dev", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(Register(context.Background(), k8sClient, operatorNamespace, "", teamA)).To(Succeed())
+		now = now.Add(30 * time.Second)
+		managed, err = registry.IsManaged(context.Background(), k8sClient, "other")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(managed).To(BeTrue())
+
+		now = now.Add(30 * time.Second)
+		managed, err = registry.IsManaged(context.Background(), k8sClient, "other")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(managed).To(BeFalse())
+		managed, err = registry.IsManaged(context.Background(), k8sClient, "team-a-dev")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(managed).To(BeTrue())
+	})
+})
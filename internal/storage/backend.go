@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned when the export does not exist on the storage array.
+var ErrNotFound = errors.New("export not found")
+
+// StorageBackend provisions the exports of the NfsPvcs on an NFS storage array. Exports are
+// identified by a name unique in the array, and every operation is idempotent.
+type StorageBackend interface {
+	// CreateExport creates the export with a quota of size bytes if it does not exist, and
+	// returns the path it is exported at.
+	CreateExport(ctx context.Context, name string, size int64) (string, error)
+	// DeleteExport deletes the export and its data. Deleting a missing export succeeds.
+	DeleteExport(ctx context.Context, name string) error
+}
+
+// ExportPolicyBackend manages the clients allowed by the export policies of an NFS storage array.
+type ExportPolicyBackend interface {
+	// ExportPolicyClients returns the clients allowed by the rules of the export policy the backend manages.
+	ExportPolicyClients(ctx context.Context, policy string) ([]string, error)
+	// SetExportPolicyClients makes the rules of the export policy the backend manages allow exactly the clients.
+	SetExportPolicyClients(ctx context.Context, policy string, clients []string) error
+}
@@ -0,0 +1,96 @@
+package httphook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dana-team/nfspvc-operator/internal/storage"
+)
+
+// DefaultTimeout bounds the requests sent to the hook.
+const DefaultTimeout = 30 * time.Second
+
+// Hook manages the export policies through a generic HTTP endpoint, for storage arrays without a
+// backend of their own. The clients of a policy are read with GET <url>/export-policies/<policy>
+// and replaced with PUT <url>/export-policies/<policy>, both carrying a {"clients": [...]} body.
+type Hook struct {
+	url        string
+	httpClient *http.Client
+}
+
+var _ storage.ExportPolicyBackend = &Hook{}
+
+type clientList struct {
+	Clients []string `json:"clients"`
+}
+
+// New returns a hook calling the url with the given HTTP client, or a default one when nil.
+func New(hookURL string, httpClient *http.Client) *Hook {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultTimeout}
+	}
+	return &Hook{url: strings.TrimSuffix(hookURL, "/"), httpClient: httpClient}
+}
+
+// ExportPolicyClients returns the clients allowed by the export policy.
+func (h *Hook) ExportPolicyClients(ctx context.Context, policy string) ([]string, error) {
+	found := clientList{}
+	if err := h.do(ctx, http.MethodGet, policy, nil, &found); err != nil {
+		return nil, err
+	}
+	return found.Clients, nil
+}
+
+// SetExportPolicyClients replaces the clients allowed by the export policy.
+func (h *Hook) SetExportPolicyClients(ctx context.Context, policy string, clients []string) error {
+	if clients == nil {
+		clients = []string{}
+	}
+	return h.do(ctx, http.MethodPut, policy, clientList{Clients: clients}, nil)
+}
+
+// do sends a request about the export policy to the hook and decodes the response into out.
+func (h *Hook) do(ctx context.Context, method, policy string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, h.url+"/export-policies/"+url.PathEscape(policy), reader)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := h.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	switch {
+	case response.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%w: export policy %q", storage.ErrNotFound, policy)
+	case response.StatusCode >= http.StatusBadRequest:
+		return fmt.Errorf("%s export policy %q returned %d: %s", method, policy, response.StatusCode, strings.TrimSpace(string(content)))
+	case out == nil || len(content) == 0:
+		return nil
+	}
+	return json.Unmarshal(content, out)
+}
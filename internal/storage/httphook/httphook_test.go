@@ -0,0 +1,75 @@
+package httphook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/dana-team/nfspvc-operator/internal/storage"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hook", func() {
+	var (
+		ctx      context.Context
+		mu       sync.Mutex
+		policies map[string][]string
+		hook     *Hook
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		policies = map[string][]string{"k8s-nodes": {"10.0.0.1"}}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			name := strings.TrimPrefix(r.URL.Path, "/api/export-policies/")
+			clients, ok := policies[name]
+			if !ok {
+				http.Error(w, "not found", http.StatusNotFound)
+				return
+			}
+			switch r.Method {
+			case http.MethodGet:
+				_ = json.NewEncoder(w).Encode(clientList{Clients: clients})
+			case http.MethodPut:
+				body := clientList{}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Clients == nil {
+					http.Error(w, "invalid body", http.StatusBadRequest)
+					return
+				}
+				policies[name] = body.Clients
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			}
+		}))
+		DeferCleanup(server.Close)
+		hook = New(server.URL+"/api/", server.Client())
+	})
+
+	It("should read and replace the clients of the export policy", func() {
+		clients, err := hook.ExportPolicyClients(ctx, "k8s-nodes")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clients).To(Equal([]string{"10.0.0.1"}))
+
+		Expect(hook.SetExportPolicyClients(ctx, "k8s-nodes", []string{"10.0.0.2", "10.0.0.3"})).To(Succeed())
+		Expect(policies["k8s-nodes"]).To(Equal([]string{"10.0.0.2", "10.0.0.3"}))
+
+		Expect(hook.SetExportPolicyClients(ctx, "k8s-nodes", nil)).To(Succeed())
+		Expect(policies["k8s-nodes"]).To(BeEmpty())
+	})
+
+	It("should report missing policies and failures", func() {
+		_, err := hook.ExportPolicyClients(ctx, "missing")
+		Expect(err).To(MatchError(storage.ErrNotFound))
+
+		hook = New("http://127.0.0.1:1", nil)
+		_, err = hook.ExportPolicyClients(ctx, "k8s-nodes")
+		Expect(err).To(HaveOccurred())
+	})
+})
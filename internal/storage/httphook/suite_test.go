@@ -0,0 +1,14 @@
+package httphook
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestHTTPHook(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "HTTP Hook Suite")
+}
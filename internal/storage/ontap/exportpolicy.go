@@ -0,0 +1,149 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/dana-team/nfspvc-operator/internal/storage"
+)
+
+var (
+	// DefaultExportRoRule and DefaultExportRwRule let the clients mount the exports with AUTH_SYS, and
+	// DefaultExportSuperuser maps their root user to the anonymous user.
+	DefaultExportRoRule    = []string{"sys"}
+	DefaultExportRwRule    = []string{"sys"}
+	DefaultExportSuperuser = []string{"none"}
+
+	// securityFlavors are the security flavors of the ro, rw and superuser fields of an export policy rule.
+	securityFlavors = []string{"any", "none", "never", "krb5", "krb5i", "krb5p", "ntlm", "sys"}
+
+	// ruleProtocols are the protocols of the export policy rules added by the backend.
+	ruleProtocols = []string{"nfs"}
+)
+
+var _ storage.ExportPolicyBackend = &Backend{}
+
+type exportClient struct {
+	Match string `json:"match"`
+}
+
+type exportRule struct {
+	Index     int            `json:"index,omitempty"`
+	Clients   []exportClient `json:"clients"`
+	RoRule    []string       `json:"ro_rule,omitempty"`
+	RwRule    []string       `json:"rw_rule,omitempty"`
+	Superuser []string       `json:"superuser,omitempty"`
+	Protocols []string       `json:"protocols,omitempty"`
+}
+
+type exportPolicy struct {
+	ID    int          `json:"id"`
+	Name  string       `json:"name"`
+	Rules []exportRule `json:"rules"`
+}
+
+// ExportPolicyClients returns the clients of the rules of the export policy managed by the backend.
+// The other rules of the policy are not reported.
+func (b *Backend) ExportPolicyClients(ctx context.Context, policy string) ([]string, error) {
+	found, err := b.getExportPolicy(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+	var clients []string
+	for _, rule := range found.Rules {
+		if b.isManagedRule(rule) && !slices.Contains(clients, rule.Clients[0].Match) {
+			clients = append(clients, rule.Clients[0].Match)
+		}
+	}
+	return clients, nil
+}
+
+// SetExportPolicyClients deletes the rules managed by the backend whose client is not listed, and adds
+// a rule for every listed client without one. The rules the backend did not add are left alone.
+func (b *Backend) SetExportPolicyClients(ctx context.Context, policy string, clients []string) error {
+	found, err := b.getExportPolicy(ctx, policy)
+	if err != nil {
+		return err
+	}
+	rulesPath := fmt.Sprintf("/protocols/nfs/export-policies/%d/rules", found.ID)
+
+	// rules are deleted from the last one, so that the indexes of the remaining rules do not shift
+	rules := slices.Clone(found.Rules)
+	slices.SortFunc(rules, func(a, b exportRule) int { return b.Index - a.Index })
+	var allowed []string
+	for _, rule := range rules {
+		if !b.isManagedRule(rule) {
+			continue
+		}
+		client := rule.Clients[0].Match
+		if slices.Contains(clients, client) && !slices.Contains(allowed, client) {
+			allowed = append(allowed, client)
+			continue
+		}
+		if err := b.do(ctx, http.MethodDelete, fmt.Sprintf("%s/%d", rulesPath, rule.Index), nil, nil, nil); err != nil {
+			return fmt.Errorf("failed to delete rule %d of export policy %q: %v", rule.Index, policy, err)
+		}
+	}
+
+	for _, client := range clients {
+		if slices.Contains(allowed, client) {
+			continue
+		}
+		if err := b.do(ctx, http.MethodPost, rulesPath, nil, b.managedRule(client), nil); err != nil {
+			return fmt.Errorf("failed to add client %q to export policy %q: %v", client, policy, err)
+		}
+		allowed = append(allowed, client)
+	}
+	return nil
+}
+
+// managedRule returns the rule the backend adds to an export policy for the client.
+func (b *Backend) managedRule(client string) exportRule {
+	return exportRule{
+		Clients:   []exportClient{{Match: client}},
+		RoRule:    b.config.ExportRoRule,
+		RwRule:    b.config.ExportRwRule,
+		Superuser: b.config.ExportSuperuser,
+		Protocols: ruleProtocols,
+	}
+}
+
+// isManagedRule returns true if the rule was added by the backend: ONTAP rules carry no comment, so the
+// rules of the backend are the ones with a single client and exactly the protocols and security flavors
+// of managedRule. A rule with several clients, a subnet or other flavors is never changed.
+func (b *Backend) isManagedRule(rule exportRule) bool {
+	managed := b.managedRule("")
+	return len(rule.Clients) == 1 && !strings.Contains(rule.Clients[0].Match, "/") &&
+		slices.Equal(rule.RoRule, managed.RoRule) && slices.Equal(rule.RwRule, managed.RwRule) &&
+		slices.Equal(rule.Superuser, managed.Superuser) && slices.Equal(rule.Protocols, managed.Protocols)
+}
+
+// parseSecurityFlavors parses a comma separated list of security flavors of an export policy rule.
+func parseSecurityFlavors(value string) ([]string, error) {
+	var flavors []string
+	for _, flavor := range strings.Split(value, ",") {
+		flavor = strings.TrimSpace(flavor)
+		if !slices.Contains(securityFlavors, flavor) {
+			return nil, fmt.Errorf("unknown security flavor %q, it must be one of %s", flavor, strings.Join(securityFlavors, ", "))
+		}
+		flavors = append(flavors, flavor)
+	}
+	return flavors, nil
+}
+
+// getExportPolicy returns the export policy of the SVM with its rules.
+func (b *Backend) getExportPolicy(ctx context.Context, policy string) (*exportPolicy, error) {
+	query := url.Values{"svm.name": {b.config.SVM}, "name": {policy}, "fields": {"id,name,rules"}}
+	found := records[exportPolicy]{}
+	if err := b.do(ctx, http.MethodGet, "/protocols/nfs/export-policies", query, nil, &found); err != nil {
+		return nil, fmt.Errorf("failed to get export policy %q: %v", policy, err)
+	}
+	if len(found.Records) == 0 {
+		return nil, fmt.Errorf("%w: export policy %q", storage.ErrNotFound, policy)
+	}
+	return &found.Records[0], nil
+}
@@ -0,0 +1,252 @@
+package ontap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	fakeVolumeUUID = "volume-uuid"
+	fakeJunction   = "/exports"
+)
+
+// fakeONTAP is an in-memory ONTAP cluster serving the subset of the REST API used by the backend.
+// Qtrees are created through asynchronous jobs, like ONTAP does.
+type fakeONTAP struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	svm       string
+	volume    string
+	nextID    int
+	qtrees    map[string]qtree
+	rules     map[string]quotaRule
+	ruleQtree map[string]string
+	policies  map[string]*exportPolicy
+	jobs      map[string]job
+	polled    map[string]bool
+	requests  []string
+	// failJobs makes the asynchronous jobs fail with the message when set.
+	failJobs string
+	// stuckJobs keeps the asynchronous jobs running.
+	stuckJobs bool
+}
+
+func newFakeONTAP(svm, volume string) *fakeONTAP {
+	f := &fakeONTAP{
+		svm:       svm,
+		volume:    volume,
+		nextID:    1,
+		qtrees:    map[string]qtree{},
+		rules:     map[string]quotaRule{},
+		ruleQtree: map[string]string{},
+		jobs:      map[string]job{},
+		policies:  map[string]*exportPolicy{},
+		polled:    map[string]bool{},
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serve))
+	return f
+}
+
+func (f *fakeONTAP) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	if username, password, ok := r.BasicAuth(); !ok || username != "admin" || password != "secret" {
+		f.fail(w, http.StatusUnauthorized, "not authorized")
+		return
+	}
+	query := r.URL.Query()
+	if (query.Has("svm.name") && query.Get("svm.name") != f.svm) || (query.Has("volume.name") && query.Get("volume.name") != f.volume) {
+		f.write(w, http.StatusOK, records[any]{Records: []any{}})
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/api")
+	switch {
+	case strings.HasPrefix(path, "/cluster/jobs/"):
+		uuid := strings.TrimPrefix(path, "/cluster/jobs/")
+		current, ok := f.jobs[uuid]
+		if !ok {
+			f.fail(w, http.StatusNotFound, "job not found")
+			return
+		}
+		if !f.polled[uuid] || f.stuckJobs {
+			f.polled[uuid] = true
+			current = job{State: "running"}
+		}
+		f.write(w, http.StatusOK, current)
+
+	case path == "/storage/qtrees" && r.Method == http.MethodGet:
+		found := []qtree{}
+		if existing, ok := f.qtrees[query.Get("name")]; ok {
+			found = append(found, existing)
+		}
+		f.write(w, http.StatusOK, records[qtree]{Records: found})
+
+	case path == "/storage/qtrees" && r.Method == http.MethodPost:
+		body := map[string]any{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			f.fail(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		name, _ := body["name"].(string)
+		if _, ok := f.qtrees[name]; ok {
+			f.fail(w, http.StatusConflict, "duplicate entry")
+			return
+		}
+		f.qtrees[name] = qtree{ID: f.nextID, Name: name, Path: fakeJunction + "/" + name, Volume: reference{Name: f.volume, UUID: fakeVolumeUUID}}
+		f.nextID++
+		f.accept(w, job{State: jobSuccess})
+
+	case strings.HasPrefix(path, "/storage/qtrees/") && r.Method == http.MethodDelete:
+		id := path[strings.LastIndex(path, "/")+1:]
+		for name, existing := range f.qtrees {
+			if strconv.Itoa(existing.ID) == id && strings.HasPrefix(path, "/storage/qtrees/"+existing.Volume.UUID+"/") {
+				delete(f.qtrees, name)
+				f.accept(w, job{State: jobSuccess})
+				return
+			}
+		}
+		f.fail(w, http.StatusNotFound, "qtree not found")
+
+	case path == "/storage/quota/rules" && r.Method == http.MethodGet:
+		found := []quotaRule{}
+		for uuid, name := range f.ruleQtree {
+			if name == query.Get("qtree.name") {
+				found = append(found, f.rules[uuid])
+			}
+		}
+		f.write(w, http.StatusOK, records[quotaRule]{Records: found})
+
+	case path == "/storage/quota/rules" && r.Method == http.MethodPost:
+		body := struct {
+			Qtree reference `json:"qtree"`
+			Type  string    `json:"type"`
+			Space space     `json:"space"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			f.fail(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if _, ok := f.qtrees[body.Qtree.Name]; !ok || body.Type != "tree" {
+			f.fail(w, http.StatusBadRequest, "invalid quota rule")
+			return
+		}
+		uuid := fmt.Sprintf("rule-%d", len(f.rules)+1)
+		f.rules[uuid] = quotaRule{UUID: uuid, Space: space{HardLimit: body.Space.HardLimit}}
+		f.ruleQtree[uuid] = body.Qtree.Name
+		f.accept(w, job{State: jobSuccess})
+
+	case strings.HasPrefix(path, "/storage/quota/rules/"):
+		uuid := strings.TrimPrefix(path, "/storage/quota/rules/")
+		rule, ok := f.rules[uuid]
+		if !ok {
+			f.fail(w, http.StatusNotFound, "quota rule not found")
+			return
+		}
+		switch r.Method {
+		case http.MethodPatch:
+			body := struct {
+				Space space `json:"space"`
+			}{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				f.fail(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			rule.Space.HardLimit = body.Space.HardLimit
+			f.rules[uuid] = rule
+		case http.MethodDelete:
+			delete(f.rules, uuid)
+			delete(f.ruleQtree, uuid)
+		}
+		f.accept(w, job{State: jobSuccess})
+
+	case path == "/protocols/nfs/export-policies":
+		found := []exportPolicy{}
+		if policy, ok := f.policies[query.Get("name")]; ok {
+			found = append(found, *policy)
+		}
+		f.write(w, http.StatusOK, records[exportPolicy]{Records: found})
+
+	case strings.HasPrefix(path, "/protocols/nfs/export-policies/"):
+		f.serveExportPolicyRules(w, r, strings.Split(strings.TrimPrefix(path, "/protocols/nfs/export-policies/"), "/"))
+
+	default:
+		f.fail(w, http.StatusNotFound, "unknown endpoint "+r.Method+" "+path)
+	}
+}
+
+// serveExportPolicyRules serves <id>/rules and <id>/rules/<index> of the export policies.
+func (f *fakeONTAP) serveExportPolicyRules(w http.ResponseWriter, r *http.Request, parts []string) {
+	var policy *exportPolicy
+	for _, candidate := range f.policies {
+		if len(parts) > 1 && strconv.Itoa(candidate.ID) == parts[0] && parts[1] == "rules" {
+			policy = candidate
+		}
+	}
+	if policy == nil {
+		f.fail(w, http.StatusNotFound, "export policy not found")
+		return
+	}
+	if len(parts) == 2 && r.Method == http.MethodPost {
+		rule := exportRule{}
+		if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+			f.fail(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		rule.Index = 1
+		for _, existing := range policy.Rules {
+			rule.Index = max(rule.Index, existing.Index+1)
+		}
+		policy.Rules = append(policy.Rules, rule)
+		f.write(w, http.StatusCreated, struct{}{})
+		return
+	}
+	for i, rule := range policy.Rules {
+		if len(parts) != 3 || strconv.Itoa(rule.Index) != parts[2] {
+			continue
+		}
+		switch r.Method {
+		case http.MethodDelete:
+			policy.Rules = append(policy.Rules[:i], policy.Rules[i+1:]...)
+		case http.MethodPatch:
+			body := exportRule{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				f.fail(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			policy.Rules[i].Clients = body.Clients
+		}
+		f.write(w, http.StatusOK, struct{}{})
+		return
+	}
+	f.fail(w, http.StatusNotFound, "export policy rule not found")
+}
+
+// accept answers with an asynchronous job that is first running, then in the given final state.
+func (f *fakeONTAP) accept(w http.ResponseWriter, final job) {
+	uuid := fmt.Sprintf("job-%d", len(f.jobs)+1)
+	if f.failJobs != "" {
+		final = job{State: jobFailure, Message: f.failJobs}
+	}
+	f.jobs[uuid] = final
+	f.write(w, http.StatusAccepted, jobResponse{Job: &reference{UUID: uuid}})
+}
+
+func (f *fakeONTAP) fail(w http.ResponseWriter, status int, message string) {
+	failure := errorResponse{}
+	failure.Error.Message = message
+	f.write(w, status, failure)
+}
+
+func (f *fakeONTAP) write(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
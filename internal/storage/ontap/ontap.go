@@ -0,0 +1,383 @@
+package ontap
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dana-team/nfspvc-operator/internal/storage"
+)
+
+const (
+	// Name is the value of the STORAGE_BACKEND configuration selecting this backend.
+	Name = "ontap"
+
+	URLEnv                = "ONTAP_URL"
+	UsernameEnv           = "ONTAP_USERNAME"
+	PasswordEnv           = "ONTAP_PASSWORD"
+	SVMEnv                = "ONTAP_SVM"
+	VolumeEnv             = "ONTAP_VOLUME"
+	InsecureSkipVerifyEnv = "ONTAP_INSECURE_SKIP_VERIFY"
+	ExportRoRuleEnv       = "ONTAP_EXPORT_RO_RULE"
+	ExportRwRuleEnv       = "ONTAP_EXPORT_RW_RULE"
+	ExportSuperuserEnv    = "ONTAP_EXPORT_SUPERUSER"
+
+	DefaultPollInterval = 2 * time.Second
+	DefaultTimeout      = 30 * time.Second
+	DefaultJobTimeout   = 5 * time.Minute
+
+	jobSuccess = "success"
+	jobFailure = "failure"
+)
+
+// Config selects the ONTAP cluster and the volume the exports are created in.
+type Config struct {
+	// URL of the cluster management interface, e.g. https://ontap.example.com.
+	URL      string
+	Username string
+	Password string
+	// SVM serving the exports.
+	SVM string
+	// Volume the exports are created in as qtrees. It must have a junction path.
+	Volume string
+	// InsecureSkipVerify skips the verification of the certificate of the cluster.
+	InsecureSkipVerify bool
+	// PollInterval is the interval between two checks of an asynchronous job, DefaultPollInterval when zero.
+	PollInterval time.Duration
+	// JobTimeout bounds the wait for an asynchronous job, DefaultJobTimeout when zero.
+	JobTimeout time.Duration
+	// ExportRoRule, ExportRwRule and ExportSuperuser are the security flavors of the export policy rules
+	// the backend adds for the clients, DefaultExportRoRule, DefaultExportRwRule and DefaultExportSuperuser
+	// when empty.
+	ExportRoRule    []string
+	ExportRwRule    []string
+	ExportSuperuser []string
+}
+
+// Backend provisions the exports as qtrees of a single ONTAP volume through the ONTAP REST API,
+// and limits their size with tree quota rules.
+type Backend struct {
+	config     Config
+	httpClient *http.Client
+}
+
+var _ storage.StorageBackend = &Backend{}
+
+// New returns a backend using the given HTTP client, or a client built from the config when nil.
+func New(config Config, httpClient *http.Client) *Backend {
+	if config.PollInterval == 0 {
+		config.PollInterval = DefaultPollInterval
+	}
+	if config.JobTimeout == 0 {
+		config.JobTimeout = DefaultJobTimeout
+	}
+	if len(config.ExportRoRule) == 0 {
+		config.ExportRoRule = DefaultExportRoRule
+	}
+	if len(config.ExportRwRule) == 0 {
+		config.ExportRwRule = DefaultExportRwRule
+	}
+	if len(config.ExportSuperuser) == 0 {
+		config.ExportSuperuser = DefaultExportSuperuser
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: DefaultTimeout,
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: config.InsecureSkipVerify},
+			},
+		}
+	}
+	config.URL = strings.TrimSuffix(config.URL, "/")
+	return &Backend{config: config, httpClient: httpClient}
+}
+
+// ConfigFromEnvironment reads the config of the backend from the environment variables.
+func ConfigFromEnvironment() (Config, error) {
+	config := Config{
+		URL:      os.Getenv(URLEnv),
+		Username: os.Getenv(UsernameEnv),
+		Password: os.Getenv(PasswordEnv),
+		SVM:      os.Getenv(SVMEnv),
+		Volume:   os.Getenv(VolumeEnv),
+	}
+	if config.URL == "" || config.Username == "" || config.Password == "" || config.SVM == "" || config.Volume == "" {
+		return config, fmt.Errorf("the ontap storage backend requires the %s, %s, %s, %s and %s environment variables",
+			URLEnv, UsernameEnv, PasswordEnv, SVMEnv, VolumeEnv)
+	}
+	if value, ok := os.LookupEnv(InsecureSkipVerifyEnv); ok {
+		insecure, err := strconv.ParseBool(value)
+		if err != nil {
+			return config, fmt.Errorf("invalid %s: %v", InsecureSkipVerifyEnv, err)
+		}
+		config.InsecureSkipVerify = insecure
+	}
+	for _, setting := range []struct {
+		env   string
+		value *[]string
+	}{
+		{env: ExportRoRuleEnv, value: &config.ExportRoRule},
+		{env: ExportRwRuleEnv, value: &config.ExportRwRule},
+		{env: ExportSuperuserEnv, value: &config.ExportSuperuser},
+	} {
+		if value := os.Getenv(setting.env); value != "" {
+			flavors, err := parseSecurityFlavors(value)
+			if err != nil {
+				return config, fmt.Errorf("invalid %s: %v", setting.env, err)
+			}
+			*setting.value = flavors
+		}
+	}
+	return config, nil
+}
+
+type reference struct {
+	Name string `json:"name,omitempty"`
+	UUID string `json:"uuid,omitempty"`
+}
+
+type qtree struct {
+	ID     int       `json:"id"`
+	Name   string    `json:"name"`
+	Path   string    `json:"path,omitempty"`
+	Volume reference `json:"volume"`
+}
+
+type space struct {
+	HardLimit int64 `json:"hard_limit"`
+}
+
+type quotaRule struct {
+	UUID  string `json:"uuid"`
+	Space space  `json:"space"`
+}
+
+type records[T any] struct {
+	Records []T `json:"records"`
+}
+
+type jobResponse struct {
+	Job *reference `json:"job,omitempty"`
+}
+
+type job struct {
+	State   string `json:"state"`
+	Message string `json:"message"`
+}
+
+type errorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+		Code    string `json:"code"`
+	} `json:"error"`
+}
+
+// CreateExport creates the qtree and its tree quota rule, and returns the path of the qtree.
+func (b *Backend) CreateExport(ctx context.Context, name string, size int64) (string, error) {
+	existing, err := b.getQtree(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if existing == nil {
+		body := map[string]any{
+			"svm":            reference{Name: b.config.SVM},
+			"volume":         reference{Name: b.config.Volume},
+			"name":           name,
+			"security_style": "unix",
+		}
+		if err := b.do(ctx, http.MethodPost, "/storage/qtrees", nil, body, nil); err != nil {
+			return "", fmt.Errorf("failed to create qtree %q: %v", name, err)
+		}
+		if existing, err = b.getQtree(ctx, name); err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return "", fmt.Errorf("qtree %q was not found after its creation", name)
+		}
+	}
+	if err := b.setQuota(ctx, name, size); err != nil {
+		return "", err
+	}
+	if existing.Path == "" {
+		return "", fmt.Errorf("qtree %q has no path, the junction path of volume %q may be missing", name, b.config.Volume)
+	}
+	return existing.Path, nil
+}
+
+// DeleteExport deletes the tree quota rule and the qtree with its data.
+func (b *Backend) DeleteExport(ctx context.Context, name string) error {
+	rule, err := b.getQuotaRule(ctx, name)
+	if err != nil {
+		return err
+	}
+	if rule != nil {
+		if err := b.do(ctx, http.MethodDelete, "/storage/quota/rules/"+rule.UUID, nil, nil, nil); err != nil {
+			return fmt.Errorf("failed to delete the quota rule of qtree %q: %v", name, err)
+		}
+	}
+	existing, err := b.getQtree(ctx, name)
+	if err != nil || existing == nil {
+		return err
+	}
+	path := fmt.Sprintf("/storage/qtrees/%s/%d", existing.Volume.UUID, existing.ID)
+	if err := b.do(ctx, http.MethodDelete, path, nil, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete qtree %q: %v", name, err)
+	}
+	return nil
+}
+
+// setQuota creates or updates the tree quota rule of the qtree.
+func (b *Backend) setQuota(ctx context.Context, name string, size int64) error {
+	rule, err := b.getQuotaRule(ctx, name)
+	if err != nil {
+		return err
+	}
+	switch {
+	case rule == nil:
+		body := map[string]any{
+			"svm":    reference{Name: b.config.SVM},
+			"volume": reference{Name: b.config.Volume},
+			"qtree":  reference{Name: name},
+			"type":   "tree",
+			"space":  map[string]int64{"hard_limit": size},
+		}
+		if err := b.do(ctx, http.MethodPost, "/storage/quota/rules", nil, body, nil); err != nil {
+			return fmt.Errorf("failed to create the quota rule of qtree %q: %v", name, err)
+		}
+	case rule.Space.HardLimit != size:
+		body := map[string]any{"space": map[string]int64{"hard_limit": size}}
+		if err := b.do(ctx, http.MethodPatch, "/storage/quota/rules/"+rule.UUID, nil, body, nil); err != nil {
+			return fmt.Errorf("failed to update the quota rule of qtree %q: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// getQtree returns the qtree, or nil when it does not exist.
+func (b *Backend) getQtree(ctx context.Context, name string) (*qtree, error) {
+	query := b.volumeQuery()
+	query.Set("name", name)
+	query.Set("fields", "id,name,path,volume")
+	found := records[qtree]{}
+	if err := b.do(ctx, http.MethodGet, "/storage/qtrees", query, nil, &found); err != nil {
+		return nil, fmt.Errorf("failed to get qtree %q: %v", name, err)
+	}
+	if len(found.Records) == 0 {
+		return nil, nil
+	}
+	return &found.Records[0], nil
+}
+
+// getQuotaRule returns the tree quota rule of the qtree, or nil when it does not exist.
+func (b *Backend) getQuotaRule(ctx context.Context, name string) (*quotaRule, error) {
+	query := b.volumeQuery()
+	query.Set("qtree.name", name)
+	query.Set("type", "tree")
+	query.Set("fields", "uuid,space")
+	found := records[quotaRule]{}
+	if err := b.do(ctx, http.MethodGet, "/storage/quota/rules", query, nil, &found); err != nil {
+		return nil, fmt.Errorf("failed to get the quota rule of qtree %q: %v", name, err)
+	}
+	if len(found.Records) == 0 {
+		return nil, nil
+	}
+	return &found.Records[0], nil
+}
+
+// volumeQuery returns the query selecting the records of the volume.
+func (b *Backend) volumeQuery() url.Values {
+	return url.Values{"svm.name": {b.config.SVM}, "volume.name": {b.config.Volume}}
+}
+
+// do sends a request to the REST API and decodes the response into out. When the API answers with
+// an asynchronous job, it waits for the job to finish.
+func (b *Backend) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	target := b.config.URL + "/api" + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	request, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	request.SetBasicAuth(b.config.Username, b.config.Password)
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := b.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode >= http.StatusBadRequest {
+		failure := errorResponse{}
+		if json.Unmarshal(content, &failure) == nil && failure.Error.Message != "" {
+			return fmt.Errorf("%s %s returned %d: %s", method, path, response.StatusCode, failure.Error.Message)
+		}
+		return fmt.Errorf("%s %s returned %d", method, path, response.StatusCode)
+	}
+	if response.StatusCode == http.StatusAccepted {
+		accepted := jobResponse{}
+		if err := json.Unmarshal(content, &accepted); err != nil {
+			return err
+		}
+		if accepted.Job != nil {
+			return b.waitJob(ctx, accepted.Job.UUID)
+		}
+	}
+	if out == nil || len(content) == 0 {
+		return nil
+	}
+	return json.Unmarshal(content, out)
+}
+
+// waitJob polls the asynchronous job until it succeeds or fails, for at most the job timeout.
+func (b *Backend) waitJob(ctx context.Context, uuid string) error {
+	timeout := time.NewTimer(b.config.JobTimeout)
+	defer timeout.Stop()
+	for {
+		current := job{}
+		query := url.Values{"fields": {"state,message"}}
+		if err := b.do(ctx, http.MethodGet, "/cluster/jobs/"+uuid, query, nil, &current); err != nil {
+			return err
+		}
+		switch current.State {
+		case jobSuccess:
+			return nil
+		case jobFailure:
+			return fmt.Errorf("job %s failed: %s", uuid, current.Message)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout.C:
+			return fmt.Errorf("job %s did not finish within %s", uuid, b.config.JobTimeout)
+		case <-time.After(b.config.PollInterval):
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package ontap
+
+import (
+	"context"
+	"time"
+
+	"github.com/dana-team/nfspvc-operator/internal/storage"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Backend", func() {
+	const gib = int64(1 << 30)
+	var (
+		ctx     context.Context
+		fake    *fakeONTAP
+		backend *Backend
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fake = newFakeONTAP("svm1", "nfspvcs")
+		DeferCleanup(fake.Close)
+		backend = New(Config{
+			URL:          fake.URL + "/",
+			Username:     "admin",
+			Password:     "secret",
+			SVM:          "svm1",
+			Volume:       "nfspvcs",
+			PollInterval: time.Millisecond,
+		}, fake.Client())
+	})
+
+	It("should create a qtree with a tree quota", func() {
+		path, err := backend.CreateExport(ctx, "default_data", 10*gib)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal("/exports/default_data"))
+		Expect(fake.qtrees).To(HaveKey("default_data"))
+		Expect(fake.rules).To(HaveLen(1))
+		Expect(fake.rules["rule-1"].Space.HardLimit).To(Equal(10 * gib))
+		Expect(fake.requests).To(ContainElement(HavePrefix("GET /api/cluster/jobs/")))
+	})
+
+	It("should be idempotent and fix the quota of an existing qtree", func() {
+		_, err := backend.CreateExport(ctx, "default_data", 10*gib)
+		Expect(err).NotTo(HaveOccurred())
+		path, err := backend.CreateExport(ctx, "default_data", 20*gib)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal("/exports/default_data"))
+		Expect(fake.qtrees).To(HaveLen(1))
+		Expect(fake.rules).To(HaveLen(1))
+		Expect(fake.rules["rule-1"].Space.HardLimit).To(Equal(20 * gib))
+	})
+
+	It("should delete the qtree and its quota rule", func() {
+		_, err := backend.CreateExport(ctx, "default_data", 10*gib)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backend.DeleteExport(ctx, "default_data")).To(Succeed())
+		Expect(fake.qtrees).To(BeEmpty())
+		Expect(fake.rules).To(BeEmpty())
+
+		Expect(backend.DeleteExport(ctx, "default_data")).To(Succeed())
+	})
+
+	It("should report failed jobs and API errors", func() {
+		fake.failJobs = "volume is offline"
+		_, err := backend.CreateExport(ctx, "default_data", 10*gib)
+		Expect(err).To(MatchError(ContainSubstring("volume is offline")))
+
+		backend = New(Config{URL: fake.URL, Username: "admin", Password: "wrong", SVM: "svm1", Volume: "nfspvcs"}, fake.Client())
+		_, err = backend.CreateExport(ctx, "default_data", 10*gib)
+		Expect(err).To(MatchError(ContainSubstring("not authorized")))
+	})
+
+	It("should give up on a job that does not finish", func() {
+		fake.stuckJobs = true
+		backend = New(Config{URL: fake.URL, Username: "admin", Password: "secret", SVM: "svm1", Volume: "nfspvcs",
+			PollInterval: time.Millisecond, JobTimeout: 20 * time.Millisecond}, fake.Client())
+		_, err := backend.CreateExport(ctx, "default_data", 10*gib)
+		Expect(err).To(MatchError(ContainSubstring("did not finish within 20ms")))
+	})
+})
+
+var _ = Describe("ConfigFromEnvironment", func() {
+	It("should require the cluster, the credentials, the svm and the volume", func() {
+		GinkgoT().Setenv(URLEnv, "https://ontap.example.com")
+		GinkgoT().Setenv(UsernameEnv, "admin")
+		GinkgoT().Setenv(PasswordEnv, "secret")
+		GinkgoT().Setenv(SVMEnv, "svm1")
+		GinkgoT().Setenv(VolumeEnv, "")
+		_, err := ConfigFromEnvironment()
+		Expect(err).To(HaveOccurred())
+
+		GinkgoT().Setenv(VolumeEnv, "nfspvcs")
+		GinkgoT().Setenv(InsecureSkipVerifyEnv, "true")
+		config, err := ConfigFromEnvironment()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config).To(Equal(Config{URL: "https://ontap.example.com", Username: "admin", Password: "secret", SVM: "svm1", Volume: "nfspvcs", InsecureSkipVerify: true}))
+
+		GinkgoT().Setenv(ExportRwRuleEnv, "krb5, krb5p")
+		config, err = ConfigFromEnvironment()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.ExportRwRule).To(Equal([]string{"krb5", "krb5p"}))
+		Expect(config.ExportSuperuser).To(BeEmpty())
+
+		GinkgoT().Setenv(ExportSuperuserEnv, "root")
+		_, err = ConfigFromEnvironment()
+		Expect(err).To(MatchError(ContainSubstring(ExportSuperuserEnv)))
+	})
+})
+
+var _ = Describe("Export policies", func() {
+	var (
+		ctx     context.Context
+		fake    *fakeONTAP
+		backend *Backend
+	)
+
+	// clientsOf returns the clients of every rule of the export policy of the fake.
+	clientsOf := func(policy string) [][]string {
+		var clients [][]string
+		for _, rule := range fake.policies[policy].Rules {
+			var matches []string
+			for _, client := range rule.Clients {
+				matches = append(matches, client.Match)
+			}
+			clients = append(clients, matches)
+		}
+		return clients
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fake = newFakeONTAP("svm1", "nfspvcs")
+		DeferCleanup(fake.Close)
+		managed := func(index int, client string) exportRule {
+			return exportRule{Index: index, Clients: []exportClient{{Match: client}},
+				RoRule: []string{"sys"}, RwRule: []string{"sys"}, Superuser: []string{"none"}, Protocols: []string{"nfs"}}
+		}
+		shared := managed(2, "10.0.0.2")
+		shared.Clients = append(shared.Clients, exportClient{Match: "10.0.0.3"})
+		fake.policies["k8s-nodes"] = &exportPolicy{ID: 7, Name: "k8s-nodes", Rules: []exportRule{
+			{Index: 1, Clients: []exportClient{{Match: "10.0.0.1"}}, RoRule: []string{"any"}, RwRule: []string{"any"}, Superuser: []string{"any"}},
+			shared,
+			managed(3, "10.0.0.4"),
+			managed(4, "10.0.0.6"),
+			managed(5, "10.0.1.0/24"),
+		}}
+		backend = New(Config{URL: fake.URL, Username: "admin", Password: "secret", SVM: "svm1", Volume: "nfspvcs"}, fake.Client())
+	})
+
+	It("should list the clients of the rules managed by the backend", func() {
+		clients, err := backend.ExportPolicyClients(ctx, "k8s-nodes")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clients).To(Equal([]string{"10.0.0.4", "10.0.0.6"}))
+
+		_, err = backend.ExportPolicyClients(ctx, "missing")
+		Expect(err).To(MatchError(storage.ErrNotFound))
+	})
+
+	It("should replace the rules managed by the backend and leave the other rules alone", func() {
+		Expect(backend.SetExportPolicyClients(ctx, "k8s-nodes", []string{"10.0.0.1", "10.0.0.4", "10.0.0.5"})).To(Succeed())
+		Expect(clientsOf("k8s-nodes")).To(Equal([][]string{
+			{"10.0.0.1"}, {"10.0.0.2", "10.0.0.3"}, {"10.0.0.4"}, {"10.0.1.0/24"}, {"10.0.0.1"}, {"10.0.0.5"},
+		}))
+		rule := fake.policies["k8s-nodes"].Rules[5]
+		Expect(rule.RwRule).To(Equal([]string{"sys"}))
+		Expect(rule.Superuser).To(Equal([]string{"none"}))
+		Expect(rule.Protocols).To(Equal([]string{"nfs"}))
+
+		Expect(backend.SetExportPolicyClients(ctx, "k8s-nodes", []string{"10.0.0.1", "10.0.0.4", "10.0.0.5"})).To(Succeed())
+		Expect(fake.policies["k8s-nodes"].Rules).To(HaveLen(6))
+	})
+
+	It("should add the rules with the configured security flavors", func() {
+		backend = New(Config{URL: fake.URL, Username: "admin", Password: "secret", SVM: "svm1", Volume: "nfspvcs",
+			ExportRwRule: []string{"krb5"}}, fake.Client())
+		clients, err := backend.ExportPolicyClients(ctx, "k8s-nodes")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clients).To(BeEmpty())
+
+		Expect(backend.SetExportPolicyClients(ctx, "k8s-nodes", []string{"10.0.0.4"})).To(Succeed())
+		rules := fake.policies["k8s-nodes"].Rules
+		Expect(rules).To(HaveLen(6))
+		Expect(rules[5].RoRule).To(Equal([]string{"sys"}))
+		Expect(rules[5].RwRule).To(Equal([]string{"krb5"}))
+	})
+})
@@ -0,0 +1,14 @@
+package ontap
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestONTAP(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "ONTAP Suite")
+}
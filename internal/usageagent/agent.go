@@ -0,0 +1,166 @@
+package usageagent
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/dana-team/nfspvc-operator/internal/controller/status"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Agent measures the usage of the NfsPvcs mounted by the pods of a node and records it in their status.
+type Agent struct {
+	Client     client.Client
+	Log        logr.Logger
+	NodeName   string
+	KubeletDir string
+	Interval   time.Duration
+	// Timeout bounds the mount check and the statfs of a volume, which block while its NFS server
+	// does not respond.
+	Timeout time.Duration
+	// mounted returns true if a volume is mounted at the path.
+	mounted func(path string) (bool, error)
+	// statfs measures the file system of the volume mounted at the path.
+	statfs func(path string) (Usage, error)
+	// now returns the current time.
+	now func() time.Time
+
+	// pending holds the paths whose measurement did not return yet.
+	pending   map[string]bool
+	pendingMu sync.Mutex
+}
+
+// DefaultTimeout is the default time given to the measurement of a volume.
+const DefaultTimeout = 10 * time.Second
+
+// NewAgent returns an agent measuring the NFS volumes mounted under the kubelet directory every interval,
+// giving up on a volume that is not measured within the timeout.
+func NewAgent(k8sClient client.Client, log logr.Logger, nodeName, kubeletDir string, interval, timeout time.Duration) *Agent {
+	return &Agent{
+		Client:     k8sClient,
+		Log:        log,
+		NodeName:   nodeName,
+		KubeletDir: kubeletDir,
+		Interval:   interval,
+		Timeout:    timeout,
+		mounted:    isMountPoint,
+		statfs:     Statfs,
+		now:        time.Now,
+		pending:    map[string]bool{},
+	}
+}
+
+// Start measures the usage every interval until the context is done.
+func (a *Agent) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := a.Collect(ctx); err != nil {
+			a.Log.Error(err, "failed to collect the usage of the NfsPvcs")
+		}
+	}, a.Interval)
+	return nil
+}
+
+// Collect measures the usage of the NfsPvcs whose PV is mounted on the node. A failure to measure
+// one NfsPvc is logged and does not stop the others.
+func (a *Agent) Collect(ctx context.Context) error {
+	mounts, err := Mounts(a.KubeletDir)
+	if err != nil {
+		return err
+	}
+	for pvName, path := range mounts {
+		if err := a.report(ctx, pvName, path); err != nil {
+			a.Log.Error(err, "failed to report the usage of a PV", "PV", pvName)
+		}
+	}
+	return nil
+}
+
+// report records the usage of the volume mounted at path in the status of the NfsPvc of the PV.
+// The PVs that do not belong to an NfsPvc are ignored, and so is a usage measured by another node
+// less than an interval ago, so that the nodes sharing a PV do not all write it.
+func (a *Agent) report(ctx context.Context, pvName, path string) error {
+	var mounted bool
+	if err := a.measure(path, func() (err error) {
+		mounted, err = a.mounted(path)
+		return err
+	}); err != nil || !mounted {
+		return err
+	}
+	pv := corev1.PersistentVolume{}
+	if err := a.Client.Get(ctx, types.NamespacedName{Name: pvName}, &pv); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	name, ok := pv.Labels[resources.NfsPvcOwnerLabel]
+	if !ok || pv.Spec.ClaimRef == nil || pv.Spec.ClaimRef.Name != name {
+		return nil
+	}
+	nfspvc := danaiov1alpha1.NfsPvc{}
+	if err := a.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: pv.Spec.ClaimRef.Namespace}, &nfspvc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get NfsPvc: %v", err)
+	}
+	if resources.PVName(nfspvc) != pvName {
+		return nil
+	}
+	now := a.now()
+	if last := nfspvc.Status.Usage; last != nil && last.Node != a.NodeName && now.Sub(last.LastUpdateTime.Time) < a.Interval {
+		return nil
+	}
+
+	var measured Usage
+	if err := a.measure(path, func() (err error) {
+		measured, err = a.statfs(path)
+		return err
+	}); err != nil {
+		return err
+	}
+	return status.SetUsage(ctx, &nfspvc, danaiov1alpha1.NfsPvcUsage{
+		UsedBytes:      measured.UsedBytes,
+		AvailableBytes: measured.AvailableBytes,
+		Node:           a.NodeName,
+		LastUpdateTime: metav1.NewTime(now),
+	}, a.Client)
+}
+
+// measure runs a measurement of the volume mounted at path in its own goroutine and gives up on it
+// after the timeout, so that an NFS server that does not respond does not block the other volumes.
+// A path whose previous measurement is still blocked is not measured again, so that the blocked
+// goroutines do not pile up.
+func (a *Agent) measure(path string, measurement func() error) error {
+	a.pendingMu.Lock()
+	if a.pending[path] {
+		a.pendingMu.Unlock()
+		return fmt.Errorf("the previous measurement of %q did not return yet", path)
+	}
+	a.pending[path] = true
+	a.pendingMu.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		err := measurement()
+		a.pendingMu.Lock()
+		delete(a.pending, path)
+		a.pendingMu.Unlock()
+		done <- err
+	}()
+	timer := time.NewTimer(a.Timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("the measurement of %q did not finish within %s", path, a.Timeout)
+	}
+}
@@ -0,0 +1,135 @@
+package usageagent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Statfs", func() {
+	It("should measure the file system of a local directory", func() {
+		usage, err := Statfs(GinkgoT().TempDir())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(usage.UsedBytes).To(BeNumerically(">=", 0))
+		Expect(usage.AvailableBytes).To(BeNumerically(">", 0))
+	})
+
+	It("should fail for a missing directory", func() {
+		_, err := Statfs(filepath.Join(GinkgoT().TempDir(), "missing"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should tell a local directory is not a mount point", func() {
+		directory := filepath.Join(GinkgoT().TempDir(), "volume")
+		Expect(os.Mkdir(directory, 0o755)).To(Succeed())
+		Expect(isMountPoint(directory)).To(BeFalse())
+	})
+})
+
+var _ = Describe("Agent", func() {
+	var (
+		ctx        context.Context
+		k8sClient  client.Client
+		kubeletDir string
+		nfspvc     *danaiov1alpha1.NfsPvc
+		agent      *Agent
+		now        time.Time
+	)
+
+	mount := func(podUID, pvName string) {
+		Expect(os.MkdirAll(filepath.Join(kubeletDir, "pods", podUID, "volumes", "kubernetes.io~nfs", pvName), 0o755)).To(Succeed())
+	}
+
+	getNfsPvc := func() danaiov1alpha1.NfsPvc {
+		current := danaiov1alpha1.NfsPvc{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(nfspvc), &current)).To(Succeed())
+		return current
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		kubeletDir = GinkgoT().TempDir()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(danaiov1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		nfspvc = &danaiov1alpha1.NfsPvc{ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "team-a"}}
+		pv := resources.PreparePV(*nfspvc, "brown", "Retain")
+		foreign := &corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "foreign"}}
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(nfspvc, &pv, foreign).
+			WithStatusSubresource(&danaiov1alpha1.NfsPvc{}).Build()
+
+		agent = NewAgent(k8sClient, logr.Discard(), "worker-1", kubeletDir, time.Minute, DefaultTimeout)
+		agent.mounted = func(string) (bool, error) { return true, nil }
+		agent.now = func() time.Time { return now }
+		mount("pod-a", pv.Name)
+		mount("pod-b", pv.Name)
+		mount("pod-c", foreign.Name)
+	})
+
+	It("should find the NFS volumes of the pods", func() {
+		mounts, err := Mounts(kubeletDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mounts).To(HaveLen(2))
+		Expect(mounts).To(HaveKey(resources.PVName(*nfspvc)))
+		Expect(mounts).To(HaveKey("foreign"))
+	})
+
+	It("should record the usage of the mounted nfspvcs", func() {
+		Expect(agent.Collect(ctx)).To(Succeed())
+		usage := getNfsPvc().Status.Usage
+		Expect(usage).NotTo(BeNil())
+		Expect(usage.AvailableBytes).To(BeNumerically(">", 0))
+		Expect(usage.Node).To(Equal("worker-1"))
+		Expect(usage.LastUpdateTime.Time).To(BeTemporally("==", now))
+	})
+
+	It("should skip the volumes that are not mounted", func() {
+		agent.mounted = func(string) (bool, error) { return false, nil }
+		Expect(agent.Collect(ctx)).To(Succeed())
+		Expect(getNfsPvc().Status.Usage).To(BeNil())
+	})
+
+	It("should give up on a volume whose measurement does not finish", func() {
+		blocked := make(chan struct{})
+		defer close(blocked)
+		agent.Timeout = 10 * time.Millisecond
+		agent.statfs = func(string) (Usage, error) {
+			<-blocked
+			return Usage{}, nil
+		}
+		path := filepath.Join(kubeletDir, "pods", "pod-a", "volumes", "kubernetes.io~nfs", resources.PVName(*nfspvc))
+		Expect(agent.report(ctx, resources.PVName(*nfspvc), path)).To(MatchError(ContainSubstring("did not finish within")))
+		Expect(agent.report(ctx, resources.PVName(*nfspvc), path)).To(MatchError(ContainSubstring("did not return yet")))
+		Expect(getNfsPvc().Status.Usage).To(BeNil())
+	})
+
+	It("should leave a fresh usage measured by another node", func() {
+		other := NewAgent(k8sClient, logr.Discard(), "worker-2", kubeletDir, time.Minute, DefaultTimeout)
+		other.mounted = agent.mounted
+		other.now = func() time.Time { return now.Add(-30 * time.Second) }
+		Expect(other.Collect(ctx)).To(Succeed())
+
+		Expect(agent.Collect(ctx)).To(Succeed())
+		Expect(getNfsPvc().Status.Usage.Node).To(Equal("worker-2"))
+
+		now = now.Add(time.Minute)
+		Expect(agent.Collect(ctx)).To(Succeed())
+		Expect(getNfsPvc().Status.Usage.Node).To(Equal("worker-1"))
+	})
+})
@@ -0,0 +1,66 @@
+package usageagent
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// nfsVolumesGlob matches the mounts of the NFS PVs of the pods of a node under the kubelet directory.
+const nfsVolumesGlob = "pods/*/volumes/kubernetes.io~nfs/*"
+
+// Usage is the space used on a mounted file system.
+type Usage struct {
+	UsedBytes      int64
+	AvailableBytes int64
+}
+
+// Statfs returns the usage of the file system mounted at path. The available space is the space
+// available to unprivileged users, so the reserved blocks are neither used nor available.
+func Statfs(path string) (Usage, error) {
+	stat := syscall.Statfs_t{}
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return Usage{}, fmt.Errorf("failed to statfs %q: %v", path, err)
+	}
+	blockSize := int64(stat.Bsize)
+	return Usage{
+		UsedBytes:      int64(stat.Blocks-stat.Bfree) * blockSize,
+		AvailableBytes: int64(stat.Bavail) * blockSize,
+	}, nil
+}
+
+// Mounts returns the directories the NFS PVs are mounted at by the pods of the node, by PV name.
+// A PV mounted by several pods is measured through any of its mounts.
+func Mounts(kubeletDir string) (map[string]string, error) {
+	paths, err := filepath.Glob(filepath.Join(kubeletDir, nfsVolumesGlob))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the NFS volumes of %q: %v", kubeletDir, err)
+	}
+	mounts := map[string]string{}
+	for _, path := range paths {
+		if _, ok := mounts[filepath.Base(path)]; !ok {
+			mounts[filepath.Base(path)] = path
+		}
+	}
+	return mounts, nil
+}
+
+// isMountPoint returns true if a file system is mounted at path, i.e. if it is on another device
+// than its parent. It tells the volumes apart from the directories the kubelet left unmounted.
+func isMountPoint(path string) (bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	parent, err := os.Stat(filepath.Dir(path))
+	if err != nil {
+		return false, err
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	parentStat, parentOk := parent.Sys().(*syscall.Stat_t)
+	if !ok || !parentOk {
+		return false, fmt.Errorf("failed to find the device of %q", path)
+	}
+	return stat.Dev != parentStat.Dev, nil
+}
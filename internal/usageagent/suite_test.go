@@ -0,0 +1,14 @@
+package usageagent
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestUsageAgent(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "UsageAgent Suite")
+}
@@ -20,8 +20,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/sharding"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
@@ -36,8 +38,14 @@ import (
 )
 
 const (
-	pvcAlreadyExists       = "a PVC of this name already exists in the namespace. Please rename your NFSPVC"
-	invalidAccessModeError = "forbidden: only the following AccessModes are permitted"
+	pvcAlreadyExists        = "a PVC of this name already exists in the namespace. Please rename your NFSPVC"
+	invalidAccessModeError  = "forbidden: only the following AccessModes are permitted"
+	kerberosWithV3Warning   = "kerberos security with NFS version 3 requires rpc.gssd on every node and does not protect the MOUNT and NLM side protocols"
+	tlsRequiresV4Error      = "forbidden: transportSecurity tls and mtls require NFS version 4.x"
+	unmanagedNamespace      = "forbidden: no nfspvc-operator instance manages this namespace"
+	selfDataSourceError     = "forbidden: an NfsPvc cannot be its own dataSource"
+	immutableExportError    = "forbidden: server and path can only be changed by an NfsPvcMigration or an NfsServerFailover"
+	immutableAdoptedPVError = "forbidden: the nfspvc.dana.io/adopted-pv annotation is immutable"
 )
 
 var supportedAccessModes = sets.New(
@@ -51,17 +59,29 @@ var supportedAccessModes = sets.New(
 var nfspvclog = logf.Log.WithName("nfspvc-resource")
 var _ webhook.CustomValidator = &NfsPvcCustomValidator{}
 
+// registryTTL is how long the webhook reuses the list of the registered shards.
+const registryTTL = 10 * time.Second
+
 // SetupNfsPvcWebhookWithManager registers the webhook for NfsPvc in the manager.
-func SetupNfsPvcWebhookWithManager(mgr ctrl.Manager) error {
+// The webhook reads the namespaced objects through the API reader, since the cache of a shard does not
+// hold every namespace. The namespaces, which are cluster-scoped, are read from the cache, and the shard
+// registry, which the cache does not hold, is memoized.
+func SetupNfsPvcWebhookWithManager(mgr ctrl.Manager, operatorNamespace string) error {
 	return ctrl.NewWebhookManagedBy(mgr).For(&nfspvcv1alpha1.NfsPvc{}).
-		WithValidator(&NfsPvcCustomValidator{c: mgr.GetClient()}).
+		WithValidator(&NfsPvcCustomValidator{
+			c:          mgr.GetAPIReader(),
+			namespaces: mgr.GetClient(),
+			registry:   sharding.NewRegistry(mgr.GetAPIReader(), operatorNamespace, registryTTL),
+		}).
 		Complete()
 }
 
 // +kubebuilder:webhook:path=/validate-nfspvc-dana-io-v1alpha1-nfspvc,mutating=false,failurePolicy=fail,sideEffects=None,groups=nfspvc.dana.io,resources=nfspvcs,verbs=create;update,versions=v1alpha1,name=vnfspvc-v1alpha1.kb.io,admissionReviewVersions=v1
 
 type NfsPvcCustomValidator struct {
-	c client.Client
+	c          client.Reader
+	namespaces client.Reader
+	registry   *sharding.Registry
 }
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
@@ -72,25 +92,69 @@ func (v *NfsPvcCustomValidator) ValidateCreate(ctx context.Context, obj runtime.
 	}
 	nfspvclog.Info("validate create", "name", nfspvc.Name)
 
-	if v.doesPVCExist(v.c, nfspvc.Name, nfspvc.Namespace) {
+	managed, err := v.registry.IsManaged(ctx, v.namespaces, nfspvc.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check the shards managing namespace %q: %v", nfspvc.Namespace, err)
+	}
+	if !managed {
+		return nil, errors.New(unmanagedNamespace)
+	}
+
+	if v.doesPVCExist(v.c, nfspvc.Name, nfspvc.Namespace) && !v.isAdopting(ctx, *nfspvc) {
 		return admission.Warnings{pvcAlreadyExists}, errors.New(pvcAlreadyExists)
 	}
 
-	if !v.validateAccessMode(nfspvc.Spec.AccessModes) {
+	return ValidateNfsPvc(*nfspvc)
+}
+
+// ValidateNfsPvc runs the validations of a new nfspvc that do not read the cluster, so that they can
+// also run offline.
+func ValidateNfsPvc(nfspvc nfspvcv1alpha1.NfsPvc) (admission.Warnings, error) {
+	if isOwnDataSource(nfspvc) {
+		return admission.Warnings{selfDataSourceError}, errors.New(selfDataSourceError)
+	}
+
+	if !validateAccessMode(nfspvc.Spec.AccessModes) {
 		return admission.Warnings{invalidAccessModeError}, fmt.Errorf(invalidAccessModeError+": %v", supportedAccessModes)
 	}
 
-	return nil, nil
+	return validateSecurity(nfspvc.Spec)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (v *NfsPvcCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
-	nfspvc, ok := oldObj.(*nfspvcv1alpha1.NfsPvc)
+	oldNfsPvc, ok := oldObj.(*nfspvcv1alpha1.NfsPvc)
 	if !ok {
 		return nil, fmt.Errorf("expected a NfsPvc object but got %T", oldObj)
 	}
+	nfspvc, ok := newObj.(*nfspvcv1alpha1.NfsPvc)
+	if !ok {
+		return nil, fmt.Errorf("expected a NfsPvc object but got %T", newObj)
+	}
 	nfspvclog.Info("validate update", "name", nfspvc.Name)
 
+	if oldNfsPvc.Annotations[nfspvcv1alpha1.AdoptedPVAnnotation] != nfspvc.Annotations[nfspvcv1alpha1.AdoptedPVAnnotation] {
+		return admission.Warnings{immutableAdoptedPVError}, errors.New(immutableAdoptedPVError)
+	}
+	// A deleting nfspvc is not validated, so that its finalizers can always be removed.
+	if nfspvc.DeletionTimestamp == nil {
+		if warnings, err := ValidateNfsPvc(*nfspvc); err != nil {
+			return warnings, err
+		}
+	}
+	if oldNfsPvc.Spec.Server == nfspvc.Spec.Server && oldNfsPvc.Spec.Path == nfspvc.Spec.Path {
+		return nil, nil
+	}
+	allowed, err := v.isMigrating(ctx, *nfspvc)
+	if err == nil && !allowed {
+		allowed, err = v.isFailingOver(ctx, *oldNfsPvc, *nfspvc)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return admission.Warnings{immutableExportError}, errors.New(immutableExportError)
+	}
 	return nil, nil
 }
 
@@ -105,7 +169,8 @@ func (v *NfsPvcCustomValidator) ValidateDelete(ctx context.Context, obj runtime.
 	return nil, nil
 }
 
-func (v *NfsPvcCustomValidator) validateAccessMode(accessMode []corev1.PersistentVolumeAccessMode) bool {
+// validateAccessMode checks that all the given access modes are supported.
+func validateAccessMode(accessMode []corev1.PersistentVolumeAccessMode) bool {
 	for _, mode := range accessMode {
 		if !supportedAccessModes.Has(mode) {
 			return false
@@ -114,7 +179,93 @@ func (v *NfsPvcCustomValidator) validateAccessMode(accessMode []corev1.Persisten
 	return true
 }
 
-func (v *NfsPvcCustomValidator) doesPVCExist(K8sClient client.Client, pvcName, pvcNamespace string) bool {
+// isOwnDataSource returns true if the dataSource of the nfspvc refers to the nfspvc itself.
+func isOwnDataSource(nfspvc nfspvcv1alpha1.NfsPvc) bool {
+	dataSource := nfspvc.Spec.DataSource
+	if dataSource == nil {
+		return false
+	}
+	return dataSource.Name == nfspvc.Name && (dataSource.Namespace == "" || dataSource.Namespace == nfspvc.Namespace)
+}
+
+// isAdopting returns true if the nfspvc adopts the existing PVC of its name, which must then be bound
+// to the PV named by its adopted-pv annotation.
+func (v *NfsPvcCustomValidator) isAdopting(ctx context.Context, nfspvc nfspvcv1alpha1.NfsPvc) bool {
+	adopted := nfspvc.Annotations[nfspvcv1alpha1.AdoptedPVAnnotation]
+	if adopted == "" {
+		return false
+	}
+	pvc := corev1.PersistentVolumeClaim{}
+	if err := v.c.Get(ctx, types.NamespacedName{Namespace: nfspvc.Namespace, Name: nfspvc.Name}, &pvc); err != nil {
+		return false
+	}
+	return pvc.Spec.VolumeName == adopted
+}
+
+// isMigrating returns true if the migration named by the migration annotation of the nfspvc is switching it
+// to its target or rolling it back to its source, which are the only server and path changes allowed.
+func (v *NfsPvcCustomValidator) isMigrating(ctx context.Context, nfspvc nfspvcv1alpha1.NfsPvc) (bool, error) {
+	name, ok := nfspvc.Annotations[nfspvcv1alpha1.MigrationAnnotation]
+	if !ok {
+		return false, nil
+	}
+	migration := nfspvcv1alpha1.NfsPvcMigration{}
+	if err := v.c.Get(ctx, types.NamespacedName{Name: name, Namespace: nfspvc.Namespace}, &migration); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to fetch nfspvcmigration %q: %v", name, err)
+	}
+	if migration.Spec.NfsPvcName != nfspvc.Name {
+		return false, nil
+	}
+	switch migration.Status.Phase {
+	case nfspvcv1alpha1.SwitchingNfsPvcMigrationPhase:
+		return nfspvc.Spec.Server == migration.Spec.Server && nfspvc.Spec.Path == migration.Spec.Path, nil
+	case nfspvcv1alpha1.RollingBackNfsPvcMigrationPhase:
+		return nfspvc.Spec.Server == migration.Status.SourceServer && nfspvc.Spec.Path == migration.Status.SourcePath, nil
+	}
+	return false, nil
+}
+
+// isFailingOver returns true if the failover named by the failover annotation of the nfspvc
+// is progressing and moves it from the server it uses to the new one, keeping its path.
+func (v *NfsPvcCustomValidator) isFailingOver(ctx context.Context, oldNfsPvc, nfspvc nfspvcv1alpha1.NfsPvc) (bool, error) {
+	name, ok := nfspvc.Annotations[nfspvcv1alpha1.FailoverAnnotation]
+	if !ok || oldNfsPvc.Spec.Path != nfspvc.Spec.Path {
+		return false, nil
+	}
+	failover := nfspvcv1alpha1.NfsServerFailover{}
+	if err := v.c.Get(ctx, types.NamespacedName{Name: name}, &failover); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to fetch nfsserverfailover %q: %v", name, err)
+	}
+	if !failover.IsProgressing() {
+		return false, nil
+	}
+	source, target := failover.Servers()
+	return oldNfsPvc.Spec.Server == source && nfspvc.Spec.Server == target, nil
+}
+
+// validateSecurity checks that the security modes of the nfspvc are supported by its NFS version.
+func validateSecurity(spec nfspvcv1alpha1.NfsPvcSpec) (admission.Warnings, error) {
+	security := spec.Security
+	if security == nil || spec.NfsVersion != "3" {
+		return nil, nil
+	}
+	if security.TransportSecurity == nfspvcv1alpha1.TLSNfsTransportSecurity || security.TransportSecurity == nfspvcv1alpha1.MTLSNfsTransportSecurity {
+		return admission.Warnings{tlsRequiresV4Error}, errors.New(tlsRequiresV4Error)
+	}
+	switch security.Sec {
+	case nfspvcv1alpha1.Krb5NfsSecurityFlavor, nfspvcv1alpha1.Krb5iNfsSecurityFlavor, nfspvcv1alpha1.Krb5pNfsSecurityFlavor:
+		return admission.Warnings{kerberosWithV3Warning}, nil
+	}
+	return nil, nil
+}
+
+func (v *NfsPvcCustomValidator) doesPVCExist(K8sClient client.Reader, pvcName, pvcNamespace string) bool {
 	pvc := corev1.PersistentVolumeClaim{}
 	if err := K8sClient.Get(context.Background(), types.NamespacedName{Namespace: pvcNamespace, Name: pvcName}, &pvc); err != nil {
 		if k8sErrors.IsNotFound(err) {
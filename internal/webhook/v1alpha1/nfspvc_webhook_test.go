@@ -0,0 +1,269 @@
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/sharding"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("NfsPvc Webhook", func() {
+	const (
+		namespace         = "default"
+		operatorNamespace = "nfspvc-operator"
+	)
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		validator *NfsPvcCustomValidator
+		nfspvc    *nfspvcv1alpha1.NfsPvc
+	)
+
+	newValidator := func(operatorNamespace string) *NfsPvcCustomValidator {
+		return &NfsPvcCustomValidator{
+			c:          k8sClient,
+			namespaces: k8sClient,
+			registry:   sharding.NewRegistry(k8sClient, operatorNamespace, time.Minute),
+		}
+	}
+
+	// moved returns a copy of the nfspvc using the given server and path, annotated with the given annotations.
+	moved := func(server, path string, annotations map[string]string) *nfspvcv1alpha1.NfsPvc {
+		updated := nfspvc.DeepCopy()
+		updated.Spec.Server, updated.Spec.Path = server, path
+		updated.Annotations = annotations
+		return updated
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(nfspvcv1alpha1.AddToScheme(scheme)).To(Succeed())
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		).WithStatusSubresource(&nfspvcv1alpha1.NfsPvcMigration{}, &nfspvcv1alpha1.NfsServerFailover{}).Build()
+		validator = newValidator("")
+		nfspvc = &nfspvcv1alpha1.NfsPvc{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: namespace},
+			Spec: nfspvcv1alpha1.NfsPvcSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+				Capacity:    corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+				Server:      "nas-a",
+				Path:        "/export/data",
+			},
+		}
+	})
+
+	Context("on create", func() {
+		It("should admit a valid nfspvc", func() {
+			warnings, err := validator.ValidateCreate(ctx, nfspvc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should deny a namespace that no registered shard manages", func() {
+			scope, err := sharding.NewScope("team-a", "team-a", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sharding.Register(ctx, k8sClient, operatorNamespace, "", scope)).To(Succeed())
+			validator = newValidator(operatorNamespace)
+
+			_, err = validator.ValidateCreate(ctx, nfspvc)
+			Expect(err).To(MatchError(unmanagedNamespace))
+
+			nfspvc.Namespace = "team-a"
+			_, err = validator.ValidateCreate(ctx, nfspvc)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny a nfspvc whose PVC already exists unless it adopts it", func() {
+			Expect(k8sClient.Create(ctx, &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: nfspvc.Name, Namespace: namespace},
+				Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "legacy-pv"},
+			})).To(Succeed())
+
+			_, err := validator.ValidateCreate(ctx, nfspvc)
+			Expect(err).To(MatchError(pvcAlreadyExists))
+
+			nfspvc.Annotations = map[string]string{nfspvcv1alpha1.AdoptedPVAnnotation: "other-pv"}
+			_, err = validator.ValidateCreate(ctx, nfspvc)
+			Expect(err).To(MatchError(pvcAlreadyExists))
+
+			nfspvc.Annotations[nfspvcv1alpha1.AdoptedPVAnnotation] = "legacy-pv"
+			_, err = validator.ValidateCreate(ctx, nfspvc)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny a nfspvc that is its own dataSource", func() {
+			nfspvc.Spec.DataSource = &nfspvcv1alpha1.NfsPvcDataSource{Name: nfspvc.Name}
+			_, err := validator.ValidateCreate(ctx, nfspvc)
+			Expect(err).To(MatchError(selfDataSourceError))
+
+			nfspvc.Spec.DataSource.Namespace = namespace
+			_, err = validator.ValidateCreate(ctx, nfspvc)
+			Expect(err).To(MatchError(selfDataSourceError))
+
+			nfspvc.Spec.DataSource.Namespace = "team-a"
+			_, err = validator.ValidateCreate(ctx, nfspvc)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny an unsupported access mode", func() {
+			nfspvc.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{"ReadWriteAll"}
+			_, err := validator.ValidateCreate(ctx, nfspvc)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix(invalidAccessModeError))
+		})
+
+		It("should require NFS version 4.x for tls", func() {
+			nfspvc.Spec.NfsVersion = "3"
+			nfspvc.Spec.Security = &nfspvcv1alpha1.NfsSecurity{TransportSecurity: nfspvcv1alpha1.TLSNfsTransportSecurity}
+			_, err := validator.ValidateCreate(ctx, nfspvc)
+			Expect(err).To(MatchError(tlsRequiresV4Error))
+
+			nfspvc.Spec.Security.TransportSecurity = nfspvcv1alpha1.MTLSNfsTransportSecurity
+			_, err = validator.ValidateCreate(ctx, nfspvc)
+			Expect(err).To(MatchError(tlsRequiresV4Error))
+
+			nfspvc.Spec.NfsVersion = "4.2"
+			warnings, err := validator.ValidateCreate(ctx, nfspvc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should warn about kerberos with NFS version 3", func() {
+			nfspvc.Spec.NfsVersion = "3"
+			nfspvc.Spec.Security = &nfspvcv1alpha1.NfsSecurity{Sec: nfspvcv1alpha1.Krb5pNfsSecurityFlavor}
+			warnings, err := validator.ValidateCreate(ctx, nfspvc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(kerberosWithV3Warning))
+
+			nfspvc.Spec.NfsVersion = "4.1"
+			warnings, err = validator.ValidateCreate(ctx, nfspvc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
+
+	Context("on update", func() {
+		It("should allow the changes that keep the server and the path", func() {
+			updated := nfspvc.DeepCopy()
+			updated.Spec.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("2Gi")}
+			_, err := validator.ValidateUpdate(ctx, nfspvc, updated)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny the server and path changes of a nfspvc that is not migrating or failing over", func() {
+			_, err := validator.ValidateUpdate(ctx, nfspvc, moved("nas-b", nfspvc.Spec.Path, nil))
+			Expect(err).To(MatchError(immutableExportError))
+			_, err = validator.ValidateUpdate(ctx, nfspvc, moved(nfspvc.Spec.Server, "/export/other", nil))
+			Expect(err).To(MatchError(immutableExportError))
+		})
+
+		It("should deny the server and path changes while the migration is not switching", func() {
+			Expect(k8sClient.Create(ctx, &nfspvcv1alpha1.NfsPvcMigration{
+				ObjectMeta: metav1.ObjectMeta{Name: "move", Namespace: namespace},
+				Spec:       nfspvcv1alpha1.NfsPvcMigrationSpec{NfsPvcName: nfspvc.Name, Server: "nas-b", Path: "/export/moved"},
+			})).To(Succeed())
+
+			_, err := validator.ValidateUpdate(ctx, nfspvc, moved("nas-b", "/export/moved",
+				map[string]string{nfspvcv1alpha1.MigrationAnnotation: "move"}))
+			Expect(err).To(MatchError(immutableExportError))
+			_, err = validator.ValidateUpdate(ctx, nfspvc, moved("nas-b", "/export/moved",
+				map[string]string{nfspvcv1alpha1.MigrationAnnotation: "missing"}))
+			Expect(err).To(MatchError(immutableExportError))
+		})
+
+		It("should only let a switching migration move its own nfspvc to its target", func() {
+			migration := &nfspvcv1alpha1.NfsPvcMigration{
+				ObjectMeta: metav1.ObjectMeta{Name: "move", Namespace: namespace},
+				Spec:       nfspvcv1alpha1.NfsPvcMigrationSpec{NfsPvcName: nfspvc.Name, Server: "nas-b", Path: "/export/moved"},
+			}
+			Expect(k8sClient.Create(ctx, migration)).To(Succeed())
+			migration.Status.Phase = nfspvcv1alpha1.SwitchingNfsPvcMigrationPhase
+			Expect(k8sClient.Status().Update(ctx, migration)).To(Succeed())
+			annotations := map[string]string{nfspvcv1alpha1.MigrationAnnotation: "move"}
+
+			_, err := validator.ValidateUpdate(ctx, nfspvc, moved("nas-b", "/export/moved", annotations))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = validator.ValidateUpdate(ctx, nfspvc, moved("nas-c", "/export/moved", annotations))
+			Expect(err).To(MatchError(immutableExportError))
+
+			other := moved("nas-b", "/export/moved", annotations)
+			other.Name = "other"
+			_, err = validator.ValidateUpdate(ctx, nfspvc, other)
+			Expect(err).To(MatchError(immutableExportError))
+		})
+
+		It("should let a rolling back migration move the nfspvc back to its source", func() {
+			migration := &nfspvcv1alpha1.NfsPvcMigration{
+				ObjectMeta: metav1.ObjectMeta{Name: "move", Namespace: namespace},
+				Spec:       nfspvcv1alpha1.NfsPvcMigrationSpec{NfsPvcName: nfspvc.Name, Server: "nas-b", Path: "/export/moved"},
+			}
+			Expect(k8sClient.Create(ctx, migration)).To(Succeed())
+			migration.Status = nfspvcv1alpha1.NfsPvcMigrationStatus{
+				Phase:        nfspvcv1alpha1.RollingBackNfsPvcMigrationPhase,
+				SourceServer: "nas-a",
+				SourcePath:   "/export/data",
+			}
+			Expect(k8sClient.Status().Update(ctx, migration)).To(Succeed())
+			switched := moved("nas-b", "/export/moved", map[string]string{nfspvcv1alpha1.MigrationAnnotation: "move"})
+
+			_, err := validator.ValidateUpdate(ctx, switched, moved("nas-a", "/export/data", switched.Annotations))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = validator.ValidateUpdate(ctx, switched, moved("nas-c", "/export/data", switched.Annotations))
+			Expect(err).To(MatchError(immutableExportError))
+		})
+
+		It("should deny the server changes while the failover is not progressing", func() {
+			Expect(k8sClient.Create(ctx, &nfspvcv1alpha1.NfsServerFailover{
+				ObjectMeta: metav1.ObjectMeta{Name: "nas-a-down"},
+				Spec:       nfspvcv1alpha1.NfsServerFailoverSpec{From: "nas-a", To: "nas-b"},
+				Status:     nfspvcv1alpha1.NfsServerFailoverStatus{Phase: nfspvcv1alpha1.CompletedNfsServerFailoverPhase},
+			})).To(Succeed())
+
+			_, err := validator.ValidateUpdate(ctx, nfspvc, moved("nas-b", nfspvc.Spec.Path,
+				map[string]string{nfspvcv1alpha1.FailoverAnnotation: "nas-a-down"}))
+			Expect(err).To(MatchError(immutableExportError))
+			_, err = validator.ValidateUpdate(ctx, nfspvc, moved("nas-b", nfspvc.Spec.Path,
+				map[string]string{nfspvcv1alpha1.FailoverAnnotation: "missing"}))
+			Expect(err).To(MatchError(immutableExportError))
+		})
+
+		It("should only let a progressing failover move the nfspvc between its servers", func() {
+			failover := &nfspvcv1alpha1.NfsServerFailover{
+				ObjectMeta: metav1.ObjectMeta{Name: "nas-a-down"},
+				Spec:       nfspvcv1alpha1.NfsServerFailoverSpec{From: "nas-a", To: "nas-b"},
+			}
+			Expect(k8sClient.Create(ctx, failover)).To(Succeed())
+			failover.Status.Phase = nfspvcv1alpha1.ProgressingNfsServerFailoverPhase
+			Expect(k8sClient.Status().Update(ctx, failover)).To(Succeed())
+			annotations := map[string]string{nfspvcv1alpha1.FailoverAnnotation: "nas-a-down"}
+
+			_, err := validator.ValidateUpdate(ctx, nfspvc, moved("nas-b", nfspvc.Spec.Path, annotations))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = validator.ValidateUpdate(ctx, nfspvc, moved("nas-c", nfspvc.Spec.Path, annotations))
+			Expect(err).To(MatchError(immutableExportError))
+			_, err = validator.ValidateUpdate(ctx, nfspvc, moved("nas-b", "/export/other", annotations))
+			Expect(err).To(MatchError(immutableExportError))
+		})
+
+		It("should keep the adopted-pv annotation immutable", func() {
+			updated := nfspvc.DeepCopy()
+			updated.Annotations = map[string]string{nfspvcv1alpha1.AdoptedPVAnnotation: "legacy-pv"}
+			_, err := validator.ValidateUpdate(ctx, nfspvc, updated)
+			Expect(err).To(MatchError(immutableAdoptedPVError))
+		})
+	})
+})
@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/nfspvcset"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ webhook.CustomValidator = &NfsPvcSetCustomValidator{}
+
+// SetupNfsPvcSetWebhookWithManager registers the webhook for NfsPvcSet in the manager.
+func SetupNfsPvcSetWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&nfspvcv1alpha1.NfsPvcSet{}).
+		WithValidator(&NfsPvcSetCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-nfspvc-dana-io-v1alpha1-nfspvcset,mutating=false,failurePolicy=fail,sideEffects=None,groups=nfspvc.dana.io,resources=nfspvcsets,verbs=create;update,versions=v1alpha1,name=vnfspvcset-v1alpha1.kb.io,admissionReviewVersions=v1
+
+type NfsPvcSetCustomValidator struct{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (v *NfsPvcSetCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	set, ok := obj.(*nfspvcv1alpha1.NfsPvcSet)
+	if !ok {
+		return nil, fmt.Errorf("expected a NfsPvcSet object but got %T", obj)
+	}
+	nfspvclog.Info("validate create", "name", set.Name)
+
+	return v.validateSet(set)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (v *NfsPvcSetCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	set, ok := newObj.(*nfspvcv1alpha1.NfsPvcSet)
+	if !ok {
+		return nil, fmt.Errorf("expected a NfsPvcSet object but got %T", newObj)
+	}
+	nfspvclog.Info("validate update", "name", set.Name)
+
+	return v.validateSet(set)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (v *NfsPvcSetCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateSet makes sure the template renders and the generated NfsPvcs are valid.
+func (v *NfsPvcSetCustomValidator) validateSet(set *nfspvcv1alpha1.NfsPvcSet) (admission.Warnings, error) {
+	if !validateAccessMode(set.Spec.Template.Spec.AccessModes) {
+		return admission.Warnings{invalidAccessModeError}, fmt.Errorf(invalidAccessModeError+": %v", supportedAccessModes)
+	}
+
+	warnings, err := validateSecurity(set.Spec.Template.Spec.NfsPvcSpec())
+	if err != nil {
+		return warnings, err
+	}
+
+	// workloads and a replica count of zero are replaced by a sample item, so the templates are always rendered
+	sample := set.DeepCopy()
+	if sample.Spec.WorkloadSelector != nil || (len(sample.Spec.Names) == 0 && (sample.Spec.Replicas == nil || *sample.Spec.Replicas == 0)) {
+		sample.Spec.WorkloadSelector = nil
+		sample.Spec.Replicas = nil
+		sample.Spec.Names = []string{"workload-0"}
+	}
+	items, err := nfspvcset.DesiredItems(context.Background(), *sample, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		nfspvc, err := nfspvcset.RenderNfsPvc(*sample, item)
+		if err != nil {
+			return nil, err
+		}
+		if errs := validation.IsDNS1123Subdomain(nfspvc.Name); len(errs) > 0 {
+			return nil, fmt.Errorf("generated NfsPvc name %q is invalid: %v", nfspvc.Name, errs)
+		}
+		if len(nfspvc.Spec.Path) == 0 || nfspvc.Spec.Path[0] != '/' {
+			return nil, fmt.Errorf("generated NfsPvc path %q must be absolute", nfspvc.Spec.Path)
+		}
+		if len(nfspvc.Spec.Server) == 0 {
+			return nil, fmt.Errorf("generated NfsPvc server must not be empty")
+		}
+	}
+	return warnings, nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	http "net/http"
+
+	apiv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	scheme "github.com/dana-team/nfspvc-operator/pkg/client/clientset/versioned/scheme"
+	rest "k8s.io/client-go/rest"
+)
+
+type NfspvcV1alpha1Interface interface {
+	RESTClient() rest.Interface
+	ClusterNfsPvcsGetter
+	NfsExportPoliciesGetter
+	NfsPvcsGetter
+	NfsPvcBackupSchedulesGetter
+	NfsPvcMigrationsGetter
+	NfsPvcSetsGetter
+	NfsPvcSnapshotsGetter
+	NfsServerFailoversGetter
+}
+
+// NfspvcV1alpha1Client is used to interact with features provided by the nfspvc.dana.io group.
+type NfspvcV1alpha1Client struct {
+	restClient rest.Interface
+}
+
+func (c *NfspvcV1alpha1Client) ClusterNfsPvcs() ClusterNfsPvcInterface {
+	return newClusterNfsPvcs(c)
+}
+
+func (c *NfspvcV1alpha1Client) NfsExportPolicies() NfsExportPolicyInterface {
+	return newNfsExportPolicies(c)
+}
+
+func (c *NfspvcV1alpha1Client) NfsPvcs(namespace string) NfsPvcInterface {
+	return newNfsPvcs(c, namespace)
+}
+
+func (c *NfspvcV1alpha1Client) NfsPvcBackupSchedules(namespace string) NfsPvcBackupScheduleInterface {
+	return newNfsPvcBackupSchedules(c, namespace)
+}
+
+func (c *NfspvcV1alpha1Client) NfsPvcMigrations(namespace string) NfsPvcMigrationInterface {
+	return newNfsPvcMigrations(c, namespace)
+}
+
+func (c *NfspvcV1alpha1Client) NfsPvcSets(namespace string) NfsPvcSetInterface {
+	return newNfsPvcSets(c, namespace)
+}
+
+func (c *NfspvcV1alpha1Client) NfsPvcSnapshots(namespace string) NfsPvcSnapshotInterface {
+	return newNfsPvcSnapshots(c, namespace)
+}
+
+func (c *NfspvcV1alpha1Client) NfsServerFailovers() NfsServerFailoverInterface {
+	return newNfsServerFailovers(c)
+}
+
+// NewForConfig creates a new NfspvcV1alpha1Client for the given config.
+// NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
+// where httpClient was generated with rest.HTTPClientFor(c).
+func NewForConfig(c *rest.Config) (*NfspvcV1alpha1Client, error) {
+	config := *c
+	setConfigDefaults(&config)
+	httpClient, err := rest.HTTPClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return NewForConfigAndClient(&config, httpClient)
+}
+
+// NewForConfigAndClient creates a new NfspvcV1alpha1Client for the given config and http client.
+// Note the http client provided takes precedence over the configured transport values.
+func NewForConfigAndClient(c *rest.Config, h *http.Client) (*NfspvcV1alpha1Client, error) {
+	config := *c
+	setConfigDefaults(&config)
+	client, err := rest.RESTClientForConfigAndClient(&config, h)
+	if err != nil {
+		return nil, err
+	}
+	return &NfspvcV1alpha1Client{client}, nil
+}
+
+// NewForConfigOrDie creates a new NfspvcV1alpha1Client for the given config and
+// panics if there is an error in the config.
+func NewForConfigOrDie(c *rest.Config) *NfspvcV1alpha1Client {
+	client, err := NewForConfig(c)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// New creates a new NfspvcV1alpha1Client for the given RESTClient.
+func New(c rest.Interface) *NfspvcV1alpha1Client {
+	return &NfspvcV1alpha1Client{c}
+}
+
+func setConfigDefaults(config *rest.Config) {
+	gv := apiv1alpha1.SchemeGroupVersion
+	config.GroupVersion = &gv
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = rest.CodecFactoryForGeneratedClient(scheme.Scheme, scheme.Codecs).WithoutConversion()
+
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *NfspvcV1alpha1Client) RESTClient() rest.Interface {
+	if c == nil {
+		return nil
+	}
+	return c.restClient
+}
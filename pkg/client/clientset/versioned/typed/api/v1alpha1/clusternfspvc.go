@@ -0,0 +1,69 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	apiv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	scheme "github.com/dana-team/nfspvc-operator/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// ClusterNfsPvcsGetter has a method to return a ClusterNfsPvcInterface.
+// A group's client should implement this interface.
+type ClusterNfsPvcsGetter interface {
+	ClusterNfsPvcs() ClusterNfsPvcInterface
+}
+
+// ClusterNfsPvcInterface has methods to work with ClusterNfsPvc resources.
+type ClusterNfsPvcInterface interface {
+	Create(ctx context.Context, clusterNfsPvc *apiv1alpha1.ClusterNfsPvc, opts v1.CreateOptions) (*apiv1alpha1.ClusterNfsPvc, error)
+	Update(ctx context.Context, clusterNfsPvc *apiv1alpha1.ClusterNfsPvc, opts v1.UpdateOptions) (*apiv1alpha1.ClusterNfsPvc, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, clusterNfsPvc *apiv1alpha1.ClusterNfsPvc, opts v1.UpdateOptions) (*apiv1alpha1.ClusterNfsPvc, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.ClusterNfsPvc, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.ClusterNfsPvcList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.ClusterNfsPvc, err error)
+	ClusterNfsPvcExpansion
+}
+
+// clusterNfsPvcs implements ClusterNfsPvcInterface
+type clusterNfsPvcs struct {
+	*gentype.ClientWithList[*apiv1alpha1.ClusterNfsPvc, *apiv1alpha1.ClusterNfsPvcList]
+}
+
+// newClusterNfsPvcs returns a ClusterNfsPvcs
+func newClusterNfsPvcs(c *NfspvcV1alpha1Client) *clusterNfsPvcs {
+	return &clusterNfsPvcs{
+		gentype.NewClientWithList[*apiv1alpha1.ClusterNfsPvc, *apiv1alpha1.ClusterNfsPvcList](
+			"clusternfspvcs",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *apiv1alpha1.ClusterNfsPvc { return &apiv1alpha1.ClusterNfsPvc{} },
+			func() *apiv1alpha1.ClusterNfsPvcList { return &apiv1alpha1.ClusterNfsPvcList{} },
+		),
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/dana-team/nfspvc-operator/pkg/client/clientset/versioned/typed/api/v1alpha1"
+	rest "k8s.io/client-go/rest"
+	testing "k8s.io/client-go/testing"
+)
+
+type FakeNfspvcV1alpha1 struct {
+	*testing.Fake
+}
+
+func (c *FakeNfspvcV1alpha1) ClusterNfsPvcs() v1alpha1.ClusterNfsPvcInterface {
+	return newFakeClusterNfsPvcs(c)
+}
+
+func (c *FakeNfspvcV1alpha1) NfsExportPolicies() v1alpha1.NfsExportPolicyInterface {
+	return newFakeNfsExportPolicies(c)
+}
+
+func (c *FakeNfspvcV1alpha1) NfsPvcs(namespace string) v1alpha1.NfsPvcInterface {
+	return newFakeNfsPvcs(c, namespace)
+}
+
+func (c *FakeNfspvcV1alpha1) NfsPvcBackupSchedules(namespace string) v1alpha1.NfsPvcBackupScheduleInterface {
+	return newFakeNfsPvcBackupSchedules(c, namespace)
+}
+
+func (c *FakeNfspvcV1alpha1) NfsPvcMigrations(namespace string) v1alpha1.NfsPvcMigrationInterface {
+	return newFakeNfsPvcMigrations(c, namespace)
+}
+
+func (c *FakeNfspvcV1alpha1) NfsPvcSets(namespace string) v1alpha1.NfsPvcSetInterface {
+	return newFakeNfsPvcSets(c, namespace)
+}
+
+func (c *FakeNfspvcV1alpha1) NfsPvcSnapshots(namespace string) v1alpha1.NfsPvcSnapshotInterface {
+	return newFakeNfsPvcSnapshots(c, namespace)
+}
+
+func (c *FakeNfspvcV1alpha1) NfsServerFailovers() v1alpha1.NfsServerFailoverInterface {
+	return newFakeNfsServerFailovers(c)
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *FakeNfspvcV1alpha1) RESTClient() rest.Interface {
+	var ret *rest.RESTClient
+	return ret
+}
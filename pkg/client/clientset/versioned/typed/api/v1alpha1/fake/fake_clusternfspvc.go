@@ -0,0 +1,51 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	apiv1alpha1 "github.com/dana-team/nfspvc-operator/pkg/client/clientset/versioned/typed/api/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeClusterNfsPvcs implements ClusterNfsPvcInterface
+type fakeClusterNfsPvcs struct {
+	*gentype.FakeClientWithList[*v1alpha1.ClusterNfsPvc, *v1alpha1.ClusterNfsPvcList]
+	Fake *FakeNfspvcV1alpha1
+}
+
+func newFakeClusterNfsPvcs(fake *FakeNfspvcV1alpha1) apiv1alpha1.ClusterNfsPvcInterface {
+	return &fakeClusterNfsPvcs{
+		gentype.NewFakeClientWithList[*v1alpha1.ClusterNfsPvc, *v1alpha1.ClusterNfsPvcList](
+			fake.Fake,
+			"",
+			v1alpha1.SchemeGroupVersion.WithResource("clusternfspvcs"),
+			v1alpha1.SchemeGroupVersion.WithKind("ClusterNfsPvc"),
+			func() *v1alpha1.ClusterNfsPvc { return &v1alpha1.ClusterNfsPvc{} },
+			func() *v1alpha1.ClusterNfsPvcList { return &v1alpha1.ClusterNfsPvcList{} },
+			func(dst, src *v1alpha1.ClusterNfsPvcList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.ClusterNfsPvcList) []*v1alpha1.ClusterNfsPvc {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.ClusterNfsPvcList, items []*v1alpha1.ClusterNfsPvc) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
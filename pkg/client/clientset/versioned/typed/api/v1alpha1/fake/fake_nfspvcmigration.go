@@ -0,0 +1,51 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	apiv1alpha1 "github.com/dana-team/nfspvc-operator/pkg/client/clientset/versioned/typed/api/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeNfsPvcMigrations implements NfsPvcMigrationInterface
+type fakeNfsPvcMigrations struct {
+	*gentype.FakeClientWithList[*v1alpha1.NfsPvcMigration, *v1alpha1.NfsPvcMigrationList]
+	Fake *FakeNfspvcV1alpha1
+}
+
+func newFakeNfsPvcMigrations(fake *FakeNfspvcV1alpha1, namespace string) apiv1alpha1.NfsPvcMigrationInterface {
+	return &fakeNfsPvcMigrations{
+		gentype.NewFakeClientWithList[*v1alpha1.NfsPvcMigration, *v1alpha1.NfsPvcMigrationList](
+			fake.Fake,
+			namespace,
+			v1alpha1.SchemeGroupVersion.WithResource("nfspvcmigrations"),
+			v1alpha1.SchemeGroupVersion.WithKind("NfsPvcMigration"),
+			func() *v1alpha1.NfsPvcMigration { return &v1alpha1.NfsPvcMigration{} },
+			func() *v1alpha1.NfsPvcMigrationList { return &v1alpha1.NfsPvcMigrationList{} },
+			func(dst, src *v1alpha1.NfsPvcMigrationList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.NfsPvcMigrationList) []*v1alpha1.NfsPvcMigration {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.NfsPvcMigrationList, items []*v1alpha1.NfsPvcMigration) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
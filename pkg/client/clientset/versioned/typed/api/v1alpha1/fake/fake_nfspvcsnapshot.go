@@ -0,0 +1,51 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	apiv1alpha1 "github.com/dana-team/nfspvc-operator/pkg/client/clientset/versioned/typed/api/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeNfsPvcSnapshots implements NfsPvcSnapshotInterface
+type fakeNfsPvcSnapshots struct {
+	*gentype.FakeClientWithList[*v1alpha1.NfsPvcSnapshot, *v1alpha1.NfsPvcSnapshotList]
+	Fake *FakeNfspvcV1alpha1
+}
+
+func newFakeNfsPvcSnapshots(fake *FakeNfspvcV1alpha1, namespace string) apiv1alpha1.NfsPvcSnapshotInterface {
+	return &fakeNfsPvcSnapshots{
+		gentype.NewFakeClientWithList[*v1alpha1.NfsPvcSnapshot, *v1alpha1.NfsPvcSnapshotList](
+			fake.Fake,
+			namespace,
+			v1alpha1.SchemeGroupVersion.WithResource("nfspvcsnapshots"),
+			v1alpha1.SchemeGroupVersion.WithKind("NfsPvcSnapshot"),
+			func() *v1alpha1.NfsPvcSnapshot { return &v1alpha1.NfsPvcSnapshot{} },
+			func() *v1alpha1.NfsPvcSnapshotList { return &v1alpha1.NfsPvcSnapshotList{} },
+			func(dst, src *v1alpha1.NfsPvcSnapshotList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.NfsPvcSnapshotList) []*v1alpha1.NfsPvcSnapshot {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.NfsPvcSnapshotList, items []*v1alpha1.NfsPvcSnapshot) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
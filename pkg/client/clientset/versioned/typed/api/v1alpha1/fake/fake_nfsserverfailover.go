@@ -0,0 +1,51 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	apiv1alpha1 "github.com/dana-team/nfspvc-operator/pkg/client/clientset/versioned/typed/api/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeNfsServerFailovers implements NfsServerFailoverInterface
+type fakeNfsServerFailovers struct {
+	*gentype.FakeClientWithList[*v1alpha1.NfsServerFailover, *v1alpha1.NfsServerFailoverList]
+	Fake *FakeNfspvcV1alpha1
+}
+
+func newFakeNfsServerFailovers(fake *FakeNfspvcV1alpha1) apiv1alpha1.NfsServerFailoverInterface {
+	return &fakeNfsServerFailovers{
+		gentype.NewFakeClientWithList[*v1alpha1.NfsServerFailover, *v1alpha1.NfsServerFailoverList](
+			fake.Fake,
+			"",
+			v1alpha1.SchemeGroupVersion.WithResource("nfsserverfailovers"),
+			v1alpha1.SchemeGroupVersion.WithKind("NfsServerFailover"),
+			func() *v1alpha1.NfsServerFailover { return &v1alpha1.NfsServerFailover{} },
+			func() *v1alpha1.NfsServerFailoverList { return &v1alpha1.NfsServerFailoverList{} },
+			func(dst, src *v1alpha1.NfsServerFailoverList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.NfsServerFailoverList) []*v1alpha1.NfsServerFailover {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.NfsServerFailoverList, items []*v1alpha1.NfsServerFailover) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	apiv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	scheme "github.com/dana-team/nfspvc-operator/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// NfsPvcsGetter has a method to return a NfsPvcInterface.
+// A group's client should implement this interface.
+type NfsPvcsGetter interface {
+	NfsPvcs(namespace string) NfsPvcInterface
+}
+
+// NfsPvcInterface has methods to work with NfsPvc resources.
+type NfsPvcInterface interface {
+	Create(ctx context.Context, nfsPvc *apiv1alpha1.NfsPvc, opts v1.CreateOptions) (*apiv1alpha1.NfsPvc, error)
+	Update(ctx context.Context, nfsPvc *apiv1alpha1.NfsPvc, opts v1.UpdateOptions) (*apiv1alpha1.NfsPvc, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, nfsPvc *apiv1alpha1.NfsPvc, opts v1.UpdateOptions) (*apiv1alpha1.NfsPvc, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.NfsPvc, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.NfsPvcList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.NfsPvc, err error)
+	NfsPvcExpansion
+}
+
+// nfsPvcs implements NfsPvcInterface
+type nfsPvcs struct {
+	*gentype.ClientWithList[*apiv1alpha1.NfsPvc, *apiv1alpha1.NfsPvcList]
+}
+
+// newNfsPvcs returns a NfsPvcs
+func newNfsPvcs(c *NfspvcV1alpha1Client, namespace string) *nfsPvcs {
+	return &nfsPvcs{
+		gentype.NewClientWithList[*apiv1alpha1.NfsPvc, *apiv1alpha1.NfsPvcList](
+			"nfspvcs",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *apiv1alpha1.NfsPvc { return &apiv1alpha1.NfsPvc{} },
+			func() *apiv1alpha1.NfsPvcList { return &apiv1alpha1.NfsPvcList{} },
+		),
+	}
+}
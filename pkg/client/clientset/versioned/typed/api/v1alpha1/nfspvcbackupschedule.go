@@ -0,0 +1,69 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	apiv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	scheme "github.com/dana-team/nfspvc-operator/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// NfsPvcBackupSchedulesGetter has a method to return a NfsPvcBackupScheduleInterface.
+// A group's client should implement this interface.
+type NfsPvcBackupSchedulesGetter interface {
+	NfsPvcBackupSchedules(namespace string) NfsPvcBackupScheduleInterface
+}
+
+// NfsPvcBackupScheduleInterface has methods to work with NfsPvcBackupSchedule resources.
+type NfsPvcBackupScheduleInterface interface {
+	Create(ctx context.Context, nfsPvcBackupSchedule *apiv1alpha1.NfsPvcBackupSchedule, opts v1.CreateOptions) (*apiv1alpha1.NfsPvcBackupSchedule, error)
+	Update(ctx context.Context, nfsPvcBackupSchedule *apiv1alpha1.NfsPvcBackupSchedule, opts v1.UpdateOptions) (*apiv1alpha1.NfsPvcBackupSchedule, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, nfsPvcBackupSchedule *apiv1alpha1.NfsPvcBackupSchedule, opts v1.UpdateOptions) (*apiv1alpha1.NfsPvcBackupSchedule, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.NfsPvcBackupSchedule, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.NfsPvcBackupScheduleList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.NfsPvcBackupSchedule, err error)
+	NfsPvcBackupScheduleExpansion
+}
+
+// nfsPvcBackupSchedules implements NfsPvcBackupScheduleInterface
+type nfsPvcBackupSchedules struct {
+	*gentype.ClientWithList[*apiv1alpha1.NfsPvcBackupSchedule, *apiv1alpha1.NfsPvcBackupScheduleList]
+}
+
+// newNfsPvcBackupSchedules returns a NfsPvcBackupSchedules
+func newNfsPvcBackupSchedules(c *NfspvcV1alpha1Client, namespace string) *nfsPvcBackupSchedules {
+	return &nfsPvcBackupSchedules{
+		gentype.NewClientWithList[*apiv1alpha1.NfsPvcBackupSchedule, *apiv1alpha1.NfsPvcBackupScheduleList](
+			"nfspvcbackupschedules",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *apiv1alpha1.NfsPvcBackupSchedule { return &apiv1alpha1.NfsPvcBackupSchedule{} },
+			func() *apiv1alpha1.NfsPvcBackupScheduleList { return &apiv1alpha1.NfsPvcBackupScheduleList{} },
+		),
+	}
+}
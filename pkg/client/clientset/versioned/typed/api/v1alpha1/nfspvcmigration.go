@@ -0,0 +1,69 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	apiv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	scheme "github.com/dana-team/nfspvc-operator/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// NfsPvcMigrationsGetter has a method to return a NfsPvcMigrationInterface.
+// A group's client should implement this interface.
+type NfsPvcMigrationsGetter interface {
+	NfsPvcMigrations(namespace string) NfsPvcMigrationInterface
+}
+
+// NfsPvcMigrationInterface has methods to work with NfsPvcMigration resources.
+type NfsPvcMigrationInterface interface {
+	Create(ctx context.Context, nfsPvcMigration *apiv1alpha1.NfsPvcMigration, opts v1.CreateOptions) (*apiv1alpha1.NfsPvcMigration, error)
+	Update(ctx context.Context, nfsPvcMigration *apiv1alpha1.NfsPvcMigration, opts v1.UpdateOptions) (*apiv1alpha1.NfsPvcMigration, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, nfsPvcMigration *apiv1alpha1.NfsPvcMigration, opts v1.UpdateOptions) (*apiv1alpha1.NfsPvcMigration, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.NfsPvcMigration, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.NfsPvcMigrationList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.NfsPvcMigration, err error)
+	NfsPvcMigrationExpansion
+}
+
+// nfsPvcMigrations implements NfsPvcMigrationInterface
+type nfsPvcMigrations struct {
+	*gentype.ClientWithList[*apiv1alpha1.NfsPvcMigration, *apiv1alpha1.NfsPvcMigrationList]
+}
+
+// newNfsPvcMigrations returns a NfsPvcMigrations
+func newNfsPvcMigrations(c *NfspvcV1alpha1Client, namespace string) *nfsPvcMigrations {
+	return &nfsPvcMigrations{
+		gentype.NewClientWithList[*apiv1alpha1.NfsPvcMigration, *apiv1alpha1.NfsPvcMigrationList](
+			"nfspvcmigrations",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *apiv1alpha1.NfsPvcMigration { return &apiv1alpha1.NfsPvcMigration{} },
+			func() *apiv1alpha1.NfsPvcMigrationList { return &apiv1alpha1.NfsPvcMigrationList{} },
+		),
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	apiv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	scheme "github.com/dana-team/nfspvc-operator/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// NfsPvcSnapshotsGetter has a method to return a NfsPvcSnapshotInterface.
+// A group's client should implement this interface.
+type NfsPvcSnapshotsGetter interface {
+	NfsPvcSnapshots(namespace string) NfsPvcSnapshotInterface
+}
+
+// NfsPvcSnapshotInterface has methods to work with NfsPvcSnapshot resources.
+type NfsPvcSnapshotInterface interface {
+	Create(ctx context.Context, nfsPvcSnapshot *apiv1alpha1.NfsPvcSnapshot, opts v1.CreateOptions) (*apiv1alpha1.NfsPvcSnapshot, error)
+	Update(ctx context.Context, nfsPvcSnapshot *apiv1alpha1.NfsPvcSnapshot, opts v1.UpdateOptions) (*apiv1alpha1.NfsPvcSnapshot, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, nfsPvcSnapshot *apiv1alpha1.NfsPvcSnapshot, opts v1.UpdateOptions) (*apiv1alpha1.NfsPvcSnapshot, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.NfsPvcSnapshot, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.NfsPvcSnapshotList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.NfsPvcSnapshot, err error)
+	NfsPvcSnapshotExpansion
+}
+
+// nfsPvcSnapshots implements NfsPvcSnapshotInterface
+type nfsPvcSnapshots struct {
+	*gentype.ClientWithList[*apiv1alpha1.NfsPvcSnapshot, *apiv1alpha1.NfsPvcSnapshotList]
+}
+
+// newNfsPvcSnapshots returns a NfsPvcSnapshots
+func newNfsPvcSnapshots(c *NfspvcV1alpha1Client, namespace string) *nfsPvcSnapshots {
+	return &nfsPvcSnapshots{
+		gentype.NewClientWithList[*apiv1alpha1.NfsPvcSnapshot, *apiv1alpha1.NfsPvcSnapshotList](
+			"nfspvcsnapshots",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *apiv1alpha1.NfsPvcSnapshot { return &apiv1alpha1.NfsPvcSnapshot{} },
+			func() *apiv1alpha1.NfsPvcSnapshotList { return &apiv1alpha1.NfsPvcSnapshotList{} },
+		),
+	}
+}
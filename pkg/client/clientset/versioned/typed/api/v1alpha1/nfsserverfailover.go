@@ -0,0 +1,69 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	apiv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	scheme "github.com/dana-team/nfspvc-operator/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// NfsServerFailoversGetter has a method to return a NfsServerFailoverInterface.
+// A group's client should implement this interface.
+type NfsServerFailoversGetter interface {
+	NfsServerFailovers() NfsServerFailoverInterface
+}
+
+// NfsServerFailoverInterface has methods to work with NfsServerFailover resources.
+type NfsServerFailoverInterface interface {
+	Create(ctx context.Context, nfsServerFailover *apiv1alpha1.NfsServerFailover, opts v1.CreateOptions) (*apiv1alpha1.NfsServerFailover, error)
+	Update(ctx context.Context, nfsServerFailover *apiv1alpha1.NfsServerFailover, opts v1.UpdateOptions) (*apiv1alpha1.NfsServerFailover, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, nfsServerFailover *apiv1alpha1.NfsServerFailover, opts v1.UpdateOptions) (*apiv1alpha1.NfsServerFailover, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.NfsServerFailover, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.NfsServerFailoverList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.NfsServerFailover, err error)
+	NfsServerFailoverExpansion
+}
+
+// nfsServerFailovers implements NfsServerFailoverInterface
+type nfsServerFailovers struct {
+	*gentype.ClientWithList[*apiv1alpha1.NfsServerFailover, *apiv1alpha1.NfsServerFailoverList]
+}
+
+// newNfsServerFailovers returns a NfsServerFailovers
+func newNfsServerFailovers(c *NfspvcV1alpha1Client) *nfsServerFailovers {
+	return &nfsServerFailovers{
+		gentype.NewClientWithList[*apiv1alpha1.NfsServerFailover, *apiv1alpha1.NfsServerFailoverList](
+			"nfsserverfailovers",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *apiv1alpha1.NfsServerFailover { return &apiv1alpha1.NfsServerFailover{} },
+			func() *apiv1alpha1.NfsServerFailoverList { return &apiv1alpha1.NfsServerFailoverList{} },
+		),
+	}
+}
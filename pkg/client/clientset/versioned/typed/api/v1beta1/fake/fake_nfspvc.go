@@ -0,0 +1,47 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1beta1 "github.com/dana-team/nfspvc-operator/api/v1beta1"
+	apiv1beta1 "github.com/dana-team/nfspvc-operator/pkg/client/clientset/versioned/typed/api/v1beta1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeNfsPvcs implements NfsPvcInterface
+type fakeNfsPvcs struct {
+	*gentype.FakeClientWithList[*v1beta1.NfsPvc, *v1beta1.NfsPvcList]
+	Fake *FakeNfspvcV1beta1
+}
+
+func newFakeNfsPvcs(fake *FakeNfspvcV1beta1, namespace string) apiv1beta1.NfsPvcInterface {
+	return &fakeNfsPvcs{
+		gentype.NewFakeClientWithList[*v1beta1.NfsPvc, *v1beta1.NfsPvcList](
+			fake.Fake,
+			namespace,
+			v1beta1.SchemeGroupVersion.WithResource("nfspvcs"),
+			v1beta1.SchemeGroupVersion.WithKind("NfsPvc"),
+			func() *v1beta1.NfsPvc { return &v1beta1.NfsPvc{} },
+			func() *v1beta1.NfsPvcList { return &v1beta1.NfsPvcList{} },
+			func(dst, src *v1beta1.NfsPvcList) { dst.ListMeta = src.ListMeta },
+			func(list *v1beta1.NfsPvcList) []*v1beta1.NfsPvc { return gentype.ToPointerSlice(list.Items) },
+			func(list *v1beta1.NfsPvcList, items []*v1beta1.NfsPvc) { list.Items = gentype.FromPointerSlice(items) },
+		),
+		fake,
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	internalinterfaces "github.com/dana-team/nfspvc-operator/pkg/client/informers/externalversions/internalinterfaces"
+)
+
+// Interface provides access to all the informers in this group version.
+type Interface interface {
+	// ClusterNfsPvcs returns a ClusterNfsPvcInformer.
+	ClusterNfsPvcs() ClusterNfsPvcInformer
+	// NfsExportPolicies returns a NfsExportPolicyInformer.
+	NfsExportPolicies() NfsExportPolicyInformer
+	// NfsPvcs returns a NfsPvcInformer.
+	NfsPvcs() NfsPvcInformer
+	// NfsPvcBackupSchedules returns a NfsPvcBackupScheduleInformer.
+	NfsPvcBackupSchedules() NfsPvcBackupScheduleInformer
+	// NfsPvcMigrations returns a NfsPvcMigrationInformer.
+	NfsPvcMigrations() NfsPvcMigrationInformer
+	// NfsPvcSets returns a NfsPvcSetInformer.
+	NfsPvcSets() NfsPvcSetInformer
+	// NfsPvcSnapshots returns a NfsPvcSnapshotInformer.
+	NfsPvcSnapshots() NfsPvcSnapshotInformer
+	// NfsServerFailovers returns a NfsServerFailoverInformer.
+	NfsServerFailovers() NfsServerFailoverInformer
+}
+
+type version struct {
+	factory          internalinterfaces.SharedInformerFactory
+	namespace        string
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// New returns a new Interface.
+func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakListOptions internalinterfaces.TweakListOptionsFunc) Interface {
+	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
+}
+
+// ClusterNfsPvcs returns a ClusterNfsPvcInformer.
+func (v *version) ClusterNfsPvcs() ClusterNfsPvcInformer {
+	return &clusterNfsPvcInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// NfsExportPolicies returns a NfsExportPolicyInformer.
+func (v *version) NfsExportPolicies() NfsExportPolicyInformer {
+	return &nfsExportPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// NfsPvcs returns a NfsPvcInformer.
+func (v *version) NfsPvcs() NfsPvcInformer {
+	return &nfsPvcInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// NfsPvcBackupSchedules returns a NfsPvcBackupScheduleInformer.
+func (v *version) NfsPvcBackupSchedules() NfsPvcBackupScheduleInformer {
+	return &nfsPvcBackupScheduleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// NfsPvcMigrations returns a NfsPvcMigrationInformer.
+func (v *version) NfsPvcMigrations() NfsPvcMigrationInformer {
+	return &nfsPvcMigrationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// NfsPvcSets returns a NfsPvcSetInformer.
+func (v *version) NfsPvcSets() NfsPvcSetInformer {
+	return &nfsPvcSetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// NfsPvcSnapshots returns a NfsPvcSnapshotInformer.
+func (v *version) NfsPvcSnapshots() NfsPvcSnapshotInformer {
+	return &nfsPvcSnapshotInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// NfsServerFailovers returns a NfsServerFailoverInformer.
+func (v *version) NfsServerFailovers() NfsServerFailoverInformer {
+	return &nfsServerFailoverInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
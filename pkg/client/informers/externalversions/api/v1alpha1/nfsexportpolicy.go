@@ -0,0 +1,100 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+	time "time"
+
+	nfspvcoperatorapiv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	versioned "github.com/dana-team/nfspvc-operator/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/dana-team/nfspvc-operator/pkg/client/informers/externalversions/internalinterfaces"
+	apiv1alpha1 "github.com/dana-team/nfspvc-operator/pkg/client/listers/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// NfsExportPolicyInformer provides access to a shared informer and lister for
+// NfsExportPolicies.
+type NfsExportPolicyInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() apiv1alpha1.NfsExportPolicyLister
+}
+
+type nfsExportPolicyInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewNfsExportPolicyInformer constructs a new informer for NfsExportPolicy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewNfsExportPolicyInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredNfsExportPolicyInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredNfsExportPolicyInformer constructs a new informer for NfsExportPolicy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredNfsExportPolicyInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.NfspvcV1alpha1().NfsExportPolicies().List(context.Background(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.NfspvcV1alpha1().NfsExportPolicies().Watch(context.Background(), options)
+			},
+			ListWithContextFunc: func(ctx context.Context, options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.NfspvcV1alpha1().NfsExportPolicies().List(ctx, options)
+			},
+			WatchFuncWithContext: func(ctx context.Context, options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.NfspvcV1alpha1().NfsExportPolicies().Watch(ctx, options)
+			},
+		},
+		&nfspvcoperatorapiv1alpha1.NfsExportPolicy{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *nfsExportPolicyInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredNfsExportPolicyInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *nfsExportPolicyInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&nfspvcoperatorapiv1alpha1.NfsExportPolicy{}, f.defaultInformer)
+}
+
+func (f *nfsExportPolicyInformer) Lister() apiv1alpha1.NfsExportPolicyLister {
+	return apiv1alpha1.NewNfsExportPolicyLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+	time "time"
+
+	nfspvcoperatorapiv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	versioned "github.com/dana-team/nfspvc-operator/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/dana-team/nfspvc-operator/pkg/client/informers/externalversions/internalinterfaces"
+	apiv1alpha1 "github.com/dana-team/nfspvc-operator/pkg/client/listers/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// NfsPvcMigrationInformer provides access to a shared informer and lister for
+// NfsPvcMigrations.
+type NfsPvcMigrationInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() apiv1alpha1.NfsPvcMigrationLister
+}
+
+type nfsPvcMigrationInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewNfsPvcMigrationInformer constructs a new informer for NfsPvcMigration type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewNfsPvcMigrationInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredNfsPvcMigrationInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredNfsPvcMigrationInformer constructs a new informer for NfsPvcMigration type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredNfsPvcMigrationInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.NfspvcV1alpha1().NfsPvcMigrations(namespace).List(context.Background(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.NfspvcV1alpha1().NfsPvcMigrations(namespace).Watch(context.Background(), options)
+			},
+			ListWithContextFunc: func(ctx context.Context, options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.NfspvcV1alpha1().NfsPvcMigrations(namespace).List(ctx, options)
+			},
+			WatchFuncWithContext: func(ctx context.Context, options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.NfspvcV1alpha1().NfsPvcMigrations(namespace).Watch(ctx, options)
+			},
+		},
+		&nfspvcoperatorapiv1alpha1.NfsPvcMigration{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *nfsPvcMigrationInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredNfsPvcMigrationInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *nfsPvcMigrationInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&nfspvcoperatorapiv1alpha1.NfsPvcMigration{}, f.defaultInformer)
+}
+
+func (f *nfsPvcMigrationInformer) Lister() apiv1alpha1.NfsPvcMigrationLister {
+	return apiv1alpha1.NewNfsPvcMigrationLister(f.Informer().GetIndexer())
+}
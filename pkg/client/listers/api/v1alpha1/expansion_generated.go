@@ -0,0 +1,70 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ClusterNfsPvcListerExpansion allows custom methods to be added to
+// ClusterNfsPvcLister.
+type ClusterNfsPvcListerExpansion interface{}
+
+// NfsExportPolicyListerExpansion allows custom methods to be added to
+// NfsExportPolicyLister.
+type NfsExportPolicyListerExpansion interface{}
+
+// NfsPvcListerExpansion allows custom methods to be added to
+// NfsPvcLister.
+type NfsPvcListerExpansion interface{}
+
+// NfsPvcNamespaceListerExpansion allows custom methods to be added to
+// NfsPvcNamespaceLister.
+type NfsPvcNamespaceListerExpansion interface{}
+
+// NfsPvcBackupScheduleListerExpansion allows custom methods to be added to
+// NfsPvcBackupScheduleLister.
+type NfsPvcBackupScheduleListerExpansion interface{}
+
+// NfsPvcBackupScheduleNamespaceListerExpansion allows custom methods to be added to
+// NfsPvcBackupScheduleNamespaceLister.
+type NfsPvcBackupScheduleNamespaceListerExpansion interface{}
+
+// NfsPvcMigrationListerExpansion allows custom methods to be added to
+// NfsPvcMigrationLister.
+type NfsPvcMigrationListerExpansion interface{}
+
+// NfsPvcMigrationNamespaceListerExpansion allows custom methods to be added to
+// NfsPvcMigrationNamespaceLister.
+type NfsPvcMigrationNamespaceListerExpansion interface{}
+
+// NfsPvcSetListerExpansion allows custom methods to be added to
+// NfsPvcSetLister.
+type NfsPvcSetListerExpansion interface{}
+
+// NfsPvcSetNamespaceListerExpansion allows custom methods to be added to
+// NfsPvcSetNamespaceLister.
+type NfsPvcSetNamespaceListerExpansion interface{}
+
+// NfsPvcSnapshotListerExpansion allows custom methods to be added to
+// NfsPvcSnapshotLister.
+type NfsPvcSnapshotListerExpansion interface{}
+
+// NfsPvcSnapshotNamespaceListerExpansion allows custom methods to be added to
+// NfsPvcSnapshotNamespaceLister.
+type NfsPvcSnapshotNamespaceListerExpansion interface{}
+
+// NfsServerFailoverListerExpansion allows custom methods to be added to
+// NfsServerFailoverLister.
+type NfsServerFailoverListerExpansion interface{}
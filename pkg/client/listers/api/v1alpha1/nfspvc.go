@@ -0,0 +1,69 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// NfsPvcLister helps list NfsPvcs.
+// All objects returned here must be treated as read-only.
+type NfsPvcLister interface {
+	// List lists all NfsPvcs in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.NfsPvc, err error)
+	// NfsPvcs returns an object that can list and get NfsPvcs.
+	NfsPvcs(namespace string) NfsPvcNamespaceLister
+	NfsPvcListerExpansion
+}
+
+// nfsPvcLister implements the NfsPvcLister interface.
+type nfsPvcLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.NfsPvc]
+}
+
+// NewNfsPvcLister returns a new NfsPvcLister.
+func NewNfsPvcLister(indexer cache.Indexer) NfsPvcLister {
+	return &nfsPvcLister{listers.New[*apiv1alpha1.NfsPvc](indexer, apiv1alpha1.Resource("nfspvc"))}
+}
+
+// NfsPvcs returns an object that can list and get NfsPvcs.
+func (s *nfsPvcLister) NfsPvcs(namespace string) NfsPvcNamespaceLister {
+	return nfsPvcNamespaceLister{listers.NewNamespaced[*apiv1alpha1.NfsPvc](s.ResourceIndexer, namespace)}
+}
+
+// NfsPvcNamespaceLister helps list and get NfsPvcs.
+// All objects returned here must be treated as read-only.
+type NfsPvcNamespaceLister interface {
+	// List lists all NfsPvcs in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.NfsPvc, err error)
+	// Get retrieves the NfsPvc from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*apiv1alpha1.NfsPvc, error)
+	NfsPvcNamespaceListerExpansion
+}
+
+// nfsPvcNamespaceLister implements the NfsPvcNamespaceLister
+// interface.
+type nfsPvcNamespaceLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.NfsPvc]
+}
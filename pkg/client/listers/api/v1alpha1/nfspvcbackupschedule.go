@@ -0,0 +1,69 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// NfsPvcBackupScheduleLister helps list NfsPvcBackupSchedules.
+// All objects returned here must be treated as read-only.
+type NfsPvcBackupScheduleLister interface {
+	// List lists all NfsPvcBackupSchedules in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.NfsPvcBackupSchedule, err error)
+	// NfsPvcBackupSchedules returns an object that can list and get NfsPvcBackupSchedules.
+	NfsPvcBackupSchedules(namespace string) NfsPvcBackupScheduleNamespaceLister
+	NfsPvcBackupScheduleListerExpansion
+}
+
+// nfsPvcBackupScheduleLister implements the NfsPvcBackupScheduleLister interface.
+type nfsPvcBackupScheduleLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.NfsPvcBackupSchedule]
+}
+
+// NewNfsPvcBackupScheduleLister returns a new NfsPvcBackupScheduleLister.
+func NewNfsPvcBackupScheduleLister(indexer cache.Indexer) NfsPvcBackupScheduleLister {
+	return &nfsPvcBackupScheduleLister{listers.New[*apiv1alpha1.NfsPvcBackupSchedule](indexer, apiv1alpha1.Resource("nfspvcbackupschedule"))}
+}
+
+// NfsPvcBackupSchedules returns an object that can list and get NfsPvcBackupSchedules.
+func (s *nfsPvcBackupScheduleLister) NfsPvcBackupSchedules(namespace string) NfsPvcBackupScheduleNamespaceLister {
+	return nfsPvcBackupScheduleNamespaceLister{listers.NewNamespaced[*apiv1alpha1.NfsPvcBackupSchedule](s.ResourceIndexer, namespace)}
+}
+
+// NfsPvcBackupScheduleNamespaceLister helps list and get NfsPvcBackupSchedules.
+// All objects returned here must be treated as read-only.
+type NfsPvcBackupScheduleNamespaceLister interface {
+	// List lists all NfsPvcBackupSchedules in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.NfsPvcBackupSchedule, err error)
+	// Get retrieves the NfsPvcBackupSchedule from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*apiv1alpha1.NfsPvcBackupSchedule, error)
+	NfsPvcBackupScheduleNamespaceListerExpansion
+}
+
+// nfsPvcBackupScheduleNamespaceLister implements the NfsPvcBackupScheduleNamespaceLister
+// interface.
+type nfsPvcBackupScheduleNamespaceLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.NfsPvcBackupSchedule]
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// NfsPvcMigrationLister helps list NfsPvcMigrations.
+// All objects returned here must be treated as read-only.
+type NfsPvcMigrationLister interface {
+	// List lists all NfsPvcMigrations in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.NfsPvcMigration, err error)
+	// NfsPvcMigrations returns an object that can list and get NfsPvcMigrations.
+	NfsPvcMigrations(namespace string) NfsPvcMigrationNamespaceLister
+	NfsPvcMigrationListerExpansion
+}
+
+// nfsPvcMigrationLister implements the NfsPvcMigrationLister interface.
+type nfsPvcMigrationLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.NfsPvcMigration]
+}
+
+// NewNfsPvcMigrationLister returns a new NfsPvcMigrationLister.
+func NewNfsPvcMigrationLister(indexer cache.Indexer) NfsPvcMigrationLister {
+	return &nfsPvcMigrationLister{listers.New[*apiv1alpha1.NfsPvcMigration](indexer, apiv1alpha1.Resource("nfspvcmigration"))}
+}
+
+// NfsPvcMigrations returns an object that can list and get NfsPvcMigrations.
+func (s *nfsPvcMigrationLister) NfsPvcMigrations(namespace string) NfsPvcMigrationNamespaceLister {
+	return nfsPvcMigrationNamespaceLister{listers.NewNamespaced[*apiv1alpha1.NfsPvcMigration](s.ResourceIndexer, namespace)}
+}
+
+// NfsPvcMigrationNamespaceLister helps list and get NfsPvcMigrations.
+// All objects returned here must be treated as read-only.
+type NfsPvcMigrationNamespaceLister interface {
+	// List lists all NfsPvcMigrations in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.NfsPvcMigration, err error)
+	// Get retrieves the NfsPvcMigration from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*apiv1alpha1.NfsPvcMigration, error)
+	NfsPvcMigrationNamespaceListerExpansion
+}
+
+// nfsPvcMigrationNamespaceLister implements the NfsPvcMigrationNamespaceLister
+// interface.
+type nfsPvcMigrationNamespaceLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.NfsPvcMigration]
+}
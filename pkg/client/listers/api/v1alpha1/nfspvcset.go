@@ -0,0 +1,69 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// NfsPvcSetLister helps list NfsPvcSets.
+// All objects returned here must be treated as read-only.
+type NfsPvcSetLister interface {
+	// List lists all NfsPvcSets in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.NfsPvcSet, err error)
+	// NfsPvcSets returns an object that can list and get NfsPvcSets.
+	NfsPvcSets(namespace string) NfsPvcSetNamespaceLister
+	NfsPvcSetListerExpansion
+}
+
+// nfsPvcSetLister implements the NfsPvcSetLister interface.
+type nfsPvcSetLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.NfsPvcSet]
+}
+
+// NewNfsPvcSetLister returns a new NfsPvcSetLister.
+func NewNfsPvcSetLister(indexer cache.Indexer) NfsPvcSetLister {
+	return &nfsPvcSetLister{listers.New[*apiv1alpha1.NfsPvcSet](indexer, apiv1alpha1.Resource("nfspvcset"))}
+}
+
+// NfsPvcSets returns an object that can list and get NfsPvcSets.
+func (s *nfsPvcSetLister) NfsPvcSets(namespace string) NfsPvcSetNamespaceLister {
+	return nfsPvcSetNamespaceLister{listers.NewNamespaced[*apiv1alpha1.NfsPvcSet](s.ResourceIndexer, namespace)}
+}
+
+// NfsPvcSetNamespaceLister helps list and get NfsPvcSets.
+// All objects returned here must be treated as read-only.
+type NfsPvcSetNamespaceLister interface {
+	// List lists all NfsPvcSets in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.NfsPvcSet, err error)
+	// Get retrieves the NfsPvcSet from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*apiv1alpha1.NfsPvcSet, error)
+	NfsPvcSetNamespaceListerExpansion
+}
+
+// nfsPvcSetNamespaceLister implements the NfsPvcSetNamespaceLister
+// interface.
+type nfsPvcSetNamespaceLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.NfsPvcSet]
+}
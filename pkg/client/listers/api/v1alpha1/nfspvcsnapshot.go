@@ -0,0 +1,69 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// NfsPvcSnapshotLister helps list NfsPvcSnapshots.
+// All objects returned here must be treated as read-only.
+type NfsPvcSnapshotLister interface {
+	// List lists all NfsPvcSnapshots in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.NfsPvcSnapshot, err error)
+	// NfsPvcSnapshots returns an object that can list and get NfsPvcSnapshots.
+	NfsPvcSnapshots(namespace string) NfsPvcSnapshotNamespaceLister
+	NfsPvcSnapshotListerExpansion
+}
+
+// nfsPvcSnapshotLister implements the NfsPvcSnapshotLister interface.
+type nfsPvcSnapshotLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.NfsPvcSnapshot]
+}
+
+// NewNfsPvcSnapshotLister returns a new NfsPvcSnapshotLister.
+func NewNfsPvcSnapshotLister(indexer cache.Indexer) NfsPvcSnapshotLister {
+	return &nfsPvcSnapshotLister{listers.New[*apiv1alpha1.NfsPvcSnapshot](indexer, apiv1alpha1.Resource("nfspvcsnapshot"))}
+}
+
+// NfsPvcSnapshots returns an object that can list and get NfsPvcSnapshots.
+func (s *nfsPvcSnapshotLister) NfsPvcSnapshots(namespace string) NfsPvcSnapshotNamespaceLister {
+	return nfsPvcSnapshotNamespaceLister{listers.NewNamespaced[*apiv1alpha1.NfsPvcSnapshot](s.ResourceIndexer, namespace)}
+}
+
+// NfsPvcSnapshotNamespaceLister helps list and get NfsPvcSnapshots.
+// All objects returned here must be treated as read-only.
+type NfsPvcSnapshotNamespaceLister interface {
+	// List lists all NfsPvcSnapshots in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.NfsPvcSnapshot, err error)
+	// Get retrieves the NfsPvcSnapshot from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*apiv1alpha1.NfsPvcSnapshot, error)
+	NfsPvcSnapshotNamespaceListerExpansion
+}
+
+// nfsPvcSnapshotNamespaceLister implements the NfsPvcSnapshotNamespaceLister
+// interface.
+type nfsPvcSnapshotNamespaceLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.NfsPvcSnapshot]
+}
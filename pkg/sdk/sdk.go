@@ -0,0 +1,103 @@
+// Package sdk is the Go SDK of the NfsPvc API for the controllers of other teams: it builds
+// NfsPvcs, names the PV and the PVC the operator manages for them, and reads their readiness. The
+// typed clientset, listers and informers are generated under pkg/client.
+package sdk
+
+import (
+	"fmt"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OwnerLabel is set by the operator on the PV and the PVC of an nfspvc, to the name of the nfspvc.
+const OwnerLabel = "nfspvc.dana.io/nfspvc-owner"
+
+// Option sets an optional field of an nfspvc built by New.
+type Option func(*danaiov1alpha1.NfsPvc)
+
+// WithAccessModes sets the access modes of the nfspvc, ReadWriteMany by default.
+func WithAccessModes(accessModes ...corev1.PersistentVolumeAccessMode) Option {
+	return func(nfspvc *danaiov1alpha1.NfsPvc) {
+		nfspvc.Spec.AccessModes = accessModes
+	}
+}
+
+// WithNfsVersion sets the NFS version of the nfspvc, e.g. "4.1" or "auto", "3" by default.
+func WithNfsVersion(version string) Option {
+	return func(nfspvc *danaiov1alpha1.NfsPvc) {
+		nfspvc.Spec.NfsVersion = version
+	}
+}
+
+// WithSecurity sets the security flavor and the transport security the export is mounted with.
+func WithSecurity(security danaiov1alpha1.NfsSecurity) Option {
+	return func(nfspvc *danaiov1alpha1.NfsPvc) {
+		nfspvc.Spec.Security = &security
+	}
+}
+
+// WithLabels sets the labels of the nfspvc.
+func WithLabels(labels map[string]string) Option {
+	return func(nfspvc *danaiov1alpha1.NfsPvc) {
+		nfspvc.Labels = labels
+	}
+}
+
+// New returns an nfspvc of the namespace mounting the export of the server with the capacity. An
+// empty path lets the storage backend of the operator provision the export.
+func New(namespace, name, server, path string, capacity resource.Quantity, options ...Option) *danaiov1alpha1.NfsPvc {
+	nfspvc := &danaiov1alpha1.NfsPvc{
+		TypeMeta:   metav1.TypeMeta{APIVersion: danaiov1alpha1.GroupVersion.String(), Kind: "NfsPvc"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: danaiov1alpha1.NfsPvcSpec{
+			Server:      server,
+			Path:        path,
+			Capacity:    corev1.ResourceList{corev1.ResourceStorage: capacity},
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+			NfsVersion:  "3",
+		},
+	}
+	for _, option := range options {
+		option(nfspvc)
+	}
+	return nfspvc
+}
+
+// PVCName returns the name of the PVC of the nfspvc, in its namespace, which pods mount.
+func PVCName(nfspvc danaiov1alpha1.NfsPvc) string {
+	return nfspvc.Name
+}
+
+// PVName returns the name of the PV of the nfspvc, the existing PV it adopted if any.
+func PVName(nfspvc danaiov1alpha1.NfsPvc) string {
+	if adopted := nfspvc.Annotations[danaiov1alpha1.AdoptedPVAnnotation]; adopted != "" {
+		return adopted
+	}
+	return nfspvc.Name + "-" + nfspvc.Namespace + "-pv"
+}
+
+// IsReady returns true once the PVC of the nfspvc is bound to its PV, so that pods can mount it, and
+// the nfspvc is not being deleted.
+func IsReady(nfspvc danaiov1alpha1.NfsPvc) bool {
+	return nfspvc.DeletionTimestamp == nil && nfspvc.Status.PvcPhase == string(corev1.ClaimBound)
+}
+
+// NotReadyReason returns why the nfspvc is not ready, from its failed diagnostic checks when the
+// operator reported some, or an empty string when it is ready.
+func NotReadyReason(nfspvc danaiov1alpha1.NfsPvc) string {
+	switch {
+	case IsReady(nfspvc):
+		return ""
+	case nfspvc.DeletionTimestamp != nil:
+		return "the nfspvc is being deleted"
+	case len(nfspvc.Status.Diagnostics) > 0:
+		return nfspvc.Status.Diagnostics[0].Finding
+	case nfspvc.Status.PvcPhase == "":
+		return "the pvc is not created yet"
+	default:
+		return fmt.Sprintf("the pvc is %s", nfspvc.Status.PvcPhase)
+	}
+}
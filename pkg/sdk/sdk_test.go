@@ -0,0 +1,80 @@
+package sdk
+
+import (
+	"context"
+
+	danaiov1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/pkg/client/clientset/versioned/fake"
+	"github.com/dana-team/nfspvc-operator/pkg/client/informers/externalversions"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ = Describe("SDK", func() {
+	It("should build an nfspvc with defaults and options", func() {
+		nfspvc := New("team", "data", "nas", "/exports/data", resource.MustParse("10Gi"),
+			WithAccessModes(corev1.ReadOnlyMany), WithNfsVersion("4.1"), WithLabels(map[string]string{"app": "web"}))
+		Expect(nfspvc.Kind).To(Equal("NfsPvc"))
+		Expect(nfspvc.Spec.AccessModes).To(Equal([]corev1.PersistentVolumeAccessMode{corev1.ReadOnlyMany}))
+		Expect(nfspvc.Spec.NfsVersion).To(Equal("4.1"))
+		Expect(nfspvc.Labels).To(HaveKeyWithValue("app", "web"))
+		Expect(nfspvc.Spec.Capacity.Storage().String()).To(Equal("10Gi"))
+
+		nfspvc = New("team", "data", "nas", "", resource.MustParse("1Gi"))
+		Expect(nfspvc.Spec.AccessModes).To(Equal([]corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}))
+		Expect(nfspvc.Spec.NfsVersion).To(Equal("3"))
+		Expect(nfspvc.Spec.Security).To(BeNil())
+	})
+
+	It("should name the PV and the PVC of an nfspvc", func() {
+		nfspvc := New("team", "data", "nas", "/exports/data", resource.MustParse("1Gi"))
+		Expect(PVCName(*nfspvc)).To(Equal("data"))
+		Expect(PVName(*nfspvc)).To(Equal("data-team-pv"))
+
+		nfspvc.Annotations = map[string]string{danaiov1alpha1.AdoptedPVAnnotation: "legacy-pv"}
+		Expect(PVName(*nfspvc)).To(Equal("legacy-pv"))
+	})
+
+	It("should read the readiness of an nfspvc", func() {
+		nfspvc := New("team", "data", "nas", "/exports/data", resource.MustParse("1Gi"))
+		Expect(IsReady(*nfspvc)).To(BeFalse())
+		Expect(NotReadyReason(*nfspvc)).To(Equal("the pvc is not created yet"))
+
+		nfspvc.Status.PvcPhase = string(corev1.ClaimPending)
+		Expect(NotReadyReason(*nfspvc)).To(Equal("the pvc is Pending"))
+		nfspvc.Status.Diagnostics = []danaiov1alpha1.NfsPvcDiagnostic{{Check: "ClaimRef", Finding: "the pv is reserved for another pvc"}}
+		Expect(NotReadyReason(*nfspvc)).To(Equal("the pv is reserved for another pvc"))
+
+		nfspvc.Status = danaiov1alpha1.NfsPvcStatus{PvcPhase: string(corev1.ClaimBound), PvPhase: string(corev1.VolumeBound)}
+		Expect(IsReady(*nfspvc)).To(BeTrue())
+		Expect(NotReadyReason(*nfspvc)).To(BeEmpty())
+
+		nfspvc.DeletionTimestamp = &metav1.Time{}
+		Expect(IsReady(*nfspvc)).To(BeFalse())
+		Expect(NotReadyReason(*nfspvc)).To(Equal("the nfspvc is being deleted"))
+	})
+
+	It("should create and list nfspvcs with the generated clientset and lister", func() {
+		ctx := context.Background()
+		clientset := fake.NewSimpleClientset()
+		factory := externalversions.NewSharedInformerFactory(clientset, 0)
+		lister := factory.Nfspvc().V1alpha1().NfsPvcs().Lister()
+		informer := factory.Nfspvc().V1alpha1().NfsPvcs().Informer()
+
+		_, err := clientset.NfspvcV1alpha1().NfsPvcs("team").Create(ctx,
+			New("team", "data", "nas", "/exports/data", resource.MustParse("1Gi")), metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		stop := make(chan struct{})
+		defer close(stop)
+		factory.Start(stop)
+		Expect(cache.WaitForCacheSync(stop, informer.HasSynced)).To(BeTrue())
+		nfspvc, err := lister.NfsPvcs("team").Get("data")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(PVName(*nfspvc)).To(Equal("data-team-pv"))
+	})
+})
@@ -0,0 +1,14 @@
+package sdk
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSDK(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "SDK Suite")
+}
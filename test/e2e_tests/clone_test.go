@@ -0,0 +1,59 @@
+package e2e_tests
+
+import (
+	"context"
+
+	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/clone"
+	mock "github.com/dana-team/nfspvc-operator/test/e2e_tests/mocks"
+	"github.com/dana-team/nfspvc-operator/test/e2e_tests/testconsts"
+	utilst "github.com/dana-team/nfspvc-operator/test/e2e_tests/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("validate NFSPVC dataSource functionality", func() {
+	It("should keep the PVC pending while the export is populated", func() {
+		source := utilst.CreateNfsPvc(k8sClient, mock.CreateBaseNfsPvc())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), source))).To(Succeed())
+		})
+
+		By("creating an NFSPVC cloned from the source")
+		nfspvc := mock.CreateBaseNfsPvc()
+		nfspvc.Name = source.Name + "-clone"
+		nfspvc.Spec.Path = "/test-clone"
+		nfspvc.Spec.DataSource = &nfspvcv1alpha1.NfsPvcDataSource{Name: source.Name}
+		Expect(k8sClient.Create(context.Background(), nfspvc)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), nfspvc))).To(Succeed())
+		})
+
+		By("checking the populating condition is set")
+		Eventually(func() bool {
+			current := utilst.GetNfsPvc(k8sClient, nfspvc.Name, nfspvc.Namespace)
+			return meta.FindStatusCondition(current.Status.Conditions, nfspvcv1alpha1.PopulatingNfsPvcCondition) != nil
+		}, testconsts.Timeout, testconsts.Interval).Should(BeTrue(), "should find the Populating condition.")
+
+		By("checking the copy job exists")
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: clone.JobName(*nfspvc), Namespace: mock.NSName},
+		}
+		Eventually(func() bool {
+			return utilst.DoesResourceExist(k8sClient, job)
+		}, testconsts.Timeout, testconsts.Interval).Should(BeTrue(), "should find the copy job.")
+
+		By("checking the PVC is pending until the export is populated")
+		current := utilst.GetNfsPvc(k8sClient, nfspvc.Name, nfspvc.Namespace)
+		if meta.IsStatusConditionTrue(current.Status.Conditions, nfspvcv1alpha1.PopulatingNfsPvcCondition) {
+			pvc := &corev1.PersistentVolumeClaim{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: nfspvc.Name, Namespace: nfspvc.Namespace}, pvc)).To(Succeed())
+			Expect(pvc.Status.Phase).To(Equal(corev1.ClaimPending))
+		}
+	})
+})
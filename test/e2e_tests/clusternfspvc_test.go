@@ -0,0 +1,61 @@
+package e2e_tests
+
+import (
+	"context"
+
+	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	mock "github.com/dana-team/nfspvc-operator/test/e2e_tests/mocks"
+	"github.com/dana-team/nfspvc-operator/test/e2e_tests/testconsts"
+	utilst "github.com/dana-team/nfspvc-operator/test/e2e_tests/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("validate ClusterNFSPVC controller functionality", func() {
+	It("should materialize the NFSPVC in selected namespaces only", func() {
+		clusterNfsPvc := mock.CreateBaseClusterNfsPvc()
+		nfspvc := &nfspvcv1alpha1.NfsPvc{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterNfsPvc.Name, Namespace: mock.NSName},
+		}
+
+		By("creating the ClusterNFSPVC")
+		Expect(k8sClient.Create(context.Background(), clusterNfsPvc)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), clusterNfsPvc))).To(Succeed())
+		})
+
+		By("labeling the namespace to be selected")
+		setNamespaceLabel(mock.ClusterNFSPVCLabel, "true")
+		Eventually(func() bool {
+			return utilst.DoesResourceExist(k8sClient, nfspvc)
+		}, testconsts.Timeout, testconsts.Interval).Should(BeTrue(), "should find the materialized NFSPVC.")
+
+		By("removing the label from the namespace")
+		setNamespaceLabel(mock.ClusterNFSPVCLabel, "")
+		Eventually(func() bool {
+			return utilst.DoesResourceExist(k8sClient, nfspvc)
+		}, testconsts.Timeout, testconsts.Interval).Should(BeFalse(), "should not find the materialized NFSPVC.")
+	})
+})
+
+// setNamespaceLabel sets a label on the e2e namespace, or removes it when the value is empty.
+func setNamespaceLabel(key, value string) {
+	Eventually(func() error {
+		namespace := &corev1.Namespace{}
+		if err := k8sClient.Get(context.Background(), client.ObjectKey{Name: mock.NSName}, namespace); err != nil {
+			return err
+		}
+		if namespace.Labels == nil {
+			namespace.Labels = map[string]string{}
+		}
+		if value == "" {
+			delete(namespace.Labels, key)
+		} else {
+			namespace.Labels[key] = value
+		}
+		return k8sClient.Update(context.Background(), namespace)
+	}, testconsts.Timeout, testconsts.Interval).Should(Succeed())
+}
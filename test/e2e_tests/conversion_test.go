@@ -0,0 +1,69 @@
+package e2e_tests
+
+import (
+	"context"
+
+	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	nfspvcv1beta1 "github.com/dana-team/nfspvc-operator/api/v1beta1"
+	mock "github.com/dana-team/nfspvc-operator/test/e2e_tests/mocks"
+	"github.com/dana-team/nfspvc-operator/test/e2e_tests/testconsts"
+	utilst "github.com/dana-team/nfspvc-operator/test/e2e_tests/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("validate NFSPVC conversion between v1alpha1 and v1beta1", func() {
+	It("should serve a v1beta1 NFSPVC as v1alpha1 and bind it", func() {
+		By("creating an NFSPVC with the v1beta1 API")
+		base := mock.CreateBaseNfsPvc()
+		base.Name += "-v1beta1"
+		nfspvc := &nfspvcv1beta1.NfsPvc{
+			ObjectMeta: base.ObjectMeta,
+			Spec: nfspvcv1beta1.NfsPvcSpec{
+				Server:       nfspvcv1beta1.NfsServerReference{Host: base.Spec.Server, Path: base.Spec.Path},
+				Capacity:     resource.MustParse("5Gi"),
+				AccessModes:  []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+				MountOptions: nfspvcv1beta1.NfsMountOptions{NfsVersion: "4.1"},
+			},
+		}
+		Expect(k8sClient.Create(context.Background(), nfspvc)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), nfspvc))).To(Succeed())
+		})
+
+		By("reading it with the v1alpha1 API")
+		converted := utilst.GetNfsPvc(k8sClient, nfspvc.Name, nfspvc.Namespace)
+		Expect(converted.Spec.Server).To(Equal(base.Spec.Server))
+		Expect(converted.Spec.Path).To(Equal(base.Spec.Path))
+		Expect(converted.Spec.NfsVersion).To(Equal("4.1"))
+		Expect(converted.Spec.Capacity.Storage().String()).To(Equal("5Gi"))
+
+		By("checking the operator binds it")
+		Eventually(func() string {
+			return utilst.GetNfsPvc(k8sClient, nfspvc.Name, nfspvc.Namespace).Status.PvcPhase
+		}, testconsts.Timeout, testconsts.Interval).Should(Equal(string(corev1.ClaimBound)), "should bind the PVC of the NFSPVC.")
+
+		By("reading its status with the v1beta1 API")
+		current := &nfspvcv1beta1.NfsPvc{}
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(nfspvc), current)).To(Succeed())
+		Expect(current.Status.PVCPhase).To(Equal(corev1.ClaimBound))
+	})
+
+	It("should keep the v1alpha1 capacity that v1beta1 cannot represent", func() {
+		nfspvc := mock.CreateBaseNfsPvc()
+		nfspvc.Spec.Capacity[corev1.ResourceEphemeralStorage] = resource.MustParse("1Gi")
+		nfspvc = utilst.CreateNfsPvc(k8sClient, nfspvc)
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), nfspvc))).To(Succeed())
+		})
+
+		current := &nfspvcv1beta1.NfsPvc{}
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(nfspvc), current)).To(Succeed())
+		Expect(current.Spec.Capacity.String()).To(Equal("5Gi"))
+		Expect(current.Annotations).To(HaveKey(nfspvcv1alpha1.ConversionDataAnnotation))
+		Expect(utilst.GetNfsPvc(k8sClient, nfspvc.Name, nfspvc.Namespace).Spec.Capacity).To(HaveLen(2))
+	})
+})
@@ -0,0 +1,50 @@
+package e2e_tests
+
+import (
+	"context"
+
+	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/createpath"
+	mock "github.com/dana-team/nfspvc-operator/test/e2e_tests/mocks"
+	"github.com/dana-team/nfspvc-operator/test/e2e_tests/testconsts"
+	utilst "github.com/dana-team/nfspvc-operator/test/e2e_tests/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("validate NFSPVC createPath functionality", func() {
+	It("should keep the PVC pending until the path is created", func() {
+		By("creating an NFSPVC whose path is created by the operator")
+		nfspvc := mock.CreateBaseNfsPvc()
+		nfspvc.Spec.Path = "/test-createpath"
+		nfspvc.Spec.CreatePath = &nfspvcv1alpha1.NfsPvcCreatePath{UID: ptr.To(int64(1000)), GID: ptr.To(int64(1000)), Mode: "0775"}
+		nfspvc = utilst.CreateNfsPvc(k8sClient, nfspvc)
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), nfspvc))).To(Succeed())
+		})
+
+		By("checking the PathReady condition is set")
+		Eventually(func() bool {
+			current := utilst.GetNfsPvc(k8sClient, nfspvc.Name, nfspvc.Namespace)
+			return meta.FindStatusCondition(current.Status.Conditions, nfspvcv1alpha1.PathReadyNfsPvcCondition) != nil
+		}, testconsts.Timeout, testconsts.Interval).Should(BeTrue(), "should find the PathReady condition.")
+
+		By("checking the PVC is pending and the job exists until the path is created")
+		current := utilst.GetNfsPvc(k8sClient, nfspvc.Name, nfspvc.Namespace)
+		if !meta.IsStatusConditionTrue(current.Status.Conditions, nfspvcv1alpha1.PathReadyNfsPvcCondition) {
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: createpath.JobName(*nfspvc), Namespace: mock.NSName},
+			}
+			Expect(utilst.DoesResourceExist(k8sClient, job)).To(BeTrue())
+			pvc := &corev1.PersistentVolumeClaim{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: nfspvc.Name, Namespace: nfspvc.Namespace}, pvc)).To(Succeed())
+			Expect(pvc.Status.Phase).To(Equal(corev1.ClaimPending))
+		}
+	})
+})
@@ -68,6 +68,11 @@ var _ = Describe("validate NFSPVC controller functionality", func() {
 			return nfspvc.Status.PvcPhase == string(corev1.ClaimBound) && nfspvc.Status.PvPhase == string(corev1.VolumeBound)
 		}, testconsts.Timeout, testconsts.Interval).Should(BeTrue(), "PV and PVC Phases should be bound.")
 
+		By("checking that no diagnostic check fails")
+		Eventually(func() []nfspvcv1alpha1.NfsPvcDiagnostic {
+			return utilst.GetNfsPvc(k8sClient, desiredNfsPvc.Name, desiredNfsPvc.Namespace).Status.Diagnostics
+		}, testconsts.Timeout, testconsts.Interval).Should(BeEmpty(), "a bound NFSPVC should have no diagnostics.")
+
 		By("deleting the NFSPVC")
 		utilst.DeleteNfsPvc(k8sClient, desiredNfsPvc)
 
@@ -12,6 +12,23 @@ import (
 var (
 	NSName     = "nfspvc-e2e-tests"
 	NFSPVCName = "nfspvc-default-test"
+
+	NFSPVCSetName = "nfspvcset-default-test"
+
+	ClusterNFSPVCName  = "clusternfspvc-default-test"
+	ClusterNFSPVCLabel = "nfspvc.dana.io/e2e-cluster-nfspvc"
+
+	NFSPVCSnapshotName = "nfspvcsnapshot-default-test"
+
+	NFSPVCBackupScheduleName  = "backup-e2e"
+	NFSPVCBackupScheduleLabel = "nfspvc.dana.io/e2e-backup"
+
+	NFSPVCMigrationName = "nfspvcmigration-default-test"
+
+	NFSServerFailoverName  = "nfsserverfailover-default-test"
+	NFSServerFailoverLabel = "nfspvc.dana.io/e2e-failover"
+
+	NFSExportPolicyName = "nfsexportpolicy-default-test"
 )
 
 func CreateBaseNfsPvc() *nfspvcv1alpha1.NfsPvc {
@@ -53,3 +70,158 @@ func CreateBasePVC(pvcName string) *corev1.PersistentVolumeClaim {
 		},
 	}
 }
+
+func CreateBaseNfsPvcSet(replicas int32) *nfspvcv1alpha1.NfsPvcSet {
+	templateSpec := templateOf(CreateBaseNfsPvc().Spec)
+	templateSpec.Path = "/test/{{.Ordinal}}"
+	return &nfspvcv1alpha1.NfsPvcSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NfsPvcSet",
+			APIVersion: "nfspvc.dana.io/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NFSPVCSetName,
+			Namespace: NSName,
+		},
+		Spec: nfspvcv1alpha1.NfsPvcSetSpec{
+			Replicas: &replicas,
+			RetentionPolicy: &nfspvcv1alpha1.NfsPvcSetRetentionPolicy{
+				WhenScaled:  nfspvcv1alpha1.DeleteNfsPvcSetRetentionPolicyType,
+				WhenDeleted: nfspvcv1alpha1.DeleteNfsPvcSetRetentionPolicyType,
+			},
+			Template: nfspvcv1alpha1.NfsPvcTemplateSpec{
+				Spec: templateSpec,
+			},
+		},
+	}
+}
+
+func CreateBaseClusterNfsPvc() *nfspvcv1alpha1.ClusterNfsPvc {
+	return &nfspvcv1alpha1.ClusterNfsPvc{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ClusterNfsPvc",
+			APIVersion: "nfspvc.dana.io/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ClusterNFSPVCName,
+		},
+		Spec: nfspvcv1alpha1.ClusterNfsPvcSpec{
+			NamespaceSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{ClusterNFSPVCLabel: "true"},
+			},
+			Template: nfspvcv1alpha1.NfsPvcTemplateSpec{
+				Spec: templateOf(CreateBaseNfsPvc().Spec),
+			},
+		},
+	}
+}
+
+func CreateBaseNfsPvcSnapshot(source string) *nfspvcv1alpha1.NfsPvcSnapshot {
+	return &nfspvcv1alpha1.NfsPvcSnapshot{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NfsPvcSnapshot",
+			APIVersion: "nfspvc.dana.io/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NFSPVCSnapshotName,
+			Namespace: NSName,
+		},
+		Spec: nfspvcv1alpha1.NfsPvcSnapshotSpec{
+			Source: source,
+			Destination: nfspvcv1alpha1.NfsPvcSnapshotDestination{
+				Server: "vs-koki-backup",
+				Path:   "/backups",
+			},
+		},
+	}
+}
+
+func CreateBaseNfsPvcBackupSchedule() *nfspvcv1alpha1.NfsPvcBackupSchedule {
+	return &nfspvcv1alpha1.NfsPvcBackupSchedule{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NfsPvcBackupSchedule",
+			APIVersion: "nfspvc.dana.io/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NFSPVCBackupScheduleName,
+			Namespace: NSName,
+		},
+		Spec: nfspvcv1alpha1.NfsPvcBackupScheduleSpec{
+			Schedule: "* * * * *",
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{NFSPVCBackupScheduleLabel: "true"},
+			},
+			Destination: nfspvcv1alpha1.NfsPvcSnapshotDestination{
+				Server: "vs-koki-backup",
+				Path:   "/backups",
+			},
+			Retention: 1,
+		},
+	}
+}
+
+func CreateBaseNfsPvcMigration(nfspvcName string) *nfspvcv1alpha1.NfsPvcMigration {
+	return &nfspvcv1alpha1.NfsPvcMigration{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NfsPvcMigration",
+			APIVersion: "nfspvc.dana.io/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NFSPVCMigrationName,
+			Namespace: NSName,
+		},
+		Spec: nfspvcv1alpha1.NfsPvcMigrationSpec{
+			NfsPvcName: nfspvcName,
+			Server:     "vs-koki-new",
+			Path:       "/test-migrated",
+		},
+	}
+}
+
+func CreateBaseNfsServerFailover() *nfspvcv1alpha1.NfsServerFailover {
+	return &nfspvcv1alpha1.NfsServerFailover{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NfsServerFailover",
+			APIVersion: "nfspvc.dana.io/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: NFSServerFailoverName,
+		},
+		Spec: nfspvcv1alpha1.NfsServerFailoverSpec{
+			From:     "vs-koki",
+			To:       "vs-koki-replica",
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{NFSServerFailoverLabel: "true"}},
+		},
+	}
+}
+
+func CreateBaseNfsExportPolicy() *nfspvcv1alpha1.NfsExportPolicy {
+	return &nfspvcv1alpha1.NfsExportPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NfsExportPolicy",
+			APIVersion: "nfspvc.dana.io/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: NFSExportPolicyName,
+		},
+		Spec: nfspvcv1alpha1.NfsExportPolicySpec{
+			PolicyName: "e2e-nodes",
+			DryRun:     true,
+		},
+	}
+}
+
+// templateOf returns the template of the NfsPvcs of a set or a ClusterNfsPvc generating the given spec.
+func templateOf(spec nfspvcv1alpha1.NfsPvcSpec) nfspvcv1alpha1.NfsPvcSpecTemplate {
+	return nfspvcv1alpha1.NfsPvcSpecTemplate{
+		AccessModes:       spec.AccessModes,
+		Capacity:          spec.Capacity,
+		Path:              spec.Path,
+		Server:            spec.Server,
+		NfsVersion:        spec.NfsVersion,
+		Security:          spec.Security,
+		DataSource:        spec.DataSource,
+		CreatePath:        spec.CreatePath,
+		MountInstructions: spec.MountInstructions,
+	}
+}
@@ -0,0 +1,54 @@
+package e2e_tests
+
+import (
+	"context"
+
+	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/mountinstructions"
+	mock "github.com/dana-team/nfspvc-operator/test/e2e_tests/mocks"
+	"github.com/dana-team/nfspvc-operator/test/e2e_tests/testconsts"
+	utilst "github.com/dana-team/nfspvc-operator/test/e2e_tests/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("validate NFSPVC mountInstructions functionality", func() {
+	It("should render the mount instructions and regenerate them when the spec changes", func() {
+		By("creating an NFSPVC with mount instructions")
+		nfspvc := mock.CreateBaseNfsPvc()
+		nfspvc.Spec.MountInstructions = &nfspvcv1alpha1.NfsPvcMountInstructions{MountPoint: "/mnt/e2e", Options: []string{"_netdev"}}
+		nfspvc = utilst.CreateNfsPvc(k8sClient, nfspvc)
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), nfspvc))).To(Succeed())
+		})
+		key := client.ObjectKey{Name: mountinstructions.ConfigMapName(*nfspvc), Namespace: nfspvc.Namespace}
+
+		By("checking the ConfigMap holds the fstab line")
+		Eventually(func() string {
+			configMap := corev1.ConfigMap{}
+			_ = k8sClient.Get(context.Background(), key, &configMap)
+			return configMap.Data[mountinstructions.FstabKey]
+		}, testconsts.Timeout, testconsts.Interval).Should(ContainSubstring(nfspvc.Spec.Server+":"+nfspvc.Spec.Path+" /mnt/e2e nfs "), "should render the fstab line.")
+
+		By("changing the mount point")
+		current := utilst.GetNfsPvc(k8sClient, nfspvc.Name, nfspvc.Namespace)
+		current.Spec.MountInstructions.MountPoint = "/srv/e2e"
+		Expect(utilst.UpdateResource(k8sClient, current)).To(Succeed())
+		Eventually(func() string {
+			configMap := corev1.ConfigMap{}
+			_ = k8sClient.Get(context.Background(), key, &configMap)
+			return configMap.Data[mountinstructions.SystemdUnitNameKey]
+		}, testconsts.Timeout, testconsts.Interval).Should(Equal("srv-e2e.mount"), "should regenerate the mount instructions.")
+
+		By("removing the mount instructions")
+		current = utilst.GetNfsPvc(k8sClient, nfspvc.Name, nfspvc.Namespace)
+		current.Spec.MountInstructions = nil
+		Expect(utilst.UpdateResource(k8sClient, current)).To(Succeed())
+		Eventually(func() bool {
+			return utilst.DoesResourceExist(k8sClient, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}})
+		}, testconsts.Timeout, testconsts.Interval).Should(BeFalse(), "should delete the ConfigMap.")
+	})
+})
@@ -0,0 +1,44 @@
+package e2e_tests
+
+import (
+	"context"
+
+	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	mock "github.com/dana-team/nfspvc-operator/test/e2e_tests/mocks"
+	"github.com/dana-team/nfspvc-operator/test/e2e_tests/testconsts"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("validate NFSExportPolicy controller functionality", func() {
+	It("should report the addresses of the nodes and the InSync condition", func() {
+		By("creating a dry-run NFSExportPolicy")
+		policy := mock.CreateBaseNfsExportPolicy()
+		Expect(k8sClient.Create(context.Background(), policy)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), policy))).To(Succeed())
+		})
+
+		By("checking the InSync condition is set")
+		Eventually(func() bool {
+			if err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(policy), policy); err != nil {
+				return false
+			}
+			return meta.FindStatusCondition(policy.Status.Conditions, nfspvcv1alpha1.InSyncNfsExportPolicyCondition) != nil
+		}, testconsts.Timeout, testconsts.Interval).Should(BeTrue(), "should find the InSync condition.")
+
+		By("checking the status lists the internal addresses of the nodes")
+		nodes := corev1.NodeList{}
+		Expect(k8sClient.List(context.Background(), &nodes)).To(Succeed())
+		for _, node := range nodes.Items {
+			for _, address := range node.Status.Addresses {
+				if address.Type == corev1.NodeInternalIP {
+					Expect(policy.Status.Clients).To(ContainElement(address.Address))
+				}
+			}
+		}
+	})
+})
@@ -0,0 +1,47 @@
+package e2e_tests
+
+import (
+	"context"
+	"time"
+
+	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/nfspvcbackupschedule"
+	mock "github.com/dana-team/nfspvc-operator/test/e2e_tests/mocks"
+	"github.com/dana-team/nfspvc-operator/test/e2e_tests/testconsts"
+	utilst "github.com/dana-team/nfspvc-operator/test/e2e_tests/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("validate NFSPVCBackupSchedule controller functionality", func() {
+	It("should back up the selected NFSPVCs on schedule", func() {
+		base := mock.CreateBaseNfsPvc()
+		base.Labels = map[string]string{mock.NFSPVCBackupScheduleLabel: "true"}
+		nfspvc := utilst.CreateNfsPvc(k8sClient, base)
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), nfspvc))).To(Succeed())
+		})
+
+		By("creating the NFSPVCBackupSchedule")
+		schedule := mock.CreateBaseNfsPvcBackupSchedule()
+		Expect(k8sClient.Create(context.Background(), schedule)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), schedule))).To(Succeed())
+			Expect(client.IgnoreNotFound(k8sClient.DeleteAllOf(context.Background(), &nfspvcv1alpha1.NfsPvcSnapshot{},
+				client.InNamespace(mock.NSName), client.MatchingLabels{nfspvcbackupschedule.ScheduleLabel: schedule.Name}))).To(Succeed())
+		})
+
+		By("checking a backup of the NFSPVC is created")
+		Eventually(func() []string {
+			snapshotList := &nfspvcv1alpha1.NfsPvcSnapshotList{}
+			Expect(k8sClient.List(context.Background(), snapshotList, client.InNamespace(mock.NSName),
+				client.MatchingLabels{nfspvcbackupschedule.ScheduleLabel: schedule.Name})).To(Succeed())
+			var sources []string
+			for _, snapshot := range snapshotList.Items {
+				sources = append(sources, snapshot.Spec.Source)
+			}
+			return sources
+		}, testconsts.Timeout+time.Minute, testconsts.Interval).Should(ContainElement(nfspvc.Name), "should find a backup of the NFSPVC.")
+	})
+})
@@ -0,0 +1,49 @@
+package e2e_tests
+
+import (
+	"context"
+
+	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/migration"
+	mock "github.com/dana-team/nfspvc-operator/test/e2e_tests/mocks"
+	"github.com/dana-team/nfspvc-operator/test/e2e_tests/testconsts"
+	utilst "github.com/dana-team/nfspvc-operator/test/e2e_tests/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("validate NFSPVCMigration controller functionality", func() {
+	It("should claim the NFSPVC and launch the copy job", func() {
+		nfspvc := utilst.CreateNfsPvc(k8sClient, mock.CreateBaseNfsPvc())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), nfspvc))).To(Succeed())
+		})
+
+		By("creating the NFSPVCMigration")
+		nfspvcMigration := mock.CreateBaseNfsPvcMigration(nfspvc.Name)
+		Expect(k8sClient.Create(context.Background(), nfspvcMigration)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), nfspvcMigration))).To(Succeed())
+		})
+
+		By("checking the NFSPVC is annotated with the migration")
+		Eventually(func() string {
+			return utilst.GetNfsPvc(k8sClient, nfspvc.Name, nfspvc.Namespace).Annotations[nfspvcv1alpha1.MigrationAnnotation]
+		}, testconsts.Timeout, testconsts.Interval).Should(Equal(nfspvcMigration.Name), "should find the migration annotation.")
+
+		By("checking the copy job exists")
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: migration.JobName(*nfspvcMigration, nfspvcv1alpha1.CopyNfsPvcMigrationStep), Namespace: mock.NSName},
+		}
+		Eventually(func() bool {
+			return utilst.DoesResourceExist(k8sClient, job)
+		}, testconsts.Timeout, testconsts.Interval).Should(BeTrue(), "should find the copy job.")
+
+		By("checking the NFSPVC still uses its server while the data is copied")
+		current := utilst.GetNfsPvc(k8sClient, nfspvc.Name, nfspvc.Namespace)
+		Expect(current.Spec.Server).To(Equal(nfspvc.Spec.Server))
+	})
+})
@@ -0,0 +1,67 @@
+package e2e_tests
+
+import (
+	"context"
+	"fmt"
+
+	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	mock "github.com/dana-team/nfspvc-operator/test/e2e_tests/mocks"
+	"github.com/dana-team/nfspvc-operator/test/e2e_tests/testconsts"
+	utilst "github.com/dana-team/nfspvc-operator/test/e2e_tests/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("validate NFSPVCSet controller functionality", func() {
+	It("should generate, scale and delete NFSPVCs from the template", func() {
+		set := mock.CreateBaseNfsPvcSet(3)
+
+		By("creating the NFSPVCSet")
+		Expect(k8sClient.Create(context.Background(), set)).To(Succeed())
+
+		By("checking the NFSPVCs are generated with a rendered path")
+		for ordinal := 0; ordinal < 3; ordinal++ {
+			nfspvc := utilst.GetNfsPvc(k8sClient, fmt.Sprintf("%s-%d", set.Name, ordinal), set.Namespace)
+			Expect(nfspvc.Spec.Path).To(Equal(fmt.Sprintf("/test/%d", ordinal)))
+			Expect(metav1.IsControlledBy(nfspvc, set)).To(BeTrue(), "NFSPVC should be owned by the set.")
+		}
+
+		By("scaling the NFSPVCSet down")
+		Eventually(func() error {
+			current := &nfspvcv1alpha1.NfsPvcSet{}
+			if err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(set), current); err != nil {
+				return err
+			}
+			replicas := int32(1)
+			current.Spec.Replicas = &replicas
+			return k8sClient.Update(context.Background(), current)
+		}, testconsts.Timeout, testconsts.Interval).Should(Succeed())
+
+		By("checking the scaled down NFSPVCs are deleted")
+		Eventually(func() bool {
+			nfspvc := &nfspvcv1alpha1.NfsPvc{
+				ObjectMeta: metav1.ObjectMeta{Name: set.Name + "-2", Namespace: set.Namespace},
+			}
+			return utilst.DoesResourceExist(k8sClient, nfspvc)
+		}, testconsts.Timeout, testconsts.Interval).Should(BeFalse(), "should not find the scaled down NFSPVC.")
+
+		By("deleting the NFSPVCSet")
+		Expect(k8sClient.Delete(context.Background(), set)).To(Succeed())
+		Eventually(func() bool {
+			nfspvc := &nfspvcv1alpha1.NfsPvc{
+				ObjectMeta: metav1.ObjectMeta{Name: set.Name + "-0", Namespace: set.Namespace},
+			}
+			return utilst.DoesResourceExist(k8sClient, nfspvc)
+		}, testconsts.Timeout, testconsts.Interval).Should(BeFalse(), "should not find the generated NFSPVC.")
+	})
+
+	It("should deny a template that does not render", func() {
+		set := mock.CreateBaseNfsPvcSet(1)
+		set.Name = set.Name + "-invalid"
+		set.Spec.Template.Spec.Path = "/test/{{.Unknown}}"
+
+		Expect(utilst.CreateResource(k8sClient, set)).Should(BeFalse())
+	})
+})
@@ -0,0 +1,48 @@
+package e2e_tests
+
+import (
+	"context"
+
+	"github.com/dana-team/nfspvc-operator/internal/controller/nfspvcsnapshot"
+	mock "github.com/dana-team/nfspvc-operator/test/e2e_tests/mocks"
+	"github.com/dana-team/nfspvc-operator/test/e2e_tests/testconsts"
+	utilst "github.com/dana-team/nfspvc-operator/test/e2e_tests/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("validate NFSPVCSnapshot controller functionality", func() {
+	It("should launch a copy job into the destination", func() {
+		nfspvc := utilst.CreateNfsPvc(k8sClient, mock.CreateBaseNfsPvc())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), nfspvc))).To(Succeed())
+		})
+
+		By("creating the NFSPVCSnapshot")
+		snapshot := mock.CreateBaseNfsPvcSnapshot(nfspvc.Name)
+		Expect(k8sClient.Create(context.Background(), snapshot)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), snapshot))).To(Succeed())
+		})
+
+		By("checking the destination PVC exists")
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: nfspvcsnapshot.DestinationClaim(*snapshot), Namespace: mock.NSName},
+		}
+		Eventually(func() bool {
+			return utilst.DoesResourceExist(k8sClient, pvc)
+		}, testconsts.Timeout, testconsts.Interval).Should(BeTrue(), "should find the destination PVC.")
+
+		By("checking the copy job exists")
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: nfspvcsnapshot.JobName(*snapshot), Namespace: mock.NSName},
+		}
+		Eventually(func() bool {
+			return utilst.DoesResourceExist(k8sClient, job)
+		}, testconsts.Timeout, testconsts.Interval).Should(BeTrue(), "should find the copy job.")
+	})
+})
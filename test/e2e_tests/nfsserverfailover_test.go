@@ -0,0 +1,55 @@
+package e2e_tests
+
+import (
+	"context"
+
+	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	mock "github.com/dana-team/nfspvc-operator/test/e2e_tests/mocks"
+	"github.com/dana-team/nfspvc-operator/test/e2e_tests/testconsts"
+	utilst "github.com/dana-team/nfspvc-operator/test/e2e_tests/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("validate NFSServerFailover controller functionality", func() {
+	It("should move the selected NFSPVCs to the new server and back", func() {
+		baseNfsPvc := mock.CreateBaseNfsPvc()
+		baseNfsPvc.Labels = map[string]string{mock.NFSServerFailoverLabel: "true"}
+		nfspvc := utilst.CreateNfsPvc(k8sClient, baseNfsPvc)
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), nfspvc))).To(Succeed())
+		})
+
+		By("creating the NFSServerFailover")
+		failover := mock.CreateBaseNfsServerFailover()
+		Expect(k8sClient.Create(context.Background(), failover)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), failover))).To(Succeed())
+		})
+
+		By("checking the PV uses the new server")
+		Eventually(func() string {
+			pv := &corev1.PersistentVolume{}
+			if err := k8sClient.Get(context.Background(), client.ObjectKey{Name: resources.PVName(*nfspvc)}, pv); err != nil || pv.Spec.NFS == nil {
+				return ""
+			}
+			return pv.Spec.NFS.Server
+		}, testconsts.Timeout, testconsts.Interval).Should(Equal(failover.Spec.To), "should find the PV of the new server.")
+		Eventually(func() map[string]string {
+			return utilst.GetNfsPvc(k8sClient, nfspvc.Name, nfspvc.Namespace).Annotations
+		}, testconsts.Timeout, testconsts.Interval).ShouldNot(HaveKey(nfspvcv1alpha1.FailoverAnnotation), "should remove the failover annotation once moved.")
+		current := utilst.GetNfsPvc(k8sClient, nfspvc.Name, nfspvc.Namespace)
+		Expect(current.Annotations).To(HaveKeyWithValue(nfspvcv1alpha1.FailedOverAnnotation, failover.Name))
+
+		By("failing back")
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(failover), failover)).To(Succeed())
+		failover.Spec.Failback = true
+		Expect(k8sClient.Update(context.Background(), failover)).To(Succeed())
+		Eventually(func() string {
+			return utilst.GetNfsPvc(k8sClient, nfspvc.Name, nfspvc.Namespace).Spec.Server
+		}, testconsts.Timeout, testconsts.Interval).Should(Equal(failover.Spec.From), "should move the NFSPVC back.")
+	})
+})
@@ -0,0 +1,47 @@
+package e2e_tests
+
+import (
+	"context"
+
+	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/dana-team/nfspvc-operator/internal/controller/provision"
+	"github.com/dana-team/nfspvc-operator/internal/controller/resources"
+	mock "github.com/dana-team/nfspvc-operator/test/e2e_tests/mocks"
+	"github.com/dana-team/nfspvc-operator/test/e2e_tests/testconsts"
+	utilst "github.com/dana-team/nfspvc-operator/test/e2e_tests/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("validate NFSPVC provisioning functionality", func() {
+	It("should not create a PV for an NFSPVC without path until its export is provisioned", func() {
+		By("creating an NFSPVC without path")
+		nfspvc := mock.CreateBaseNfsPvc()
+		nfspvc.Spec.Path = ""
+		nfspvc = utilst.CreateNfsPvc(k8sClient, nfspvc)
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), nfspvc))).To(Succeed())
+		})
+
+		By("checking the Provisioned condition is set")
+		Eventually(func() bool {
+			current := utilst.GetNfsPvc(k8sClient, nfspvc.Name, nfspvc.Namespace)
+			return meta.FindStatusCondition(current.Status.Conditions, nfspvcv1alpha1.ProvisionedNfsPvcCondition) != nil
+		}, testconsts.Timeout, testconsts.Interval).Should(BeTrue(), "should find the Provisioned condition.")
+
+		By("checking the PV only exists once the export is provisioned")
+		current := utilst.GetNfsPvc(k8sClient, nfspvc.Name, nfspvc.Namespace)
+		condition := meta.FindStatusCondition(current.Status.Conditions, nfspvcv1alpha1.ProvisionedNfsPvcCondition)
+		if condition.Reason == provision.ProvisionedReason {
+			Expect(current.Status.Path).NotTo(BeEmpty())
+			return
+		}
+		Expect(current.Status.Path).To(BeEmpty())
+		pv := &corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: resources.PVName(*nfspvc)}}
+		Expect(utilst.DoesResourceExist(k8sClient, pv)).To(BeFalse())
+	})
+})
@@ -0,0 +1,65 @@
+package e2e_tests
+
+import (
+	"context"
+
+	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	mock "github.com/dana-team/nfspvc-operator/test/e2e_tests/mocks"
+	"github.com/dana-team/nfspvc-operator/test/e2e_tests/testconsts"
+	utilst "github.com/dana-team/nfspvc-operator/test/e2e_tests/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("validate NFSPVC security modes", func() {
+	It("should render the security modes as mount options", func() {
+		baseNfsPvc := mock.CreateBaseNfsPvc()
+		baseNfsPvc.Spec.NfsVersion = "4.2"
+		baseNfsPvc.Spec.Security = &nfspvcv1alpha1.NfsSecurity{
+			Sec:               nfspvcv1alpha1.Krb5pNfsSecurityFlavor,
+			TransportSecurity: nfspvcv1alpha1.TLSNfsTransportSecurity,
+		}
+		desiredNfsPvc := utilst.CreateNfsPvc(k8sClient, baseNfsPvc)
+
+		By("checking the PV mount options")
+		Eventually(func() []string {
+			pv := &corev1.PersistentVolume{}
+			if err := k8sClient.Get(context.Background(), client.ObjectKey{Name: desiredNfsPvc.Name + "-" + desiredNfsPvc.Namespace + "-pv"}, pv); err != nil {
+				return nil
+			}
+			return pv.Spec.MountOptions
+		}, testconsts.Timeout, testconsts.Interval).Should(ContainElements("nfsvers=4.2", "sec=krb5p", "xprtsec=tls"))
+
+		utilst.DeleteNfsPvc(k8sClient, desiredNfsPvc)
+	})
+
+	It("should deny transport security with NFS version 3", func() {
+		baseNfsPvc := mock.CreateBaseNfsPvc()
+		baseNfsPvc.Name = baseNfsPvc.Name + "-tls-v3"
+		baseNfsPvc.Spec.NfsVersion = "3"
+		baseNfsPvc.Spec.Security = &nfspvcv1alpha1.NfsSecurity{
+			TransportSecurity: nfspvcv1alpha1.TLSNfsTransportSecurity,
+		}
+
+		Expect(utilst.CreateResource(k8sClient, baseNfsPvc)).Should(BeFalse())
+	})
+
+	It("should deny an update to NFS version 3 with transport security", func() {
+		baseNfsPvc := mock.CreateBaseNfsPvc()
+		baseNfsPvc.Name = baseNfsPvc.Name + "-tls-update"
+		baseNfsPvc.Spec.NfsVersion = "4.2"
+		baseNfsPvc.Spec.Security = &nfspvcv1alpha1.NfsSecurity{
+			TransportSecurity: nfspvcv1alpha1.TLSNfsTransportSecurity,
+		}
+		desiredNfsPvc := utilst.CreateNfsPvc(k8sClient, baseNfsPvc)
+
+		By("updating the NFS version to 3")
+		nfspvc := utilst.GetNfsPvc(k8sClient, desiredNfsPvc.Name, desiredNfsPvc.Namespace)
+		nfspvc.Spec.NfsVersion = "3"
+		Expect(utilst.UpdateResource(k8sClient, nfspvc)).Should(HaveOccurred())
+
+		utilst.DeleteNfsPvc(k8sClient, desiredNfsPvc)
+	})
+})
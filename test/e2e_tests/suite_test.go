@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	nfspvcv1beta1 "github.com/dana-team/nfspvc-operator/api/v1beta1"
 	mock "github.com/dana-team/nfspvc-operator/test/e2e_tests/mocks"
 	"github.com/dana-team/nfspvc-operator/test/e2e_tests/testconsts"
 	utilst "github.com/dana-team/nfspvc-operator/test/e2e_tests/utils"
@@ -31,6 +32,7 @@ func newScheme() *runtime.Scheme {
 	s := runtime.NewScheme()
 	_ = corev1.AddToScheme(s)
 	_ = nfspvcv1alpha1.AddToScheme(s)
+	_ = nfspvcv1beta1.AddToScheme(s)
 
 	_ = scheme.AddToScheme(s)
 	return s
@@ -3,6 +3,7 @@ package e2e_tests
 import (
 	"context"
 
+	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
 	mock "github.com/dana-team/nfspvc-operator/test/e2e_tests/mocks"
 	"github.com/dana-team/nfspvc-operator/test/e2e_tests/testconsts"
 	utilst "github.com/dana-team/nfspvc-operator/test/e2e_tests/utils"
@@ -31,6 +32,26 @@ var _ = Describe("validate NFSPVC webhook functionality ", func() {
 		Expect(utilst.CreateResource(k8sClient, baseNfsPvc)).Should(BeFalse())
 	})
 
+	It("should allow an NFSPVC adopting the existing PVC of its PV", func() {
+		baseNfsPvc := mock.CreateBaseNfsPvc()
+		pvc := mock.CreateBasePVC(baseNfsPvc.Name)
+		pvc.Spec.VolumeName = "legacy-" + baseNfsPvc.Name
+		Expect(utilst.CreateResource(k8sClient, pvc)).Should(BeTrue())
+
+		By("creating NFSPVC adopting another PV")
+		baseNfsPvc.Annotations = map[string]string{nfspvcv1alpha1.AdoptedPVAnnotation: "another-pv"}
+		Expect(utilst.CreateResource(k8sClient, baseNfsPvc.DeepCopy())).Should(BeFalse())
+
+		By("creating NFSPVC adopting the PV of the PVC")
+		baseNfsPvc.Annotations[nfspvcv1alpha1.AdoptedPVAnnotation] = pvc.Spec.VolumeName
+		Expect(utilst.CreateResource(k8sClient, baseNfsPvc)).Should(BeTrue())
+
+		By("changing the adopted PV")
+		nfspvcCopy := utilst.GetNfsPvc(k8sClient, baseNfsPvc.Name, baseNfsPvc.Namespace).DeepCopy()
+		nfspvcCopy.Annotations[nfspvcv1alpha1.AdoptedPVAnnotation] = "another-pv"
+		Expect(utilst.UpdateResource(k8sClient, nfspvcCopy)).ShouldNot(Succeed())
+	})
+
 	It("should deny editing immutable fields", func() {
 		baseNfsPvc := mock.CreateBaseNfsPvc()
 		desiredNfsPvc := utilst.CreateNfsPvc(k8sClient, baseNfsPvc)
@@ -58,5 +79,17 @@ var _ = Describe("validate NFSPVC webhook functionality ", func() {
 		nfspvcCopy.Spec.Server = "vs-updated"
 		err = utilst.UpdateResource(k8sClient, nfspvcCopy)
 		Expect(err).To(HaveOccurred())
+
+		By("changing NFSPVC server with a migration annotation naming no migration")
+		nfspvcCopy = desiredNfsPvc.DeepCopy()
+		nfspvcCopy.Annotations = map[string]string{nfspvcv1alpha1.MigrationAnnotation: "missing"}
+		nfspvcCopy.Spec.Server = "vs-updated"
+		err = utilst.UpdateResource(k8sClient, nfspvcCopy)
+		Expect(err).To(HaveOccurred())
+	})
+	It("should deny an NFSPVC that is its own dataSource", func() {
+		baseNfsPvc := mock.CreateBaseNfsPvc()
+		baseNfsPvc.Spec.DataSource = &nfspvcv1alpha1.NfsPvcDataSource{Name: baseNfsPvc.Name}
+		Expect(utilst.CreateResource(k8sClient, baseNfsPvc)).Should(BeFalse())
 	})
 })